@@ -2,13 +2,15 @@ module github.com/heroku/terraform-provider-heroku/v4
 
 require (
 	github.com/bgentry/go-netrc v0.0.0-20140422174119-9fd32a8b3d3d
+	github.com/cenkalti/backoff v2.1.1+incompatible
 	github.com/google/uuid v1.1.1
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/go-uuid v1.0.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.5.0
 	github.com/heroku/heroku-go/v5 v5.3.0
 	github.com/mitchellh/go-homedir v1.1.0
-	github.com/verybluebot/tarinator-go v0.0.0-20190613183509-5ab4e1193986
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 )
 
 go 1.15
@@ -22,6 +22,8 @@ const (
 	TestConfigTeam
 	TestConfigUserID
 	TestConfigPipelineID
+	TestConfigSpaceTransferTeamKey
+	TestConfigEnterpriseAccountKey
 )
 
 var testConfigKeyToEnvName = map[TestConfigKey]string{
@@ -35,6 +37,8 @@ var testConfigKeyToEnvName = map[TestConfigKey]string{
 	TestConfigTeam:                 "HEROKU_TEAM",
 	TestConfigUserID:               "HEROKU_USER_ID",
 	TestConfigPipelineID:           "HEROKU_PIPELINE_ID",
+	TestConfigSpaceTransferTeamKey: "HEROKU_SPACES_TRANSFER_TEAM",
+	TestConfigEnterpriseAccountKey: "HEROKU_ENTERPRISE_ACCOUNT",
 	TestConfigAcceptanceTestKey:    resource.TestEnvVar,
 }
 
@@ -137,3 +141,11 @@ func (t *TestConfig) GetUserIDOrSkip(testing *testing.T) (val string) {
 func (t *TestConfig) GetPipelineIDorSkip(testing *testing.T) (val string) {
 	return t.GetOrSkip(testing, TestConfigPipelineID)
 }
+
+func (t *TestConfig) GetSpaceTransferTeamOrSkip(testing *testing.T) (val string) {
+	return t.GetOrSkip(testing, TestConfigSpaceTransferTeamKey)
+}
+
+func (t *TestConfig) GetEnterpriseAccountOrSkip(testing *testing.T) (val string) {
+	return t.GetOrSkip(testing, TestConfigEnterpriseAccountKey)
+}
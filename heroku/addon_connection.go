@@ -0,0 +1,155 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// addonConnectionServices are the add-on services whose connection URL config var
+// is parsed into the connection_details attribute of add-ons.
+var addonConnectionServices = map[string]bool{
+	"heroku-postgresql": true,
+	"heroku-redis":      true,
+}
+
+// addonConnectionSchema is the computed connection_details attribute of Postgres and Redis
+// add-ons, holding the components of their connection URL.
+func addonConnectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeList,
+		Computed:  true,
+		Sensitive: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uri": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"scheme": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"host": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"port": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"database": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"username": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"password": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"tls": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+
+				"sslmode": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// addonConnection returns the parsed connection URL of a Postgres or Redis add-on,
+// read from the first of its config vars on its owning app. Other add-ons have no
+// connection.
+func addonConnection(ctx context.Context, client *heroku.Service, addon *heroku.AddOn) ([]map[string]interface{}, error) {
+	if !addonConnectionServices[addonServiceName(addon.Plan.Name)] || len(addon.ConfigVars) == 0 {
+		return nil, nil
+	}
+
+	vars, err := client.ConfigVarInfoForApp(ctx, addon.App.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving config vars of app %s: %s", addon.App.Name, err)
+	}
+
+	v := vars[addon.ConfigVars[0]]
+	if v == nil || *v == "" {
+		return nil, nil
+	}
+
+	connection, err := parseAddonConnectionURL(*v)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %s of add-on %s: %s", addon.ConfigVars[0], addon.Name, err)
+	}
+
+	return []map[string]interface{}{connection}, nil
+}
+
+// parseAddonConnectionURL parses a postgres:// or redis(s):// connection URL into
+// the attributes of addonConnectionSchema. Heroku Postgres always requires TLS, so
+// its sslmode defaults to require.
+func parseAddonConnectionURL(raw string) (map[string]interface{}, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+
+	connection := map[string]interface{}{
+		"uri":      raw,
+		"scheme":   u.Scheme,
+		"host":     u.Hostname(),
+		"database": strings.TrimPrefix(u.Path, "/"),
+		"username": u.User.Username(),
+	}
+	if password, ok := u.User.Password(); ok {
+		connection["password"] = password
+	}
+
+	var defaultPort int
+	switch u.Scheme {
+	case "postgres", "postgresql":
+		defaultPort = 5432
+		sslmode := u.Query().Get("sslmode")
+		if sslmode == "" {
+			sslmode = "require"
+		}
+		connection["sslmode"] = sslmode
+		connection["tls"] = sslmode != "disable"
+	case "redis", "rediss":
+		defaultPort = 6379
+		connection["tls"] = u.Scheme == "rediss"
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	connection["port"] = defaultPort
+	if p := u.Port(); p != "" {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", p)
+		}
+		connection["port"] = port
+	}
+
+	return connection, nil
+}
@@ -0,0 +1,212 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiUsageUntracked is the resource of API calls made outside of a resource
+// operation, such as when the provider is configured.
+const apiUsageUntracked = "untracked"
+
+// apiUsageOperation accumulates the API calls of a single resource operation,
+// which is attributed to the resource once its ID is known.
+type apiUsageOperation struct {
+	mu      sync.Mutex
+	calls   int
+	latency time.Duration
+}
+
+func (op *apiUsageOperation) recordCall(latency time.Duration) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	op.calls++
+	op.latency += latency
+}
+
+type apiUsageRecord struct {
+	Resource     string `json:"resource"`
+	Operation    string `json:"operation,omitempty"`
+	DurationMS   int64  `json:"duration_ms"`
+	APICalls     int    `json:"api_calls"`
+	APILatencyMS int64  `json:"api_latency_ms"`
+}
+
+// apiUsage appends a line with the API calls and latency of each resource
+// operation to a file, so that the file holds the usage of the whole run
+// whenever the plan or apply ends, without being rewritten.
+type apiUsage struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newAPIUsage(path string) *apiUsage {
+	return &apiUsage{path: path}
+}
+
+func (u *apiUsage) append(record apiUsageRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	f, err := os.OpenFile(u.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (u *apiUsage) record(record apiUsageRecord) {
+	if err := u.append(record); err != nil {
+		log.Printf("[WARN] Error writing API usage summary to %s: %s", u.path, err)
+	}
+}
+
+// apiUsageTransport records the latency of each API call, to the operation
+// making it when the client was created for one.
+type apiUsageTransport struct {
+	usage     *apiUsage
+	operation *apiUsageOperation
+	transport http.RoundTripper
+}
+
+func (t *apiUsageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.transport.RoundTrip(req)
+	latency := time.Since(start)
+
+	if t.operation != nil {
+		t.operation.recordCall(latency)
+	} else {
+		t.usage.record(apiUsageRecord{
+			Resource:     apiUsageUntracked,
+			APICalls:     1,
+			APILatencyMS: latency.Milliseconds(),
+		})
+	}
+
+	return res, err
+}
+
+// trackAPIUsage wraps the operations of the provider's resources and data sources
+// to attribute their API calls to them, when api_usage_summary is configured.
+func trackAPIUsage(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		trackResourceAPIUsage(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		trackResourceAPIUsage("data."+name, r)
+	}
+}
+
+func trackResourceAPIUsage(name string, r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = trackContextFuncAPIUsage(name, "create", r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = trackContextFuncAPIUsage(name, "read", r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = trackContextFuncAPIUsage(name, "update", r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = trackContextFuncAPIUsage(name, "delete", r.DeleteContext)
+	}
+	if r.Create != nil {
+		r.Create = trackFuncAPIUsage(name, "create", r.Create)
+	}
+	if r.Read != nil {
+		r.Read = trackFuncAPIUsage(name, "read", r.Read)
+	}
+	if r.Update != nil {
+		r.Update = trackFuncAPIUsage(name, "update", r.Update)
+	}
+	if r.Delete != nil {
+		r.Delete = trackFuncAPIUsage(name, "delete", r.Delete)
+	}
+}
+
+func trackContextFuncAPIUsage(name, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		config, ok := meta.(*Config)
+		if !ok || config.apiUsage == nil {
+			return f(ctx, d, meta)
+		}
+
+		id := d.Id()
+		op := &apiUsageOperation{}
+		start := time.Now()
+		diags := f(ctx, d, config.withAPIUsageOperation(op))
+		config.recordAPIUsage(name, operation, id, d.Id(), op, time.Since(start))
+
+		return diags
+	}
+}
+
+func trackFuncAPIUsage(name, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config, ok := meta.(*Config)
+		if !ok || config.apiUsage == nil {
+			return f(d, meta)
+		}
+
+		id := d.Id()
+		op := &apiUsageOperation{}
+		start := time.Now()
+		err := f(d, config.withAPIUsageOperation(op))
+		config.recordAPIUsage(name, operation, id, d.Id(), op, time.Since(start))
+
+		return err
+	}
+}
+
+// withAPIUsageOperation returns a copy of the config whose API clients attribute
+// their calls to op. Operations receive it in place of the provider's config, so
+// their calls are attributed whether or not they pass a context to the clients.
+func (c *Config) withAPIUsageOperation(op *apiUsageOperation) *Config {
+	config := *c
+	config.apiUsageOperation = op
+	config.initializeAPIServices()
+	return &config
+}
+
+// recordAPIUsage attributes an operation to its resource, using the ID it ended
+// with, e.g. after a create, or otherwise started with, e.g. after a delete.
+func (c *Config) recordAPIUsage(name, operation, startID, endID string, op *apiUsageOperation, duration time.Duration) {
+	resource := name
+	if endID != "" {
+		resource = fmt.Sprintf("%s[%s]", name, endID)
+	} else if startID != "" {
+		resource = fmt.Sprintf("%s[%s]", name, startID)
+	}
+
+	op.mu.Lock()
+	record := apiUsageRecord{
+		Resource:     resource,
+		Operation:    operation,
+		DurationMS:   duration.Milliseconds(),
+		APICalls:     op.calls,
+		APILatencyMS: op.latency.Milliseconds(),
+	}
+	op.mu.Unlock()
+
+	c.apiUsage.record(record)
+}
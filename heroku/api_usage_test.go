@@ -0,0 +1,92 @@
+package heroku
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAPIUsageSummary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"01234567-89ab-cdef-0123-456789abcdef","name":"some-app"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "usage.json")
+	config := NewConfig()
+	config.URL = srv.URL
+	config.APIUsageSummaryPath = path
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	create := trackContextFuncAPIUsage("heroku_app", "create", func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*Config).Api
+		for i := 0; i < 2; i++ {
+			if _, err := client.AppInfo(ctx, "some-app"); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		d.SetId("some-app")
+		return nil
+	})
+
+	// Operations that do not pass a context to the client are attributed too.
+	read := trackFuncAPIUsage("heroku_app", "read", func(d *schema.ResourceData, meta interface{}) error {
+		_, err := meta.(*Config).Api.AppInfo(context.TODO(), d.Id())
+		return err
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuApp().Schema, nil)
+	if diags := create(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if err := read(d, config); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Api.AppInfo(context.TODO(), "some-app"); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var records []apiUsageRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record apiUsageRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []apiUsageRecord{
+		{Resource: "heroku_app[some-app]", Operation: "create", APICalls: 2},
+		{Resource: "heroku_app[some-app]", Operation: "read", APICalls: 1},
+		{Resource: apiUsageUntracked, APICalls: 1},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %#v", len(expected), records)
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.Resource != e.Resource || r.Operation != e.Operation || r.APICalls != e.APICalls {
+			t.Fatalf("expected record %d to be %#v, got %#v", i, e, r)
+		}
+	}
+}
@@ -22,9 +22,27 @@ const (
 	DefaultPostSpaceCreateDelay  = int64(5)
 	DefaultPostDomainCreateDelay = int64(5)
 
+	// Delays that are disabled by default, for lags that only affect some accounts
+	DefaultPostAppUpdateDelay              = int64(0)
+	DefaultPostAddonCreateDelay            = int64(0)
+	DefaultPostPipelineCouplingCreateDelay = int64(0)
+
+	// DefaultMetricsURL is the URL of the API serving app metrics and alerts.
+	DefaultMetricsURL = "https://api.metrics.heroku.com"
+
+	// DefaultPostgresURL is the URL of the API managing Heroku Postgres databases.
+	DefaultPostgresURL = "https://postgres-api.heroku.com"
+
+	// DefaultRedisURL is the URL of the API managing Heroku Data for Redis databases.
+	DefaultRedisURL = "https://redis-api.heroku.com"
+
 	// Default custom timeouts
 	DefaultAddonCreateTimeout         = int64(20)
 	DefaultSetAppAllConfigVarsInState = true
+
+	// Default features
+	DefaultPreventAppDestroy      = false
+	DefaultSkipFinalAddonSnapshot = true
 )
 
 type Config struct {
@@ -35,31 +53,87 @@ type Config struct {
 	Headers   http.Header
 	URL       string
 
+	// DefaultTeam owns the apps, pipelines and spaces that do not specify one.
+	DefaultTeam string
+
+	// UserAgentSuffix is appended to the User-Agent of API requests, to identify
+	// the organization or pipeline the requests originate from.
+	UserAgentSuffix string
+
+	// Metrics and data API clients, authenticated like the Platform API client
+	MetricsAPI  *heroku.Service
+	MetricsURL  string
+	PostgresAPI *heroku.Service
+	PostgresURL string
+	RedisAPI    *heroku.Service
+	RedisURL    string
+
+	// OAuth client credentials, exchanged for access tokens instead of using an APIKey
+	OAuthClientID     string
+	OAuthClientSecret string
+	OAuthRefreshToken string
+	OAuthTokenURL     string
+	tokenSource       tokenSource
+
+	// Preflight check of the credentials, and the scopes of the credentials
+	Preflight               bool
+	PreflightRequiredScopes []string
+	tokenScopes             *oauthScopes
+
+	// Proxies, overriding the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables,
+	// and the transport of the requests through them
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	transport  http.RoundTripper
+
+	// API usage summary
+	APIUsageSummaryPath string
+	apiUsage            *apiUsage
+	apiUsageOperation   *apiUsageOperation
+
 	// Delays
-	PostAppCreateDelay    int64
-	PostDomainCreateDelay int64
-	PostSpaceCreateDelay  int64
+	PostAppCreateDelay              int64
+	PostAppUpdateDelay              int64
+	PostAddonCreateDelay            int64
+	PostDomainCreateDelay           int64
+	PostPipelineCouplingCreateDelay int64
+	PostSpaceCreateDelay            int64
 
 	// Timeouts
 	AddonCreateTimeout int64
 
 	// Customization
 	SetAppAllConfigVarsInState bool
+
+	// Features
+	PreventAppDestroy      bool
+	SkipFinalAddonSnapshot bool
 }
 
 func (c Config) String() string {
-	return fmt.Sprintf("{APIKey:xxx Email:%s URL:%s Headers:xxx DebugHTTP:%t PostAppCreateDelay:%d PostDomainCreateDelay:%d PostSpaceCreateDelay:%d}",
-		c.Email, c.URL, c.DebugHTTP, c.PostAppCreateDelay, c.PostDomainCreateDelay, c.PostSpaceCreateDelay)
+	return fmt.Sprintf("{APIKey:xxx Email:%s URL:%s Headers:xxx DebugHTTP:%t PostAppCreateDelay:%d PostAppUpdateDelay:%d PostAddonCreateDelay:%d PostDomainCreateDelay:%d PostPipelineCouplingCreateDelay:%d PostSpaceCreateDelay:%d}",
+		c.Email, c.URL, c.DebugHTTP, c.PostAppCreateDelay, c.PostAppUpdateDelay, c.PostAddonCreateDelay,
+		c.PostDomainCreateDelay, c.PostPipelineCouplingCreateDelay, c.PostSpaceCreateDelay)
 }
 
 func NewConfig() *Config {
 	config := &Config{
-		Headers:                    make(http.Header),
-		PostAppCreateDelay:         DefaultPostAppCreateDelay,
-		PostDomainCreateDelay:      DefaultPostDomainCreateDelay,
-		PostSpaceCreateDelay:       DefaultPostSpaceCreateDelay,
-		AddonCreateTimeout:         DefaultAddonCreateTimeout,
-		SetAppAllConfigVarsInState: DefaultSetAppAllConfigVarsInState,
+		Headers:                         make(http.Header),
+		MetricsURL:                      DefaultMetricsURL,
+		PostgresURL:                     DefaultPostgresURL,
+		RedisURL:                        DefaultRedisURL,
+		OAuthTokenURL:                   DefaultOAuthTokenURL,
+		PostAppCreateDelay:              DefaultPostAppCreateDelay,
+		PostAppUpdateDelay:              DefaultPostAppUpdateDelay,
+		PostAddonCreateDelay:            DefaultPostAddonCreateDelay,
+		PostDomainCreateDelay:           DefaultPostDomainCreateDelay,
+		PostPipelineCouplingCreateDelay: DefaultPostPipelineCouplingCreateDelay,
+		PostSpaceCreateDelay:            DefaultPostSpaceCreateDelay,
+		AddonCreateTimeout:              DefaultAddonCreateTimeout,
+		SetAppAllConfigVarsInState:      DefaultSetAppAllConfigVarsInState,
+		PreventAppDestroy:               DefaultPreventAppDestroy,
+		SkipFinalAddonSnapshot:          DefaultSkipFinalAddonSnapshot,
 	}
 	if logging.IsDebugOrHigher() {
 		config.DebugHTTP = true
@@ -68,30 +142,102 @@ func NewConfig() *Config {
 }
 
 func (c *Config) initializeAPI() (err error) {
-	c.Api = heroku.NewService(&http.Client{
-		Transport: &heroku.Transport{
-			Username: c.Email,
-			Password: c.APIKey,
-			UserAgent: fmt.Sprintf("%s terraform-provider-heroku/%s",
-				heroku.DefaultUserAgent, version.ProviderVersion),
-			AdditionalHeaders: c.Headers,
-			Debug:             c.DebugHTTP,
-			Transport:         heroku.RoundTripWithRetryBackoff{
-				// Configuration fields for ExponentialBackOff
-				// InitialIntervalSeconds: 30,
-				// RandomizationFactor:    0.25,
-				// Multiplier:             2,
-				// MaxIntervalSeconds:     900,
-				// MaxElapsedTimeSeconds:  0,
+	if c.APIUsageSummaryPath != "" {
+		c.apiUsage = newAPIUsage(c.APIUsageSummaryPath)
+		log.Printf("[INFO] Heroku API usage summary will be written to %s", c.APIUsageSummaryPath)
+	}
+
+	if err := c.configureProxy(); err != nil {
+		return err
+	}
+
+	if c.OAuthClientID != "" {
+		if c.OAuthClientSecret == "" {
+			return fmt.Errorf("Provider configuration error: oauth_client_secret is required with oauth_client_id")
+		}
+		c.tokenSource = newOAuthTokenSource(c.OAuthClientID, c.OAuthClientSecret, c.OAuthRefreshToken, c.OAuthTokenURL, c.transport)
+		log.Printf("[INFO] Heroku Client authenticating with OAuth client %s", c.OAuthClientID)
+	} else {
+		c.tokenSource = newAPIKeyTokenSource(c.Email, c.APIKey, c.reloadCredentials)
+	}
+	c.tokenScopes = &oauthScopes{}
+
+	c.initializeAPIServices()
+
+	log.Printf("[INFO] Heroku Client configured for user: %s", c.Email)
+
+	return
+}
+
+// initializeAPIServices creates the Platform, metrics and data API clients.
+func (c *Config) initializeAPIServices() {
+	c.Api = c.newAPIService(c.Headers)
+
+	c.MetricsAPI = c.newAPIService(c.Headers)
+	c.MetricsAPI.URL = c.MetricsURL
+
+	c.PostgresAPI = c.newAPIService(c.Headers)
+	c.PostgresAPI.URL = c.PostgresURL
+
+	c.RedisAPI = c.newAPIService(c.Headers)
+	c.RedisAPI.URL = c.RedisURL
+}
+
+// newAPIService returns a Heroku API client authenticated with the provider's
+// credentials, sending the given headers with each request.
+func (c *Config) newAPIService(headers http.Header) *heroku.Service {
+	var transport http.RoundTripper = &rateLimitRetryTransport{transport: c.transport}
+	if c.apiUsage != nil {
+		transport = &apiUsageTransport{usage: c.apiUsage, operation: c.apiUsageOperation, transport: transport}
+	}
+
+	api := heroku.NewService(&http.Client{
+		Transport: &requestIDTransport{
+			transport: &heroku.Transport{
+				UserAgent:         c.userAgent(),
+				AdditionalHeaders: headers,
+				Debug:             c.DebugHTTP,
+				Transport: &requestIDRecorder{
+					transport: &oauthScopeRecorder{
+						scopes:    c.tokenScopes,
+						transport: &tokenTransport{source: c.tokenSource, transport: transport},
+					},
+				},
 			},
 		},
 	})
 
-	c.Api.URL = c.URL
+	api.URL = c.URL
 
-	log.Printf("[INFO] Heroku Client configured for user: %s", c.Email)
+	return api
+}
 
-	return
+// userAgent returns the User-Agent of the provider's API requests.
+func (c *Config) userAgent() string {
+	userAgent := fmt.Sprintf("%s terraform-provider-heroku/%s", heroku.DefaultUserAgent, version.ProviderVersion)
+	if c.UserAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, c.UserAgentSuffix)
+	}
+	return userAgent
+}
+
+// variantAPI returns a Heroku API client that requests the given variant of
+// the Platform API, e.g. "docker-releases", for endpoints that require one.
+func (c *Config) variantAPI(variant string) *heroku.Service {
+	headers := c.Headers.Clone()
+	headers.Set("Accept", fmt.Sprintf("application/vnd.heroku+json; version=3.%s", variant))
+
+	return c.newAPIService(headers)
+}
+
+// inclusionAPI returns a Heroku API client that requests the given optional
+// fields of the Platform API's responses, e.g. "config_vars" for add-on
+// attachments.
+func (c *Config) inclusionAPI(inclusion string) *heroku.Service {
+	headers := c.Headers.Clone()
+	headers.Set("Accept-Inclusion", inclusion)
+
+	return c.newAPIService(headers)
 }
 
 func (c *Config) applySchema(d *schema.ResourceData) (err error) {
@@ -110,6 +256,65 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 		c.URL = url.(string)
 	}
 
+	if v, ok := d.GetOk("default_team"); ok {
+		c.DefaultTeam = v.(string)
+	}
+
+	if v, ok := d.GetOk("user_agent_suffix"); ok {
+		c.UserAgentSuffix = v.(string)
+	}
+
+	if v, ok := d.GetOk("oauth_client_id"); ok {
+		c.OAuthClientID = v.(string)
+	}
+
+	if v, ok := d.GetOk("oauth_client_secret"); ok {
+		c.OAuthClientSecret = v.(string)
+	}
+
+	if v, ok := d.GetOk("oauth_refresh_token"); ok {
+		c.OAuthRefreshToken = v.(string)
+	}
+
+	if v, ok := d.GetOk("oauth_token_url"); ok {
+		c.OAuthTokenURL = v.(string)
+	}
+
+	if v, ok := d.GetOk("preflight"); ok {
+		vL := v.([]interface{})
+		if len(vL) > 1 {
+			return fmt.Errorf("Provider configuration error: only one preflight block is permitted")
+		}
+		for _, v := range vL {
+			c.Preflight = true
+
+			// An empty preflight block has no attributes.
+			preflight, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, s := range preflight["required_scopes"].(*schema.Set).List() {
+				c.PreflightRequiredScopes = append(c.PreflightRequiredScopes, s.(string))
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("http_proxy"); ok {
+		c.HTTPProxy = v.(string)
+	}
+
+	if v, ok := d.GetOk("https_proxy"); ok {
+		c.HTTPSProxy = v.(string)
+	}
+
+	if v, ok := d.GetOk("no_proxy"); ok {
+		c.NoProxy = v.(string)
+	}
+
+	if v, ok := d.GetOk("api_usage_summary"); ok {
+		c.APIUsageSummaryPath = v.(string)
+	}
+
 	if v, ok := d.GetOk("customizations"); ok {
 		vL := v.([]interface{})
 		if len(vL) > 1 {
@@ -123,6 +328,22 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 		}
 	}
 
+	if v, ok := d.GetOk("features"); ok {
+		vL := v.([]interface{})
+		if len(vL) > 1 {
+			return fmt.Errorf("Provider configuration error: only one features block is permitted")
+		}
+		for _, v := range vL {
+			features := v.(map[string]interface{})
+			if v, ok := features["prevent_app_destroy"].(bool); ok {
+				c.PreventAppDestroy = v
+			}
+			if v, ok := features["skip_final_addon_snapshot"].(bool); ok {
+				c.SkipFinalAddonSnapshot = v
+			}
+		}
+	}
+
 	if v, ok := d.GetOk("delays"); ok {
 		vL := v.([]interface{})
 		if len(vL) > 1 {
@@ -139,6 +360,15 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 			if v, ok := delaysConfig["post_domain_create_delay"].(int); ok {
 				c.PostDomainCreateDelay = int64(v)
 			}
+			if v, ok := delaysConfig["post_app_update_delay"].(int); ok {
+				c.PostAppUpdateDelay = int64(v)
+			}
+			if v, ok := delaysConfig["post_addon_create_delay"].(int); ok {
+				c.PostAddonCreateDelay = int64(v)
+			}
+			if v, ok := delaysConfig["post_pipeline_coupling_create_delay"].(int); ok {
+				c.PostPipelineCouplingCreateDelay = int64(v)
+			}
 		}
 	}
 
@@ -159,6 +389,25 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 	return
 }
 
+// reloadCredentials re-reads the email and API key from the environment or the
+// netrc file, for a key that was renewed since the provider was configured.
+func (c *Config) reloadCredentials() (string, string, error) {
+	reloaded := &Config{URL: c.URL}
+	if err := reloaded.applyNetrcFile(); err != nil {
+		return "", "", err
+	}
+
+	if v := os.Getenv("HEROKU_EMAIL"); v != "" {
+		reloaded.Email = v
+	}
+
+	if v := os.Getenv("HEROKU_API_KEY"); v != "" {
+		reloaded.APIKey = v
+	}
+
+	return reloaded.Email, reloaded.APIKey, nil
+}
+
 func (c *Config) applyNetrcFile() error {
 	// Get the netrc file path. If path not shown, then fall back to default netrc path value
 	path := os.Getenv("NETRC_PATH")
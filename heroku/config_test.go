@@ -0,0 +1,37 @@
+package heroku
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConfigApplySchemaDelays(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"delays": []interface{}{
+			map[string]interface{}{
+				"post_app_update_delay":               1,
+				"post_addon_create_delay":             2,
+				"post_pipeline_coupling_create_delay": 3,
+			},
+		},
+	})
+
+	config := NewConfig()
+	if err := config.applySchema(d); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.PostAppUpdateDelay != 1 {
+		t.Errorf("Expected PostAppUpdateDelay 1, got %d", config.PostAppUpdateDelay)
+	}
+	if config.PostAddonCreateDelay != 2 {
+		t.Errorf("Expected PostAddonCreateDelay 2, got %d", config.PostAddonCreateDelay)
+	}
+	if config.PostPipelineCouplingCreateDelay != 3 {
+		t.Errorf("Expected PostPipelineCouplingCreateDelay 3, got %d", config.PostPipelineCouplingCreateDelay)
+	}
+	if config.PostAppCreateDelay != DefaultPostAppCreateDelay {
+		t.Errorf("Expected the default PostAppCreateDelay, got %d", config.PostAppCreateDelay)
+	}
+}
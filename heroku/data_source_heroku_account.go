@@ -0,0 +1,152 @@
+package heroku
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuAccountRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"default_team": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"two_factor_authentication": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"federated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"verified": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"team_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"teams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account, err := client.AccountInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Error retrieving account: %s", err)
+	}
+
+	teams, err := client.TeamList(ctx, &heroku.ListRange{Field: "name", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving teams of account %s: %s", account.Email, err)
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+
+	names := make([]string, 0, len(teams))
+	memberships := make([]map[string]interface{}, 0, len(teams))
+	for _, t := range teams {
+		role := ""
+		if t.Role != nil {
+			role = *t.Role
+		}
+		names = append(names, t.Name)
+		memberships = append(memberships, map[string]interface{}{
+			"id":   t.ID,
+			"name": t.Name,
+			"role": role,
+		})
+	}
+
+	name := ""
+	if account.Name != nil {
+		name = *account.Name
+	}
+
+	// The default organization is the former name of the default team.
+	defaultTeam := ""
+	if account.DefaultTeam != nil {
+		defaultTeam = account.DefaultTeam.Name
+	} else if account.DefaultOrganization != nil {
+		defaultTeam = account.DefaultOrganization.Name
+	}
+
+	d.SetId(account.ID)
+	if err := d.Set("email", account.Email); err != nil {
+		return setAttributeDiagnostics("email", err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("default_team", defaultTeam); err != nil {
+		return setAttributeDiagnostics("default_team", err)
+	}
+	if err := d.Set("two_factor_authentication", account.TwoFactorAuthentication); err != nil {
+		return setAttributeDiagnostics("two_factor_authentication", err)
+	}
+	if err := d.Set("federated", account.Federated); err != nil {
+		return setAttributeDiagnostics("federated", err)
+	}
+	if err := d.Set("verified", account.Verified); err != nil {
+		return setAttributeDiagnostics("verified", err)
+	}
+	if err := d.Set("team_names", names); err != nil {
+		return setAttributeDiagnostics("team_names", err)
+	}
+	if err := d.Set("teams", memberships); err != nil {
+		return setAttributeDiagnostics("teams", err)
+	}
+
+	return nil
+}
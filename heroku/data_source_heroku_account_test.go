@@ -0,0 +1,81 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuAccount_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAccountWithDataSource_Basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.heroku_account.current", "id"),
+					resource.TestCheckResourceAttrSet("data.heroku_account.current", "email"),
+					resource.TestCheckResourceAttrSet("data.heroku_account.current", "team_names.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAccountWithDataSource_Basic() string {
+	return `
+data "heroku_account" "current" {}
+`
+}
+
+func TestDataSourceHerokuAccountRead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account":
+			w.Write([]byte(`{"id": "01234567-89ab-cdef-0123-456789abcdef", "email": "someone@example.com",
+				"name": null, "two_factor_authentication": true, "default_organization": {"name": "legacy-team"}}`))
+		case "/teams":
+			w.Write([]byte(`[{"id": "b", "name": "team-b", "role": "member"}, {"id": "a", "name": "team-a", "role": "admin"}]`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := dataSourceHerokuAccount().TestResourceData()
+	if diags := dataSourceHerokuAccountRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	expected := map[string]string{
+		"email":                     "someone@example.com",
+		"name":                      "",
+		"default_team":              "legacy-team",
+		"two_factor_authentication": "true",
+		"team_names.0":              "team-a",
+		"team_names.1":              "team-b",
+		"teams.0.role":              "admin",
+	}
+	for k, v := range expected {
+		if actual := fmt.Sprint(d.Get(k)); actual != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+	if d.Id() != "01234567-89ab-cdef-0123-456789abcdef" {
+		t.Errorf("Unexpected id: %s", d.Id())
+	}
+}
@@ -1,6 +1,8 @@
 package heroku
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -40,6 +42,28 @@ func dataSourceHerokuAddon() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+
+			"connection_details": addonConnectionSchema(),
+
+			"web_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -55,11 +79,29 @@ func dataSourceHerokuAddonRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(addon.ID)
-	d.Set("name", addon.Name)
-	d.Set("app", addon.App.Name)
-	d.Set("plan", addon.Plan.Name)
-	d.Set("provider_id", addon.ProviderID)
-	d.Set("config_vars", addon.ConfigVars)
+	if err := d.Set("name", addon.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("app", addon.App.Name); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("plan", addon.Plan.Name); err != nil {
+		return setAttributeError("plan", err)
+	}
+	if err := d.Set("provider_id", addon.ProviderID); err != nil {
+		return setAttributeError("provider_id", err)
+	}
+	if err := d.Set("config_vars", addon.ConfigVars); err != nil {
+		return setAttributeError("config_vars", err)
+	}
+
+	connection, err := addonConnection(context.TODO(), client, addon)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_details", connection); err != nil {
+		return setAttributeError("connection_details", err)
+	}
 
-	return nil
+	return setAddonDashboardAttributes(d, addon)
 }
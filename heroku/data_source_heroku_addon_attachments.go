@@ -0,0 +1,144 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuAddonAttachments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuAddonAttachmentsRead,
+		Schema: map[string]*schema.Schema{
+			"addon": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"addon", "app"},
+			},
+
+			"app": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"attachments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"app_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"app_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"addon_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"addon_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"addon_app_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"web_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuAddonAttachmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	lr := &heroku.ListRange{Field: "id", Max: 1000}
+
+	var id string
+	var attachments []heroku.AddOnAttachment
+	if v, ok := d.GetOk("addon"); ok {
+		id = fmt.Sprintf("addons/%s/addon-attachments", v.(string))
+		result, err := client.AddOnAttachmentListByAddOn(ctx, v.(string), lr)
+		if err != nil {
+			return diag.Errorf("Error retrieving the attachments of add-on %s: %s", v.(string), err)
+		}
+		attachments = result
+	} else {
+		app := d.Get("app").(string)
+		id = fmt.Sprintf("apps/%s/addon-attachments", app)
+		result, err := client.AddOnAttachmentListByApp(ctx, app, lr)
+		if err != nil {
+			return diag.Errorf("Error retrieving the add-on attachments of app %s: %s", app, err)
+		}
+		attachments = result
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		if attachments[i].App.Name != attachments[j].App.Name {
+			return attachments[i].App.Name < attachments[j].App.Name
+		}
+		return attachments[i].Name < attachments[j].Name
+	})
+
+	result := make([]map[string]interface{}, 0, len(attachments))
+	for _, a := range attachments {
+		namespace := ""
+		if a.Namespace != nil {
+			namespace = *a.Namespace
+		}
+		webURL := ""
+		if a.WebURL != nil {
+			webURL = *a.WebURL
+		}
+		result = append(result, map[string]interface{}{
+			"id":             a.ID,
+			"name":           a.Name,
+			"namespace":      namespace,
+			"app_id":         a.App.ID,
+			"app_name":       a.App.Name,
+			"addon_id":       a.Addon.ID,
+			"addon_name":     a.Addon.Name,
+			"addon_app_name": a.Addon.App.Name,
+			"web_url":        webURL,
+		})
+	}
+
+	d.SetId(id)
+	if err := d.Set("attachments", result); err != nil {
+		return setAttributeDiagnostics("attachments", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuAddonAttachments_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	appName2 := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAddonAttachmentsWithDatasource(appName, appName2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_addon_attachments.addon", "attachments.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_addon_attachments.addon", "attachments.0.addon_app_name", appName),
+					resource.TestCheckResourceAttr(
+						"data.heroku_addon_attachments.app", "attachments.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_addon_attachments.app", "attachments.0.name", "SHARED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAddonAttachmentsWithDatasource(appName, appName2 string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_app" "foobar2" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_addon" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    plan = "deployhooks:http"
+    config = {
+		url = "http://google.com"
+	}
+}
+
+resource "heroku_addon_attachment" "shared" {
+    app_id = "${heroku_app.foobar2.id}"
+    addon_id = "${heroku_addon.foobar.id}"
+    name = "SHARED"
+}
+
+data "heroku_addon_attachments" "addon" {
+  addon = "${heroku_addon_attachment.shared.addon_id}"
+}
+
+data "heroku_addon_attachments" "app" {
+  app = "${heroku_addon_attachment.shared.app_id}"
+}
+`, appName, appName2)
+}
+
+func TestDataSourceHerokuAddonAttachmentsRead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/addons/shared-db/addon-attachments" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"id": "2", "name": "SHARED_DB", "app": {"id": "b", "name": "worker-app"},
+			 "addon": {"id": "addon-id", "name": "shared-db", "app": {"name": "web-app"}}},
+			{"id": "1", "name": "DATABASE", "app": {"id": "a", "name": "web-app"},
+			 "addon": {"id": "addon-id", "name": "shared-db", "app": {"name": "web-app"}},
+			 "web_url": "https://addons-sso.heroku.com/apps/a/addons/addon-id"}
+		]`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := dataSourceHerokuAddonAttachments().TestResourceData()
+	d.Set("addon", "shared-db")
+
+	if diags := dataSourceHerokuAddonAttachmentsRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	expected := map[string]string{
+		"attachments.#":                "2",
+		"attachments.0.name":           "DATABASE",
+		"attachments.0.app_name":       "web-app",
+		"attachments.0.web_url":        "https://addons-sso.heroku.com/apps/a/addons/addon-id",
+		"attachments.1.name":           "SHARED_DB",
+		"attachments.1.app_name":       "worker-app",
+		"attachments.1.addon_app_name": "web-app",
+		"attachments.1.namespace":      "",
+	}
+	for k, v := range expected {
+		if actual := fmt.Sprint(d.Get(k)); actual != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+}
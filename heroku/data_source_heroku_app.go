@@ -69,6 +69,11 @@ func dataSourceHerokuApp() *schema.Resource {
 				Computed: true,
 			},
 
+			"internal_hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"organization": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -96,6 +101,11 @@ func dataSourceHerokuApp() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"generation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -123,8 +133,12 @@ func dataSourceHerokuAppRead(d *schema.ResourceData, m interface{}) error {
 		return setErr
 	}
 
-	d.Set("buildpacks", app.Buildpacks)
-	d.Set("config_vars", app.Vars)
+	if err := d.Set("buildpacks", app.Buildpacks); err != nil {
+		return setAttributeError("buildpacks", err)
+	}
+	if err := d.Set("config_vars", app.Vars); err != nil {
+		return setAttributeError("config_vars", err)
+	}
 
 	return nil
 }
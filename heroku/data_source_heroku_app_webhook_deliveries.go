@@ -0,0 +1,174 @@
+package heroku
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuAppWebhookDeliveries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuAppWebhookDeliveriesRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"webhook_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"pending", "scheduled", "retrying", "failed", "succeeded"}, false),
+			},
+
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+
+			"deliveries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"webhook_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"event_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"include": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"num_attempts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"last_attempt_code": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"last_attempt_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"last_attempt_error_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"last_attempt_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"next_attempt_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuAppWebhookDeliveriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appID := d.Get("app_id").(string)
+	webhookID := d.Get("webhook_id").(string)
+	status := d.Get("status").(string)
+	limit := d.Get("limit").(int)
+
+	appDeliveries, err := client.AppWebhookDeliveryList(ctx, appID, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving webhook deliveries of app %s: %s", appID, err)
+	}
+
+	// Most recent deliveries first.
+	sort.Slice(appDeliveries, func(i, j int) bool {
+		return appDeliveries[i].CreatedAt.After(appDeliveries[j].CreatedAt)
+	})
+
+	deliveries := make([]map[string]interface{}, 0)
+	for _, dl := range appDeliveries {
+		if len(deliveries) >= limit {
+			break
+		}
+		if webhookID != "" && dl.Webhook.ID != webhookID {
+			continue
+		}
+		if status != "" && dl.Status != status {
+			continue
+		}
+
+		delivery := map[string]interface{}{
+			"id":           dl.ID,
+			"webhook_id":   dl.Webhook.ID,
+			"event_id":     dl.Event.ID,
+			"include":      dl.Event.Include,
+			"status":       dl.Status,
+			"num_attempts": dl.NumAttempts,
+			"created_at":   dl.CreatedAt.Format(time.RFC3339),
+		}
+		if a := dl.LastAttempt; a != nil {
+			delivery["last_attempt_status"] = a.Status
+			delivery["last_attempt_at"] = a.CreatedAt.Format(time.RFC3339)
+			if a.Code != nil {
+				delivery["last_attempt_code"] = *a.Code
+			}
+			if a.ErrorClass != nil {
+				delivery["last_attempt_error_class"] = *a.ErrorClass
+			}
+		}
+		if dl.NextAttemptAt != nil {
+			delivery["next_attempt_at"] = dl.NextAttemptAt.Format(time.RFC3339)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	d.SetId(appID)
+	if err := d.Set("deliveries", deliveries); err != nil {
+		return diag.Errorf("Error setting webhook deliveries of app %s: %s", appID, err)
+	}
+
+	return nil
+}
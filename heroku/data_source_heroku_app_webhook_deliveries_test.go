@@ -0,0 +1,42 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuAppWebhookDeliveries_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppWebhookDeliveriesWithDataSource_Basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_app_webhook_deliveries.foobar", "id", "heroku_app.foobar", "id"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_app_webhook_deliveries.foobar", "deliveries.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppWebhookDeliveriesWithDataSource_Basic(appName string) string {
+	return testAccCheckHerokuAppWebhookConfig(appName, "https://terraform.example.com:1234", "notify", "api:release") + `
+
+data "heroku_app_webhook_deliveries" "foobar" {
+  app_id     = heroku_app.foobar.id
+  webhook_id = heroku_app_webhook.foobar_webhook.id
+  limit      = 10
+}
+`
+}
@@ -0,0 +1,111 @@
+package heroku
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuAppWebhooks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuAppWebhooksRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"urls": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"webhooks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"level": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"include": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuAppWebhooksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appID := d.Get("app_id").(string)
+
+	appWebhooks, err := client.AppWebhookList(ctx, appID, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving webhooks of app %s: %s", appID, err)
+	}
+
+	sort.Slice(appWebhooks, func(i, j int) bool {
+		return appWebhooks[i].URL < appWebhooks[j].URL
+	})
+
+	urls := make([]string, 0, len(appWebhooks))
+	webhooks := make([]map[string]interface{}, 0, len(appWebhooks))
+	for _, w := range appWebhooks {
+		urls = append(urls, w.URL)
+		webhooks = append(webhooks, map[string]interface{}{
+			"id":         w.ID,
+			"url":        w.URL,
+			"level":      w.Level,
+			"include":    w.Include,
+			"created_at": w.CreatedAt.Format(time.RFC3339),
+			"updated_at": w.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	d.SetId(appID)
+	if err := d.Set("urls", urls); err != nil {
+		return setAttributeDiagnostics("urls", err)
+	}
+	if err := d.Set("webhooks", webhooks); err != nil {
+		return diag.Errorf("Error setting webhooks of app %s: %s", appID, err)
+	}
+
+	return nil
+}
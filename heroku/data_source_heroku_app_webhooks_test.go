@@ -0,0 +1,48 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuAppWebhooks_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppWebhooksWithDataSource_Basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_app_webhooks.foobar", "id", "heroku_app.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_app_webhooks.foobar", "webhooks.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_app_webhooks.foobar", "webhooks.0.id", "heroku_app_webhook.foobar_webhook", "id"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_app_webhooks.foobar", "webhooks.0.url", "https://terraform.example.com:1234"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_app_webhooks.foobar", "webhooks.0.level", "notify"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_app_webhooks.foobar", "webhooks.0.include.0", "api:release"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppWebhooksWithDataSource_Basic(appName string) string {
+	return testAccCheckHerokuAppWebhookConfig(appName, "https://terraform.example.com:1234", "notify", "api:release") + `
+
+data "heroku_app_webhooks" "foobar" {
+  app_id = heroku_app_webhook.foobar_webhook.app_id
+}
+`
+}
@@ -0,0 +1,217 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// auditTrailDateLayout is the format of the dates of the audit trail events API.
+const auditTrailDateLayout = "2006-01-02"
+
+// auditTrailMaxDays limits how many days of events are retrieved, as the API
+// returns the events of a single date per request.
+const auditTrailMaxDays = 31
+
+// auditTrailEventQuery holds the query parameters of the audit trail events API.
+type auditTrailEventQuery struct {
+	Date   string `url:"date,omitempty"`
+	Actor  string `url:"actor,omitempty"`
+	Action string `url:"action,omitempty"`
+	Type   string `url:"type,omitempty"`
+}
+
+func dataSourceHerokuAuditTrailEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuAuditTrailEventsRead,
+		Schema: map[string]*schema.Schema{
+			"enterprise_account": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"start_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in YYYY-MM-DD format"),
+			},
+
+			"end_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in YYYY-MM-DD format"),
+			},
+
+			"actor": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"action": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"app": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"actor_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"app_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"app_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"team_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuAuditTrailEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account := d.Get("enterprise_account").(string)
+
+	today := time.Now().UTC().Format(auditTrailDateLayout)
+	startDate, endDate := today, today
+	if v, ok := d.GetOk("end_date"); ok {
+		endDate = v.(string)
+	}
+	if v, ok := d.GetOk("start_date"); ok {
+		startDate = v.(string)
+	} else {
+		startDate = endDate
+	}
+
+	dates, err := auditTrailDates(startDate, endDate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	query := auditTrailEventQuery{
+		Actor:  d.Get("actor").(string),
+		Action: d.Get("action").(string),
+		Type:   d.Get("type").(string),
+	}
+	app := d.Get("app").(string)
+
+	events := make([]map[string]interface{}, 0)
+	for _, date := range dates {
+		query.Date = date
+
+		var dayEvents []heroku.AuditTrailEvent
+		err := client.Get(ctx, &dayEvents, fmt.Sprintf("/enterprise-accounts/%v/events", account), query, nil)
+		if err != nil {
+			return diag.Errorf("Error retrieving audit trail events of enterprise account %s on %s: %s", account, date, err)
+		}
+
+		for _, e := range dayEvents {
+			if app != "" && e.App.Name != app && e.App.ID != app {
+				continue
+			}
+
+			events = append(events, map[string]interface{}{
+				"id":          e.ID,
+				"type":        e.Type,
+				"action":      e.Action,
+				"actor_email": e.Actor.Email,
+				"app_id":      e.App.ID,
+				"app_name":    e.App.Name,
+				"team_name":   e.Team.Name,
+				"ip_address":  e.Request.IPAddress,
+				"created_at":  e.CreatedAt.Format(time.RFC3339),
+			})
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", account, startDate, endDate))
+	if err := d.Set("events", events); err != nil {
+		return diag.Errorf("Error setting audit trail events of enterprise account %s: %s", account, err)
+	}
+
+	return nil
+}
+
+// auditTrailDates returns each date from start to end, inclusive.
+func auditTrailDates(start, end string) ([]string, error) {
+	startTime, err := time.Parse(auditTrailDateLayout, start)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing start_date %s: %s", start, err)
+	}
+	endTime, err := time.Parse(auditTrailDateLayout, end)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing end_date %s: %s", end, err)
+	}
+
+	if endTime.Before(startTime) {
+		return nil, fmt.Errorf("end_date %s is before start_date %s", end, start)
+	}
+
+	var dates []string
+	for t := startTime; !t.After(endTime); t = t.AddDate(0, 0, 1) {
+		dates = append(dates, t.Format(auditTrailDateLayout))
+	}
+
+	if len(dates) > auditTrailMaxDays {
+		return nil, fmt.Errorf("the range from start_date %s to end_date %s spans %d days, more than the maximum of %d", start, end, len(dates), auditTrailMaxDays)
+	}
+
+	return dates, nil
+}
@@ -0,0 +1,56 @@
+package heroku
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuAuditTrailEvents_Basic(t *testing.T) {
+	account := testAccConfig.GetEnterpriseAccountOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAuditTrailEventsWithDataSource_Basic(account),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.heroku_audit_trail_events.foobar", "events.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAuditTrailEventsWithDataSource_Basic(account string) string {
+	return fmt.Sprintf(`
+data "heroku_audit_trail_events" "foobar" {
+  enterprise_account = "%s"
+  type               = "app"
+}
+`, account)
+}
+
+func TestAuditTrailDates(t *testing.T) {
+	dates, err := auditTrailDates("2020-02-27", "2020-03-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{"2020-02-27", "2020-02-28", "2020-02-29", "2020-03-01"}
+	if !reflect.DeepEqual(dates, expected) {
+		t.Errorf("dates = %v, expected %v", dates, expected)
+	}
+
+	if _, err := auditTrailDates("2020-03-01", "2020-02-27"); err == nil {
+		t.Errorf("expected an error for an end date before the start date")
+	}
+
+	if _, err := auditTrailDates("2020-01-01", "2020-03-01"); err == nil {
+		t.Errorf("expected an error for a range of more than %d days", auditTrailMaxDays)
+	}
+}
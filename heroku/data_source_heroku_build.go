@@ -0,0 +1,169 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuBuild() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuBuildRead,
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"build_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stack": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"buildpacks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"source_checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"slug_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"output_stream_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuBuildRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appName := d.Get("app").(string)
+
+	var build *heroku.Build
+	if v, ok := d.GetOk("build_id"); ok {
+		b, err := client.BuildInfo(ctx, appName, v.(string))
+		if err != nil {
+			return diag.Errorf("Error retrieving build %s of app %s: %s", v.(string), appName, err)
+		}
+		build = b
+	} else {
+		builds, err := client.BuildList(ctx, appName, &heroku.ListRange{Field: "created_at", Descending: true, Max: 1})
+		if err != nil {
+			return diag.Errorf("Error retrieving builds of app %s: %s", appName, err)
+		}
+		if len(builds) == 0 {
+			return diag.Errorf("no builds found for app %s", appName)
+		}
+		build = &builds[0]
+	}
+
+	d.SetId(build.ID)
+	if err := d.Set("build_id", build.ID); err != nil {
+		return setAttributeDiagnostics("build_id", err)
+	}
+	if err := d.Set("status", build.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("stack", build.Stack); err != nil {
+		return setAttributeDiagnostics("stack", err)
+	}
+	if err := d.Set("output_stream_url", build.OutputStreamURL); err != nil {
+		return setAttributeDiagnostics("output_stream_url", err)
+	}
+	if err := d.Set("created_at", build.CreatedAt.Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("created_at", err)
+	}
+	if err := d.Set("user_email", build.User.Email); err != nil {
+		return setAttributeDiagnostics("user_email", err)
+	}
+
+	buildpacks := make([]string, 0, len(build.Buildpacks))
+	for _, b := range build.Buildpacks {
+		buildpacks = append(buildpacks, b.URL)
+	}
+	if err := d.Set("buildpacks", buildpacks); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting buildpacks: %s", err))
+	}
+
+	if err := d.Set("source_url", build.SourceBlob.URL); err != nil {
+		return setAttributeDiagnostics("source_url", err)
+	}
+	if build.SourceBlob.Checksum != nil {
+		if err := d.Set("source_checksum", *build.SourceBlob.Checksum); err != nil {
+			return setAttributeDiagnostics("source_checksum", err)
+		}
+	}
+	if build.SourceBlob.Version != nil {
+		if err := d.Set("source_version", *build.SourceBlob.Version); err != nil {
+			return setAttributeDiagnostics("source_version", err)
+		}
+	}
+
+	if build.Release != nil {
+		if err := d.Set("release_id", build.Release.ID); err != nil {
+			return setAttributeDiagnostics("release_id", err)
+		}
+	}
+
+	if build.Slug != nil {
+		if err := d.Set("slug_id", build.Slug.ID); err != nil {
+			return setAttributeDiagnostics("slug_id", err)
+		}
+	}
+
+	return nil
+}
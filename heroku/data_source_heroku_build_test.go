@@ -0,0 +1,63 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuBuild_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuBuildWithDatasourceBasic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_build.by_id", "id", "heroku_build.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_build.by_id", "status", "succeeded"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_build.by_id", "source_checksum", "heroku_build.foobar", "local_checksum"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_build.by_id", "release_id", "heroku_build.foobar", "release_id"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_build.latest", "id", "heroku_build.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuBuildWithDatasourceBasic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_build" "foobar" {
+  app = heroku_app.foobar.id
+  source {
+    path = "test-fixtures/app.tgz"
+  }
+}
+
+data "heroku_build" "by_id" {
+  app      = heroku_app.foobar.id
+  build_id = heroku_build.foobar.id
+}
+
+data "heroku_build" "latest" {
+  app        = heroku_app.foobar.id
+  depends_on = [heroku_build.foobar]
+}
+`, appName)
+}
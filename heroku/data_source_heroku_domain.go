@@ -0,0 +1,128 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuDomain() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuDomainRead,
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"app_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_record_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_target": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"kind": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"sni_endpoint_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"acm_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"acm_status_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	app := d.Get("app").(string)
+	hostname := d.Get("hostname").(string)
+
+	do, err := client.DomainInfo(ctx, app, hostname)
+	if err != nil {
+		return diag.Errorf("Error retrieving domain %s of app %s: %s", hostname, app, err)
+	}
+
+	d.SetId(do.ID)
+	if err := d.Set("hostname", do.Hostname); err != nil {
+		return setAttributeDiagnostics("hostname", err)
+	}
+	if err := d.Set("app_id", do.App.ID); err != nil {
+		return setAttributeDiagnostics("app_id", err)
+	}
+	if err := d.Set("kind", do.Kind); err != nil {
+		return setAttributeDiagnostics("kind", err)
+	}
+	if err := d.Set("status", do.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("dns_record_type", domainDNSRecordType(do.Hostname)); err != nil {
+		return setAttributeDiagnostics("dns_record_type", err)
+	}
+
+	if do.CName != nil {
+		if err := d.Set("cname", *do.CName); err != nil {
+			return setAttributeDiagnostics("cname", err)
+		}
+		if err := d.Set("dns_target", *do.CName); err != nil {
+			return setAttributeDiagnostics("dns_target", err)
+		}
+	}
+
+	if do.SniEndpoint != nil {
+		if err := d.Set("sni_endpoint_id", do.SniEndpoint.ID); err != nil {
+			return setAttributeDiagnostics("sni_endpoint_id", err)
+		}
+	}
+
+	if do.AcmStatus != nil {
+		if err := d.Set("acm_status", *do.AcmStatus); err != nil {
+			return setAttributeDiagnostics("acm_status", err)
+		}
+	}
+
+	if do.AcmStatusReason != nil {
+		if err := d.Set("acm_status_reason", *do.AcmStatusReason); err != nil {
+			return setAttributeDiagnostics("acm_status_reason", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuDomain_Basic(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+	hostname := fmt.Sprintf("terraform-tftest-%s.example.com", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuDomainWithDatasourceBasic(appName, hostname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_domain.foobar", "id", "heroku_domain.foobar", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_domain.foobar", "cname", "heroku_domain.foobar", "cname"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_domain.foobar", "app_id", "heroku_app.foobar", "uuid"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_domain.foobar", "hostname", hostname),
+					resource.TestCheckResourceAttr(
+						"data.heroku_domain.foobar", "kind", "custom"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuDomainWithDatasourceBasic(appName, hostname string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_domain" "foobar" {
+  app      = heroku_app.foobar.name
+  hostname = "%s"
+}
+
+data "heroku_domain" "foobar" {
+  app      = heroku_app.foobar.name
+  hostname = heroku_domain.foobar.hostname
+}
+`, appName, hostname)
+}
@@ -0,0 +1,84 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuDrain() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuDrainRead,
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"token": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"addon_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"addon_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuDrainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	app := d.Get("app").(string)
+	url := d.Get("url").(string)
+
+	drains, err := client.LogDrainList(ctx, app, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving drains of app %s: %s", app, err)
+	}
+
+	var dr *heroku.LogDrain
+	for i := range drains {
+		if drains[i].URL == url {
+			dr = &drains[i]
+			break
+		}
+	}
+	if dr == nil {
+		return diag.Errorf("Error retrieving drain of app %s: no drain with URL %s", app, url)
+	}
+
+	d.SetId(dr.ID)
+	if err := d.Set("url", dr.URL); err != nil {
+		return setAttributeDiagnostics("url", err)
+	}
+	if err := d.Set("token", dr.Token); err != nil {
+		return setAttributeDiagnostics("token", err)
+	}
+
+	// Drains created by add-ons, such as logging add-ons, are linked to their add-on.
+	if dr.Addon != nil {
+		if err := d.Set("addon_id", dr.Addon.ID); err != nil {
+			return setAttributeDiagnostics("addon_id", err)
+		}
+		if err := d.Set("addon_name", dr.Addon.Name); err != nil {
+			return setAttributeDiagnostics("addon_name", err)
+		}
+	}
+
+	return nil
+}
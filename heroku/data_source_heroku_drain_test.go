@@ -0,0 +1,43 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuDrain_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuDrainWithDataSource_Basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_drain.foobar", "id", "heroku_drain.foobar", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_drain.foobar", "token", "heroku_drain.foobar", "token"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_drain.foobar", "addon_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuDrainWithDataSource_Basic(appName string) string {
+	return testAccCheckHerokuDrainConfig_basic(appName) + `
+
+data "heroku_drain" "foobar" {
+  app = heroku_drain.foobar.app
+  url = heroku_drain.foobar.url
+}
+`
+}
@@ -0,0 +1,187 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dynoMetricsQuery holds the query parameters of the dyno metrics API.
+type dynoMetricsQuery struct {
+	ProcessType string    `url:"process_type"`
+	StartTime   time.Time `url:"start_time"`
+	EndTime     time.Time `url:"end_time"`
+	Step        string    `url:"step"`
+}
+
+// dynoMetrics is a time series of the metrics API, keyed by metric name. Missing
+// data points are null.
+type dynoMetrics struct {
+	Data map[string][]*float64 `json:"data"`
+}
+
+func dataSourceHerokuDynoMetrics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuDynoMetricsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"process_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "web",
+			},
+
+			"hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 24),
+			},
+
+			"start_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"end_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"load_mean": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"load_p95": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"load_max": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"memory_mean": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"memory_p95": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"memory_max": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"memory_quota": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuDynoMetricsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).MetricsAPI
+
+	appID := d.Get("app_id").(string)
+	processType := d.Get("process_type").(string)
+
+	end := time.Now().UTC().Truncate(time.Minute)
+	query := dynoMetricsQuery{
+		ProcessType: processType,
+		StartTime:   end.Add(-time.Duration(d.Get("hours").(int)) * time.Hour),
+		EndTime:     end,
+		Step:        "1m",
+	}
+
+	var load dynoMetrics
+	if err := client.Get(ctx, &load, fmt.Sprintf("/metrics/%s/dyno/load", appID), query, nil); err != nil {
+		return diag.Errorf("Error retrieving load of app %s %s dynos: %s", appID, processType, err)
+	}
+
+	var memory dynoMetrics
+	if err := client.Get(ctx, &memory, fmt.Sprintf("/metrics/%s/dyno/memory", appID), query, nil); err != nil {
+		return diag.Errorf("Error retrieving memory of app %s %s dynos: %s", appID, processType, err)
+	}
+
+	d.SetId(buildCompositeID(appID, processType))
+	if err := d.Set("start_time", query.StartTime.Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("start_time", err)
+	}
+	if err := d.Set("end_time", query.EndTime.Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("end_time", err)
+	}
+
+	loadMean, loadP95, loadMax := dynoMetricsSummary(load.Data["load_avg_1m"])
+	if err := d.Set("load_mean", loadMean); err != nil {
+		return setAttributeDiagnostics("load_mean", err)
+	}
+	if err := d.Set("load_p95", loadP95); err != nil {
+		return setAttributeDiagnostics("load_p95", err)
+	}
+	if err := d.Set("load_max", loadMax); err != nil {
+		return setAttributeDiagnostics("load_max", err)
+	}
+
+	memoryMean, memoryP95, memoryMax := dynoMetricsSummary(memory.Data["memory_total"])
+	if err := d.Set("memory_mean", memoryMean); err != nil {
+		return setAttributeDiagnostics("memory_mean", err)
+	}
+	if err := d.Set("memory_p95", memoryP95); err != nil {
+		return setAttributeDiagnostics("memory_p95", err)
+	}
+	if err := d.Set("memory_max", memoryMax); err != nil {
+		return setAttributeDiagnostics("memory_max", err)
+	}
+
+	_, _, memoryQuota := dynoMetricsSummary(memory.Data["memory_quota"])
+	if err := d.Set("memory_quota", memoryQuota); err != nil {
+		return setAttributeDiagnostics("memory_quota", err)
+	}
+
+	return nil
+}
+
+// dynoMetricsSummary returns the mean, 95th percentile and maximum of the data
+// points of a time series, ignoring missing data points. They are all 0 when
+// there are no data points, e.g. when the process type has no running dynos.
+func dynoMetricsSummary(series []*float64) (mean, p95, max float64) {
+	values := make([]float64, 0, len(series))
+	for _, v := range series {
+		if v != nil {
+			values = append(values, *v)
+		}
+	}
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+
+	sort.Float64s(values)
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	// Nearest-rank percentile.
+	rank := int(math.Ceil(0.95*float64(len(values)))) - 1
+
+	return sum / float64(len(values)), values[rank], values[len(values)-1]
+}
@@ -0,0 +1,64 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuDynoMetrics_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuDynoMetricsWithDataSource_Basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_dyno_metrics.foobar", "process_type", "web"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_dyno_metrics.foobar", "start_time"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_dyno_metrics.foobar", "load_max", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuDynoMetricsWithDataSource_Basic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+data "heroku_dyno_metrics" "foobar" {
+  app_id = heroku_app.foobar.uuid
+}
+`, appName)
+}
+
+func TestDynoMetricsSummary(t *testing.T) {
+	series := make([]*float64, 0, 101)
+	for i := 1; i <= 100; i++ {
+		v := float64(i)
+		series = append(series, &v)
+	}
+	series = append(series, nil)
+
+	mean, p95, max := dynoMetricsSummary(series)
+	if mean != 50.5 || p95 != 95 || max != 100 {
+		t.Fatalf("expected mean 50.5, p95 95 and max 100, got %g, %g and %g", mean, p95, max)
+	}
+
+	if mean, p95, max := dynoMetricsSummary([]*float64{nil}); mean != 0 || p95 != 0 || max != 0 {
+		t.Fatalf("expected zeros without data points, got %g, %g and %g", mean, p95, max)
+	}
+}
@@ -0,0 +1,92 @@
+package heroku
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuEnterpriseAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuEnterpriseAccountRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"trial": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"identity_provider_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"identity_provider_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuEnterpriseAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	name := d.Get("name").(string)
+
+	account, err := client.EnterpriseAccountInfo(ctx, name)
+	if err != nil {
+		return diag.Errorf("Error retrieving enterprise account %s: %s", name, err)
+	}
+
+	d.SetId(account.ID)
+	if err := d.Set("name", account.Name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("permissions", account.Permissions); err != nil {
+		return setAttributeDiagnostics("permissions", err)
+	}
+	if err := d.Set("trial", account.Trial); err != nil {
+		return setAttributeDiagnostics("trial", err)
+	}
+	if err := d.Set("created_at", account.CreatedAt.Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("created_at", err)
+	}
+
+	if account.IdentityProvider != nil {
+		if err := d.Set("identity_provider_id", account.IdentityProvider.ID); err != nil {
+			return setAttributeDiagnostics("identity_provider_id", err)
+		}
+		if err := d.Set("identity_provider_name", account.IdentityProvider.Name); err != nil {
+			return setAttributeDiagnostics("identity_provider_name", err)
+		}
+	} else {
+		if err := d.Set("identity_provider_id", ""); err != nil {
+			return setAttributeDiagnostics("identity_provider_id", err)
+		}
+		if err := d.Set("identity_provider_name", ""); err != nil {
+			return setAttributeDiagnostics("identity_provider_name", err)
+		}
+	}
+
+	return nil
+}
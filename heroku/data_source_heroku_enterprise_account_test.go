@@ -0,0 +1,38 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuEnterpriseAccount_Basic(t *testing.T) {
+	// There is no heroku_enterprise_account resource, so this test requires an existing enterprise account.
+	account := testAccConfig.GetEnterpriseAccountOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuEnterpriseAccountWithDataSource_Basic(account),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.heroku_enterprise_account.foobar", "name", account),
+					resource.TestCheckResourceAttrSet("data.heroku_enterprise_account.foobar", "permissions.#"),
+					resource.TestCheckResourceAttrSet("data.heroku_enterprise_account.foobar", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuEnterpriseAccountWithDataSource_Basic(account string) string {
+	return fmt.Sprintf(`
+data "heroku_enterprise_account" "foobar" {
+  name = "%s"
+}
+`, account)
+}
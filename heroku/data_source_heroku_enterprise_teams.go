@@ -0,0 +1,122 @@
+package heroku
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuEnterpriseTeams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuEnterpriseTeamsRead,
+		Schema: map[string]*schema.Schema{
+			"enterprise_account": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"teams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"member_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"default_permission": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"addons_controls": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuEnterpriseTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account := d.Get("enterprise_account").(string)
+
+	teams, err := client.TeamListByEnterpriseAccount(ctx, account, &heroku.ListRange{Field: "name", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving teams of enterprise account %s: %s", account, err)
+	}
+
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+
+	names := make([]string, 0, len(teams))
+	teamList := make([]map[string]interface{}, 0, len(teams))
+	for _, t := range teams {
+		members, err := client.TeamMemberList(ctx, t.ID, &heroku.ListRange{Field: "email", Max: 1000})
+		if err != nil {
+			return diag.Errorf("Error retrieving members of team %s: %s", t.Name, err)
+		}
+
+		preferences, err := client.TeamPreferencesList(ctx, t.ID)
+		if err != nil {
+			return diag.Errorf("Error retrieving preferences of team %s: %s", t.Name, err)
+		}
+
+		team := map[string]interface{}{
+			"id":           t.ID,
+			"name":         t.Name,
+			"member_count": len(members),
+			"created_at":   t.CreatedAt.Format(time.RFC3339),
+		}
+		if preferences.DefaultPermission != nil {
+			team["default_permission"] = *preferences.DefaultPermission
+		}
+		if preferences.AddonsControls != nil {
+			team["addons_controls"] = *preferences.AddonsControls
+		}
+
+		names = append(names, t.Name)
+		teamList = append(teamList, team)
+	}
+
+	d.SetId(account)
+	if err := d.Set("names", names); err != nil {
+		return setAttributeDiagnostics("names", err)
+	}
+	if err := d.Set("teams", teamList); err != nil {
+		return diag.Errorf("Error setting teams of enterprise account %s: %s", account, err)
+	}
+
+	return nil
+}
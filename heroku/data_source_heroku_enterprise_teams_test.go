@@ -0,0 +1,37 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuEnterpriseTeams_Basic(t *testing.T) {
+	account := testAccConfig.GetEnterpriseAccountOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuEnterpriseTeamsWithDataSource_Basic(account),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.heroku_enterprise_teams.foobar", "id", account),
+					resource.TestCheckResourceAttrSet("data.heroku_enterprise_teams.foobar", "names.#"),
+					resource.TestCheckResourceAttrSet("data.heroku_enterprise_teams.foobar", "teams.0.member_count"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuEnterpriseTeamsWithDataSource_Basic(account string) string {
+	return fmt.Sprintf(`
+data "heroku_enterprise_teams" "foobar" {
+  enterprise_account = "%s"
+}
+`, account)
+}
@@ -0,0 +1,208 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// enterpriseUsageQuery holds the query parameters of the monthly enterprise usage API.
+type enterpriseUsageQuery struct {
+	Start string `url:"start"`
+	End   string `url:"end,omitempty"`
+}
+
+// enterpriseUsageSchema returns the computed usage attributes shared by the
+// months, teams and apps of the enterprise usage data source.
+func enterpriseUsageSchema(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"dynos": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+
+		"addons": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+
+		"data": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+
+		"partner": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+	}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+func dataSourceHerokuEnterpriseUsage() *schema.Resource {
+	monthRegexp := regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuEnterpriseUsageRead,
+		Schema: map[string]*schema.Schema{
+			"enterprise_account": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"start": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(monthRegexp, "must be a month in YYYY-MM format"),
+			},
+
+			"end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(monthRegexp, "must be a month in YYYY-MM format"),
+			},
+
+			"months": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: enterpriseUsageSchema(map[string]*schema.Schema{
+						"month": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"space": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+
+						"connect": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+
+						"teams": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: enterpriseUsageSchema(map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"space": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+
+									"connect": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+
+									"apps": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: enterpriseUsageSchema(map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											}),
+										},
+									},
+								}),
+							},
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuEnterpriseUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	name := d.Get("enterprise_account").(string)
+
+	// The usage API only accepts the ID of the enterprise account.
+	account, err := client.EnterpriseAccountInfo(ctx, name)
+	if err != nil {
+		return diag.Errorf("Error retrieving enterprise account %s: %s", name, err)
+	}
+
+	query := enterpriseUsageQuery{
+		Start: d.Get("start").(string),
+		End:   d.Get("end").(string),
+	}
+
+	var usage heroku.EnterpriseAccountMonthlyUsageInfoResult
+	err = client.Get(ctx, &usage, fmt.Sprintf("/enterprise-accounts/%v/usage/monthly", account.ID), query, nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving usage of enterprise account %s: %s", account.Name, err)
+	}
+
+	months := make([]map[string]interface{}, 0, len(usage))
+	for _, u := range usage {
+		teams := make([]map[string]interface{}, 0, len(u.Teams))
+		for _, t := range u.Teams {
+			apps := make([]map[string]interface{}, 0, len(t.Apps))
+			for _, a := range t.Apps {
+				apps = append(apps, map[string]interface{}{
+					"name":    a.AppName,
+					"dynos":   a.Dynos,
+					"addons":  a.Addons,
+					"data":    a.Data,
+					"partner": a.Partner,
+				})
+			}
+
+			teams = append(teams, map[string]interface{}{
+				"id":      t.ID,
+				"name":    t.Name,
+				"dynos":   t.Dynos,
+				"addons":  t.Addons,
+				"data":    t.Data,
+				"partner": t.Partner,
+				"space":   t.Space,
+				"connect": t.Connect,
+				"apps":    apps,
+			})
+		}
+
+		months = append(months, map[string]interface{}{
+			"month":   u.Month,
+			"dynos":   u.Dynos,
+			"addons":  u.Addons,
+			"data":    u.Data,
+			"partner": u.Partner,
+			"space":   u.Space,
+			"connect": u.Connect,
+			"teams":   teams,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", account.ID, query.Start, query.End))
+	if err := d.Set("months", months); err != nil {
+		return diag.Errorf("Error setting usage of enterprise account %s: %s", account.Name, err)
+	}
+
+	return nil
+}
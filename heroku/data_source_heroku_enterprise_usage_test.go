@@ -0,0 +1,39 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuEnterpriseUsage_Basic(t *testing.T) {
+	account := testAccConfig.GetEnterpriseAccountOrSkip(t)
+	month := time.Now().UTC().AddDate(0, -1, 0).Format("2006-01")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuEnterpriseUsageWithDataSource_Basic(account, month),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.heroku_enterprise_usage.foobar", "months.#", "1"),
+					resource.TestCheckResourceAttr("data.heroku_enterprise_usage.foobar", "months.0.month", month),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuEnterpriseUsageWithDataSource_Basic(account, month string) string {
+	return fmt.Sprintf(`
+data "heroku_enterprise_usage" "foobar" {
+  enterprise_account = "%s"
+  start              = "%s"
+}
+`, account, month)
+}
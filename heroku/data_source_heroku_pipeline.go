@@ -38,9 +38,15 @@ func dataSourceHerokuPipelineRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(pipeline.ID)
-	d.Set("name", pipeline.Name)
-	d.Set("owner_id", pipeline.Owner.ID)
-	d.Set("owner_type", pipeline.Owner.Type)
+	if err := d.Set("name", pipeline.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("owner_id", pipeline.Owner.ID); err != nil {
+		return setAttributeError("owner_id", err)
+	}
+	if err := d.Set("owner_type", pipeline.Owner.Type); err != nil {
+		return setAttributeError("owner_type", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,154 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// postgresBehindByPattern matches the "Behind By" info of a follower, e.g.
+// "125 commits".
+var postgresBehindByPattern = regexp.MustCompile(`^(\d+) commits?$`)
+
+func dataSourceHerokuPostgresFollowers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuPostgresFollowersRead,
+		Schema: map[string]*schema.Schema{
+			"addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"max_behind_by": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"followers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"addon_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"addon_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"attachment_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"plan": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"behind_by": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuPostgresFollowersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	addonID := d.Get("addon_id").(string)
+	addon, err := client.AddOnInfo(ctx, addonID)
+	if err != nil {
+		return diag.Errorf("Error retrieving add-on %s: %s", addonID, err)
+	}
+
+	var database postgresDatabase
+	if err := config.PostgresAPI.Get(ctx, &database, postgresDatabasePath(addon.ID), nil, nil); err != nil {
+		return diag.Errorf("Error retrieving Postgres database %s: %s", addon.Name, err)
+	}
+
+	// The followers are listed by the names of their attachments to the leader's app.
+	attachmentNames := database.infoValues("Followers")
+	sort.Strings(attachmentNames)
+
+	maxBehindBy := 0
+	followers := make([]map[string]interface{}, 0, len(attachmentNames))
+	for _, name := range attachmentNames {
+		attachment, err := client.AddOnAttachmentInfoByApp(ctx, addon.App.ID, name)
+		if err != nil {
+			return diag.Errorf("Error retrieving follower attachment %s of app %s: %s", name, addon.App.Name, err)
+		}
+
+		var follower postgresDatabase
+		if err := config.PostgresAPI.Get(ctx, &follower, postgresDatabasePath(attachment.Addon.ID), nil, nil); err != nil {
+			return diag.Errorf("Error retrieving follower Postgres database %s: %s", attachment.Addon.Name, err)
+		}
+
+		behindBy, err := parsePostgresBehindBy(follower.infoValues("Behind By"))
+		if err != nil {
+			return diag.Errorf("Error parsing replication lag of follower %s: %s", attachment.Addon.Name, err)
+		}
+		if behindBy > maxBehindBy {
+			maxBehindBy = behindBy
+		}
+
+		f := map[string]interface{}{
+			"addon_id":        attachment.Addon.ID,
+			"addon_name":      attachment.Addon.Name,
+			"attachment_name": attachment.Name,
+			"behind_by":       behindBy,
+		}
+		if v := follower.infoValues("Plan"); len(v) > 0 {
+			f["plan"] = v[0]
+		}
+		if v := follower.infoValues("Status"); len(v) > 0 {
+			f["status"] = v[0]
+		}
+		followers = append(followers, f)
+	}
+
+	d.SetId(addon.ID)
+	if err := d.Set("max_behind_by", maxBehindBy); err != nil {
+		return setAttributeDiagnostics("max_behind_by", err)
+	}
+	if err := d.Set("followers", followers); err != nil {
+		return diag.Errorf("Error setting followers: %s", err)
+	}
+
+	return nil
+}
+
+// parsePostgresBehindBy returns the number of commits a follower is behind its
+// leader. A follower without "Behind By" info is caught up.
+func parsePostgresBehindBy(values []string) (int, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	m := postgresBehindByPattern.FindStringSubmatch(values[0])
+	if m == nil {
+		return 0, fmt.Errorf("unexpected format %q", values[0])
+	}
+
+	return strconv.Atoi(m[1])
+}
@@ -0,0 +1,90 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestParsePostgresBehindBy(t *testing.T) {
+	cases := []struct {
+		values   []string
+		expected int
+		err      bool
+	}{
+		{nil, 0, false},
+		{[]string{"0 commits"}, 0, false},
+		{[]string{"1 commit"}, 1, false},
+		{[]string{"125 commits"}, 125, false},
+		{[]string{"unknown"}, 0, true},
+	}
+
+	for _, tc := range cases {
+		actual, err := parsePostgresBehindBy(tc.values)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for %v", tc.values)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %v: %s", tc.values, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("expected %d for %v, got %d", tc.expected, tc.values, actual)
+		}
+	}
+}
+
+func TestAccDatasourceHerokuPostgresFollowers_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuPostgresFollowersWithDatasource_Basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_postgres_followers.foobar", "followers.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_postgres_followers.foobar", "followers.0.addon_id", "heroku_addon.follower", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPostgresFollowersWithDatasource_Basic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "leader" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+}
+
+resource "heroku_addon" "follower" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+
+  config = {
+    follow = heroku_addon.leader.name
+  }
+}
+
+data "heroku_postgres_followers" "foobar" {
+  addon_id = heroku_addon.leader.id
+
+  depends_on = [heroku_addon.follower]
+}
+`, appName)
+}
@@ -0,0 +1,39 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuRateLimit() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuRateLimitRead,
+		Schema: map[string]*schema.Schema{
+			"remaining": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuRateLimitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	// Heroku does not count this request against the rate limit.
+	rateLimit, err := client.RateLimitInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Error retrieving the rate limit of the account: %s", err)
+	}
+
+	// The rate limit is that of the provider's account, which is the only one
+	// that can be read.
+	d.SetId("account/rate-limits")
+	if err := d.Set("remaining", rateLimit.Remaining); err != nil {
+		return setAttributeDiagnostics("remaining", err)
+	}
+
+	return nil
+}
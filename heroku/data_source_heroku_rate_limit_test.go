@@ -0,0 +1,64 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuRateLimit_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuRateLimitWithDataSource_Basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.heroku_rate_limit.current", "id", "account/rate-limits"),
+					resource.TestCheckResourceAttrSet("data.heroku_rate_limit.current", "remaining"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuRateLimitWithDataSource_Basic() string {
+	return `
+data "heroku_rate_limit" "current" {}
+`
+}
+
+func TestDataSourceHerokuRateLimitRead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/account/rate-limits" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"remaining": 4321}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := dataSourceHerokuRateLimit().TestResourceData()
+	if diags := dataSourceHerokuRateLimitRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if remaining := d.Get("remaining").(int); remaining != 4321 {
+		t.Errorf("Expected 4321 remaining requests, got %d", remaining)
+	}
+	if d.Id() != "account/rate-limits" {
+		t.Errorf("Unexpected id: %s", d.Id())
+	}
+}
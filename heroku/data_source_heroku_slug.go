@@ -0,0 +1,116 @@
+package heroku
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceHerokuSlug() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSlugRead,
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"slug_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"blob": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"buildpack_provided_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"commit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"commit_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"process_types": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"stack": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stack_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSlugRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appName := d.Get("app").(string)
+	slugID := d.Get("slug_id").(string)
+
+	slug, err := client.SlugInfo(ctx, appName, slugID)
+	if err != nil {
+		return diag.Errorf("Error retrieving slug %s of app %s: %s", slugID, appName, err)
+	}
+
+	d.SetId(slug.ID)
+	if err := setSlugState(d, slug); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("created_at", slug.CreatedAt.Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("created_at", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSlug_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSlugWithDatasourceBasic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_slug.foobar", "id", "heroku_slug.foobar", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_slug.foobar", "checksum", "heroku_slug.foobar", "checksum"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_slug.foobar", "commit", "abcdef"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_slug.foobar", "process_types.test", "echo 'Just a test'"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_slug.foobar", "stack", "heroku_slug.foobar", "stack"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSlugWithDatasourceBasic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_slug" "foobar" {
+  app       = heroku_app.foobar.id
+  file_path = "test-fixtures/slug.tgz"
+  commit    = "abcdef"
+
+  process_types = {
+    test = "echo 'Just a test'"
+  }
+}
+
+data "heroku_slug" "foobar" {
+  app     = heroku_app.foobar.id
+  slug_id = heroku_slug.foobar.id
+}
+`, appName)
+}
@@ -52,10 +52,20 @@ func dataSourceHerokuSpace() *schema.Resource {
 				Default:  nil,
 			},
 
+			"state_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"shield": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+
+			"generation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -72,8 +82,15 @@ func dataSourceHerokuSpaceRead(d *schema.ResourceData, m interface{}) error {
 	space := spaceRaw.(*spaceWithNAT)
 
 	d.SetId(name)
-	d.Set("state", space.State)
-	d.Set("shield", space.Shield)
+	if err := d.Set("state", space.State); err != nil {
+		return setAttributeError("state", err)
+	}
+	if err := d.Set("state_reason", space.stateReason()); err != nil {
+		return setAttributeError("state_reason", err)
+	}
+	if err := d.Set("shield", space.Shield); err != nil {
+		return setAttributeError("shield", err)
+	}
 
 	return resourceHerokuSpaceRead(d, m)
 }
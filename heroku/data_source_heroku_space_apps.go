@@ -0,0 +1,136 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuSpaceApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSpaceAppsRead,
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"space_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cidr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"apps": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"internal_routing": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"web_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"internal_hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"service_discovery_domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSpaceAppsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	spaceIdentity := d.Get("space").(string)
+
+	space, err := client.SpaceInfo(ctx, spaceIdentity)
+	if err != nil {
+		return diag.Errorf("Error retrieving space %s: %s", spaceIdentity, err)
+	}
+
+	// Apps in a space always belong to the team of the space.
+	teamApps, err := client.TeamAppListByTeam(ctx, space.Team.Name, &heroku.ListRange{Field: "name", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving apps of space %s: %s", space.Name, err)
+	}
+
+	d.SetId(space.ID)
+	if err := d.Set("space_id", space.ID); err != nil {
+		return setAttributeDiagnostics("space_id", err)
+	}
+	if err := d.Set("cidr", space.CIDR); err != nil {
+		return setAttributeDiagnostics("cidr", err)
+	}
+
+	names := make([]string, 0)
+	apps := make([]map[string]interface{}, 0)
+	for _, a := range teamApps {
+		if a.Space == nil || a.Space.ID != space.ID {
+			continue
+		}
+
+		internalRouting := a.InternalRouting != nil && *a.InternalRouting
+		internalHostname := ""
+		if internalRouting {
+			internalHostname = herokuHostname(&herokuApplication{Name: a.Name, WebURL: a.WebURL})
+		}
+
+		names = append(names, a.Name)
+		apps = append(apps, map[string]interface{}{
+			"id":                       a.ID,
+			"name":                     a.Name,
+			"internal_routing":         internalRouting,
+			"web_url":                  a.WebURL,
+			"internal_hostname":        internalHostname,
+			"service_discovery_domain": fmt.Sprintf("%s.app.localspace", a.Name),
+		})
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return setAttributeDiagnostics("names", err)
+	}
+	if err := d.Set("apps", apps); err != nil {
+		return diag.Errorf("Error setting apps of space %s: %s", space.Name, err)
+	}
+
+	return nil
+}
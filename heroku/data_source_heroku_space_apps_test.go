@@ -0,0 +1,66 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSpaceApps_Basic(t *testing.T) {
+	spaceName := fmt.Sprintf("tftest-space-apps-%s", acctest.RandString(3))
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	orgName := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpaceApps_basic(spaceName, appName, orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_apps.foobar", "names.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_apps.foobar", "apps.0.name", appName),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_apps.foobar", "apps.0.internal_routing", "true"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_apps.foobar", "apps.0.service_discovery_domain", appName+".app.localspace"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_space_apps.foobar", "cidr"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpaceApps_basic(spaceName, appName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+resource "heroku_app" "foobar" {
+  name             = "%s"
+  space            = heroku_space.foobar.name
+  region           = "virginia"
+  internal_routing = true
+
+  organization {
+    name = "%s"
+  }
+}
+
+data "heroku_space_apps" "foobar" {
+  space = heroku_space.foobar.name
+
+  depends_on = [heroku_app.foobar]
+}
+`, spaceName, orgName, appName, orgName)
+}
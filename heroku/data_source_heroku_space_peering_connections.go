@@ -0,0 +1,108 @@
+package heroku
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuSpacePeeringConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSpacePeeringConnectionsRead,
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"peering_connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vpc_peering_connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"aws_account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"aws_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"cidr_blocks": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"expires": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSpacePeeringConnectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	space := d.Get("space").(string)
+
+	peerings, err := client.PeeringList(ctx, space, nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving peering connections of space %s: %s", space, err)
+	}
+
+	d.SetId(space)
+
+	peeringConnections := make([]map[string]interface{}, 0, len(peerings))
+	for _, p := range peerings {
+		peering := map[string]interface{}{
+			"vpc_peering_connection_id": p.PcxID,
+			"type":                      p.Type,
+			"status":                    p.Status,
+			"aws_account_id":            p.AwsAccountID,
+			"aws_region":                p.AwsRegion,
+			"vpc_id":                    p.AwsVpcID,
+			"cidr_blocks":               p.CIDRBlocks,
+		}
+		if !p.Expires.IsZero() {
+			peering["expires"] = p.Expires.Format(time.RFC3339)
+		}
+		peeringConnections = append(peeringConnections, peering)
+	}
+
+	if err := d.Set("peering_connections", peeringConnections); err != nil {
+		return diag.Errorf("Error setting peering connections of space %s: %s", space, err)
+	}
+
+	return nil
+}
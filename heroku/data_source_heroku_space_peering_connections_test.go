@@ -0,0 +1,46 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSpacePeeringConnections_Basic(t *testing.T) {
+	spaceName := fmt.Sprintf("tftest-space-peers-%s", acctest.RandString(3))
+	orgName := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpacePeeringConnections_basic(spaceName, orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_peering_connections.foobar", "id", spaceName),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_space_peering_connections.foobar", "peering_connections.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpacePeeringConnections_basic(spaceName string, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+data "heroku_space_peering_connections" "foobar" {
+  space = heroku_space.foobar.name
+}
+`, spaceName, orgName)
+}
@@ -65,12 +65,24 @@ func dataSourceHerokuSpacePeeringInfoRead(d *schema.ResourceData, m interface{})
 		return err
 	}
 
-	d.Set("aws_account_id", peeringInfo.AwsAccountID)
-	d.Set("aws_region", peeringInfo.AwsRegion)
-	d.Set("vpc_id", peeringInfo.VpcID)
-	d.Set("vpc_cidr", peeringInfo.VpcCIDR)
-	d.Set("dyno_cidr_blocks", peeringInfo.DynoCIDRBlocks)
-	d.Set("unavailable_cidr_blocks", peeringInfo.UnavailableCIDRBlocks)
+	if err := d.Set("aws_account_id", peeringInfo.AwsAccountID); err != nil {
+		return setAttributeError("aws_account_id", err)
+	}
+	if err := d.Set("aws_region", peeringInfo.AwsRegion); err != nil {
+		return setAttributeError("aws_region", err)
+	}
+	if err := d.Set("vpc_id", peeringInfo.VpcID); err != nil {
+		return setAttributeError("vpc_id", err)
+	}
+	if err := d.Set("vpc_cidr", peeringInfo.VpcCIDR); err != nil {
+		return setAttributeError("vpc_cidr", err)
+	}
+	if err := d.Set("dyno_cidr_blocks", peeringInfo.DynoCIDRBlocks); err != nil {
+		return setAttributeError("dyno_cidr_blocks", err)
+	}
+	if err := d.Set("unavailable_cidr_blocks", peeringInfo.UnavailableCIDRBlocks); err != nil {
+		return setAttributeError("unavailable_cidr_blocks", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,149 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuSpaceVPNConnection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSpaceVPNConnectionRead,
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"routable_cidrs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"space_cidr_block": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ike_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"customer_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"pre_shared_key": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"last_status_change": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSpaceVPNConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	space := d.Get("space").(string)
+	name := d.Get("name").(string)
+
+	conn, err := client.VPNConnectionInfo(ctx, space, name)
+	if err != nil {
+		return diag.Errorf("Error reading VPN connection %s of space %s: %s", name, space, err)
+	}
+
+	d.SetId(buildCompositeID(space, conn.ID))
+	if err := d.Set("name", conn.Name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("public_ip", conn.PublicIP); err != nil {
+		return setAttributeDiagnostics("public_ip", err)
+	}
+	if err := d.Set("routable_cidrs", conn.RoutableCidrs); err != nil {
+		return setAttributeDiagnostics("routable_cidrs", err)
+	}
+	if err := d.Set("space_cidr_block", conn.SpaceCIDRBlock); err != nil {
+		return setAttributeDiagnostics("space_cidr_block", err)
+	}
+	if err := d.Set("ike_version", conn.IKEVersion); err != nil {
+		return setAttributeDiagnostics("ike_version", err)
+	}
+	if err := d.Set("status", conn.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("status_message", conn.StatusMessage); err != nil {
+		return setAttributeDiagnostics("status_message", err)
+	}
+
+	tunnels := []map[string]interface{}{}
+	for _, t := range conn.Tunnels {
+		tunnels = append(tunnels, map[string]interface{}{
+			"ip":                 t.IP,
+			"customer_ip":        t.CustomerIP,
+			"pre_shared_key":     t.PreSharedKey,
+			"status":             t.Status,
+			"status_message":     t.StatusMessage,
+			"last_status_change": t.LastStatusChange,
+		})
+	}
+	if err := d.Set("tunnels", tunnels); err != nil {
+		return diag.Errorf("Error setting tunnels of VPN connection %s: %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSpaceVPNConnection_Basic(t *testing.T) {
+	spaceName := fmt.Sprintf("tftest1-%s", acctest.RandString(10))
+	org := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpaceVPNConnectionWithDatasource(spaceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_space_vpn_connection.foobar", "id", "heroku_space_vpn_connection.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_vpn_connection.foobar", "status", "active"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_vpn_connection.foobar", "routable_cidrs.0", "10.100.0.0/16"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_space_vpn_connection.foobar", "tunnels.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_space_vpn_connection.foobar", "tunnels.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpaceVPNConnectionWithDatasource(spaceName, orgName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "heroku_space_vpn_connection" "foobar" {
+  space = heroku_space.foobar.name
+  name  = heroku_space_vpn_connection.foobar.name
+}
+`, testAccCheckHerokuVPNConnectionConfig_basic(spaceName, orgName))
+}
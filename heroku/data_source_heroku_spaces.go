@@ -0,0 +1,130 @@
+package heroku
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuSpaces() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSpacesRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"spaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"team": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"shield": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"data_cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSpacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Get("team").(string)
+	region := d.Get("region").(string)
+
+	spaces, err := client.SpaceList(ctx, &heroku.ListRange{Field: "name", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving spaces: %s", err)
+	}
+
+	sort.Slice(spaces, func(i, j int) bool { return spaces[i].Name < spaces[j].Name })
+
+	names := make([]string, 0, len(spaces))
+	spaceList := make([]map[string]interface{}, 0, len(spaces))
+	for _, s := range spaces {
+		if team != "" && s.Team.Name != team && s.Team.ID != team {
+			continue
+		}
+		if region != "" && s.Region.Name != region {
+			continue
+		}
+
+		names = append(names, s.Name)
+		spaceList = append(spaceList, map[string]interface{}{
+			"id":        s.ID,
+			"name":      s.Name,
+			"team":      s.Team.Name,
+			"region":    s.Region.Name,
+			"shield":    s.Shield,
+			"state":     s.State,
+			"cidr":      s.CIDR,
+			"data_cidr": s.DataCIDR,
+		})
+	}
+
+	d.SetId(buildCompositeID(team, region))
+	if err := d.Set("names", names); err != nil {
+		return setAttributeDiagnostics("names", err)
+	}
+	if err := d.Set("spaces", spaceList); err != nil {
+		return diag.Errorf("Error setting spaces: %s", err)
+	}
+
+	return nil
+}
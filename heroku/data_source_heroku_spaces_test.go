@@ -0,0 +1,53 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSpaces_Basic(t *testing.T) {
+	spaceName := fmt.Sprintf("tftest1-%s", acctest.RandString(10))
+	orgName := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpacesWithDatasource(spaceName, orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("space_found", "true"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_spaces.foobar", "spaces.0.team", orgName),
+					resource.TestCheckResourceAttr(
+						"data.heroku_spaces.foobar", "spaces.0.region", "virginia"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpacesWithDatasource(spaceName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+data "heroku_spaces" "foobar" {
+  team       = "%s"
+  region     = "virginia"
+  depends_on = [heroku_space.foobar]
+}
+
+output "space_found" {
+  value = contains(data.heroku_spaces.foobar.names, heroku_space.foobar.name)
+}
+`, spaceName, orgName, orgName)
+}
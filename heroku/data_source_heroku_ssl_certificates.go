@@ -0,0 +1,156 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuSSLCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSSLCertificatesRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"certificates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     sslCertificateResource(),
+			},
+
+			"next_expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSSLCertificatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appID := d.Get("app_id").(string)
+
+	endpoints, err := client.SniEndpointList(ctx, appID, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving SSL certificates of app %s: %s", appID, err)
+	}
+
+	d.SetId(appID)
+
+	var nextExpiresAt time.Time
+	certificates := make([]map[string]interface{}, 0, len(endpoints))
+	for _, ep := range endpoints {
+		certificates = append(certificates, flattenSSLCertificate(ep))
+
+		if nextExpiresAt.IsZero() || ep.SSLCert.ExpiresAt.Before(nextExpiresAt) {
+			nextExpiresAt = ep.SSLCert.ExpiresAt
+		}
+	}
+
+	if err := d.Set("certificates", certificates); err != nil {
+		return diag.Errorf("Error setting SSL certificates of app %s: %s", appID, err)
+	}
+
+	if !nextExpiresAt.IsZero() {
+		if err := d.Set("next_expires_at", nextExpiresAt.Format(time.RFC3339)); err != nil {
+			return setAttributeDiagnostics("next_expires_at", err)
+		}
+	} else {
+		if err := d.Set("next_expires_at", ""); err != nil {
+			return setAttributeDiagnostics("next_expires_at", err)
+		}
+	}
+
+	return nil
+}
+
+// sslCertificateResource is the schema of a certificate of an SNI endpoint.
+func sslCertificateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"cert_domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"issuer": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subject": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ca_signed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"self_signed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"starts_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func flattenSSLCertificate(ep heroku.SniEndpoint) map[string]interface{} {
+	certDomains := make([]string, 0, len(ep.SSLCert.CertDomains))
+	for _, v := range ep.SSLCert.CertDomains {
+		certDomains = append(certDomains, fmt.Sprintf("%v", v))
+	}
+
+	return map[string]interface{}{
+		"id":           ep.ID,
+		"name":         ep.Name,
+		"domains":      ep.Domains,
+		"cert_domains": certDomains,
+		"issuer":       ep.SSLCert.Issuer,
+		"subject":      ep.SSLCert.Subject,
+		"ca_signed":    ep.SSLCert.IsCaSigned,
+		"self_signed":  ep.SSLCert.IsSelfSigned,
+		"starts_at":    ep.SSLCert.StartsAt.Format(time.RFC3339),
+		"expires_at":   ep.SSLCert.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,54 @@
+package heroku
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSSLCertificates_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	wd, _ := os.Getwd()
+	certFile := wd + "/test-fixtures/terraform.cert"
+	keyFile := wd + "/test-fixtures/terraform.key"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSSLCertificatesWithDatasource(appName, certFile, keyFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_ssl_certificates.foobar", "certificates.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_ssl_certificates.foobar", "certificates.0.id", "heroku_ssl.one", "id"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_ssl_certificates.foobar", "certificates.0.expires_at"),
+					resource.TestCheckResourceAttrSet(
+						"data.heroku_ssl_certificates.foobar", "certificates.0.issuer"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_ssl_certificates.foobar", "next_expires_at",
+						"data.heroku_ssl_certificates.foobar", "certificates.0.expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSSLCertificatesWithDatasource(appName, certFile, keyFile string) string {
+	return fmt.Sprintf(`
+%s
+
+data "heroku_ssl_certificates" "foobar" {
+  app_id     = heroku_app.one.uuid
+  depends_on = [heroku_ssl.one]
+}
+`, testAccCheckHerokuSSLConfig(appName, certFile, keyFile))
+}
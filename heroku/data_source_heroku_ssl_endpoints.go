@@ -0,0 +1,99 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func dataSourceHerokuSSLEndpoints() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuSSLEndpointsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     sslCertificateResource(),
+			},
+
+			"covered_domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"uncovered_domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuSSLEndpointsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	appID := d.Get("app_id").(string)
+
+	endpoints, err := client.SniEndpointList(ctx, appID, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving SSL endpoints of app %s: %s", appID, err)
+	}
+
+	domains, err := client.DomainList(ctx, appID, &heroku.ListRange{Field: "hostname", Max: 1000})
+	if err != nil {
+		return diag.Errorf("Error retrieving domains of app %s: %s", appID, err)
+	}
+
+	d.SetId(appID)
+
+	flattened := make([]map[string]interface{}, 0, len(endpoints))
+	certDomains := make(map[string][]interface{}, len(endpoints))
+	for _, ep := range endpoints {
+		flattened = append(flattened, flattenSSLCertificate(ep))
+		certDomains[ep.ID] = ep.SSLCert.CertDomains
+	}
+
+	// A custom domain is covered when it has an ACM certificate, or when the SNI
+	// endpoint it is associated with has a certificate for its hostname.
+	covered := make([]string, 0, len(domains))
+	uncovered := make([]string, 0)
+	for _, do := range domains {
+		if do.Kind != "custom" {
+			continue
+		}
+
+		switch {
+		case do.AcmStatus != nil && *do.AcmStatus == "cert issued":
+			covered = append(covered, do.Hostname)
+		case do.SniEndpoint != nil && sslCertCoversHostname(certDomains[do.SniEndpoint.ID], do.Hostname):
+			covered = append(covered, do.Hostname)
+		default:
+			uncovered = append(uncovered, do.Hostname)
+		}
+	}
+
+	if err := d.Set("endpoints", flattened); err != nil {
+		return diag.Errorf("Error setting SSL endpoints of app %s: %s", appID, err)
+	}
+	if err := d.Set("covered_domains", covered); err != nil {
+		return setAttributeDiagnostics("covered_domains", err)
+	}
+	if err := d.Set("uncovered_domains", uncovered); err != nil {
+		return setAttributeDiagnostics("uncovered_domains", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package heroku
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuSSLEndpoints_Basic(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+	hostname := fmt.Sprintf("uncovered-tftest-%s.example.com", randString)
+
+	wd, _ := os.Getwd()
+	certFile := wd + "/test-fixtures/terraform.cert"
+	keyFile := wd + "/test-fixtures/terraform.key"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSSLEndpointsWithDatasource(appName, certFile, keyFile, hostname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_ssl_endpoints.foobar", "endpoints.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.heroku_ssl_endpoints.foobar", "endpoints.0.id", "heroku_ssl.one", "id"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_ssl_endpoints.foobar", "uncovered_domains.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_ssl_endpoints.foobar", "uncovered_domains.0", hostname),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSSLEndpointsWithDatasource(appName, certFile, keyFile, hostname string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "heroku_domain" "uncovered" {
+  app      = heroku_app.one.name
+  hostname = "%s"
+}
+
+data "heroku_ssl_endpoints" "foobar" {
+  app_id     = heroku_app.one.uuid
+  depends_on = [heroku_ssl.one, heroku_domain.uncovered]
+}
+`, testAccCheckHerokuSSLConfig(appName, certFile, keyFile), hostname)
+}
@@ -49,12 +49,21 @@ func dataSourceHerokuTeamRead(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(team.ID)
 
-	var setErr error
-	setErr = d.Set("name", team.Name)
-	setErr = d.Set("default", team.Default)
-	setErr = d.Set("membership_limit", team.MembershipLimit)
-	setErr = d.Set("provisioned_licenses", team.ProvisionedLicenses)
-	setErr = d.Set("type", team.Type)
-
-	return setErr
+	if err := d.Set("name", team.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("default", team.Default); err != nil {
+		return setAttributeError("default", err)
+	}
+	if err := d.Set("membership_limit", team.MembershipLimit); err != nil {
+		return setAttributeError("membership_limit", err)
+	}
+	if err := d.Set("provisioned_licenses", team.ProvisionedLicenses); err != nil {
+		return setAttributeError("provisioned_licenses", err)
+	}
+	if err := d.Set("type", team.Type); err != nil {
+		return setAttributeError("type", err)
+	}
+
+	return nil
 }
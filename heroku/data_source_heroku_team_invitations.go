@@ -0,0 +1,86 @@
+package heroku
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuTeamInvitations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuTeamInvitationsRead,
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"invitations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"invited_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuTeamInvitationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Get("team").(string)
+
+	teamInvitations, err := client.TeamInvitationList(ctx, team, nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving invitations of team %s: %s", team, err)
+	}
+
+	invitations := make([]map[string]interface{}, 0, len(teamInvitations))
+	for _, i := range teamInvitations {
+		invitation := map[string]interface{}{
+			"id":         i.ID,
+			"email":      i.User.Email,
+			"invited_by": i.InvitedBy.Email,
+			"created_at": i.CreatedAt.Format(time.RFC3339),
+		}
+		if i.Role != nil {
+			invitation["role"] = *i.Role
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	d.SetId(team)
+	if err := d.Set("invitations", invitations); err != nil {
+		return diag.Errorf("Error setting invitations of team %s: %s", team, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuTeamInvitations_Basic(t *testing.T) {
+	team := testAccConfig.GetTeamOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuTeamInvitationsWithDataSource_Basic(team),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.heroku_team_invitations.foobar", "id", team),
+					resource.TestCheckResourceAttrSet("data.heroku_team_invitations.foobar", "invitations.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuTeamInvitationsWithDataSource_Basic(team string) string {
+	return fmt.Sprintf(`
+data "heroku_team_invitations" "foobar" {
+  team = "%s"
+}
+`, team)
+}
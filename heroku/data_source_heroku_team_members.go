@@ -123,9 +123,15 @@ func dataSourceHerokuTeamMembersRead(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	d.Set("team", teamName)
-	d.Set("roles", roles)
-	d.Set("members", members)
+	if err := d.Set("team", teamName); err != nil {
+		return setAttributeDiagnostics("team", err)
+	}
+	if err := d.Set("roles", roles); err != nil {
+		return setAttributeDiagnostics("roles", err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return setAttributeDiagnostics("members", err)
+	}
 
 	return diags
 }
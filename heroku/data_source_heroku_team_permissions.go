@@ -0,0 +1,71 @@
+package heroku
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHerokuTeamPermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuTeamPermissionsRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuTeamPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	teamPermissions, err := client.TeamAppPermissionList(ctx, nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving team app permissions: %s", err)
+	}
+
+	names := make([]string, 0, len(teamPermissions))
+	permissions := make([]map[string]interface{}, 0, len(teamPermissions))
+	for _, p := range teamPermissions {
+		names = append(names, p.Name)
+		permissions = append(permissions, map[string]interface{}{
+			"name":        p.Name,
+			"description": p.Description,
+		})
+	}
+
+	// The permissions are the same for every team.
+	d.SetId("team-permissions")
+	if err := d.Set("names", names); err != nil {
+		return setAttributeDiagnostics("names", err)
+	}
+	if err := d.Set("permissions", permissions); err != nil {
+		return diag.Errorf("Error setting team app permissions: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package heroku
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/heroku/terraform-provider-heroku/v4/helper/test"
+)
+
+func TestAccDatasourceHerokuTeamPermissions_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "heroku_team_permissions" "foobar" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					test.TestCheckTypeSetElemAttr("data.heroku_team_permissions.foobar", "names.*", "view"),
+					test.TestCheckTypeSetElemAttr("data.heroku_team_permissions.foobar", "names.*", "deploy"),
+					resource.TestCheckResourceAttrSet("data.heroku_team_permissions.foobar", "permissions.0.description"),
+				),
+			},
+		},
+	})
+}
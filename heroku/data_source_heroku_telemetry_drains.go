@@ -0,0 +1,148 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// telemetryDrain is a telemetry drain of a Fir app or space, which is not part of
+// heroku-go yet. The exporter headers are omitted, as they usually hold the
+// credentials of the collector.
+type telemetryDrain struct {
+	ID    string `json:"id"`
+	Owner struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"owner"`
+	Signals  []string `json:"signals"`
+	Exporter struct {
+		Type     string `json:"type"`
+		Endpoint string `json:"endpoint"`
+	} `json:"exporter"`
+}
+
+func dataSourceHerokuTelemetryDrains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHerokuTelemetryDrainsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"app_id", "space"},
+			},
+
+			"space": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"drains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"owner_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"owner_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"signals": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"exporter_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"exporter_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHerokuTelemetryDrainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	var owner, path string
+	if v, ok := d.GetOk("app_id"); ok {
+		owner = fmt.Sprintf("app %s", v.(string))
+		path = fmt.Sprintf("/apps/%s/telemetry-drains", v.(string))
+	} else {
+		owner = fmt.Sprintf("space %s", d.Get("space").(string))
+		path = fmt.Sprintf("/spaces/%s/telemetry-drains", d.Get("space").(string))
+	}
+
+	var ownerDrains []telemetryDrain
+	if err := client.Get(ctx, &ownerDrains, path, nil, &heroku.ListRange{Field: "id", Max: 1000}); err != nil {
+		// Only Fir generation apps have telemetry drains, which is the likely cause.
+		if v, ok := d.GetOk("app_id"); ok {
+			if app, appErr := retrieveAppWithGeneration(v.(string), client); appErr == nil && app.GenerationName() != "fir" {
+				return attributeDiagnostics(cty.GetAttrPath("app_id"), "Error retrieving telemetry drains of %s, "+
+					"which is a %s generation app: telemetry drains are only available to Fir generation apps", owner, app.GenerationName())
+			}
+		}
+		return diag.Errorf("Error retrieving telemetry drains of %s: %s", owner, err)
+	}
+
+	sort.Slice(ownerDrains, func(i, j int) bool {
+		return ownerDrains[i].Exporter.Endpoint < ownerDrains[j].Exporter.Endpoint
+	})
+
+	endpoints := make([]string, 0, len(ownerDrains))
+	drains := make([]map[string]interface{}, 0, len(ownerDrains))
+	for _, dr := range ownerDrains {
+		endpoints = append(endpoints, dr.Exporter.Endpoint)
+		drains = append(drains, map[string]interface{}{
+			"id":                dr.ID,
+			"owner_id":          dr.Owner.ID,
+			"owner_type":        dr.Owner.Type,
+			"signals":           dr.Signals,
+			"exporter_type":     dr.Exporter.Type,
+			"exporter_endpoint": dr.Exporter.Endpoint,
+		})
+	}
+
+	d.SetId(path)
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return setAttributeDiagnostics("endpoints", err)
+	}
+	if err := d.Set("drains", drains); err != nil {
+		return diag.Errorf("Error setting telemetry drains of %s: %s", owner, err)
+	}
+
+	return nil
+}
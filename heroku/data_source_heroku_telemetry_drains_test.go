@@ -0,0 +1,46 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceHerokuTelemetryDrains_Fir(t *testing.T) {
+	spaceName := fmt.Sprintf("tffirtest-%s", acctest.RandString(10))
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuTelemetryDrainsWithDataSource_Fir(spaceName, appName, org),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.heroku_telemetry_drains.app", "drains.#", "0"),
+					resource.TestCheckResourceAttr(
+						"data.heroku_telemetry_drains.space", "drains.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuTelemetryDrainsWithDataSource_Fir(spaceName, appName, org string) string {
+	return testAccCheckHerokuSpaceConfig_fir(spaceName, appName, org) + `
+
+data "heroku_telemetry_drains" "app" {
+  app_id = heroku_app.foobar.uuid
+}
+
+data "heroku_telemetry_drains" "space" {
+  space = heroku_space.foobar.id
+}
+`
+}
@@ -0,0 +1,107 @@
+package heroku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attributeError is an error about the attribute at path. Operations that return
+// an error instead of diagnostics return it so that their error still points at
+// the attribute, see errorDiagnostics.
+type attributeError struct {
+	path cty.Path
+	err  error
+}
+
+func (e *attributeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attributeError) Unwrap() error {
+	return e.err
+}
+
+// attributePathError returns err as an error about the attribute at path, such as
+// cty.GetAttrPath("config_vars").IndexString("KEY").
+func attributePathError(path cty.Path, err error) error {
+	return &attributeError{path: path, err: err}
+}
+
+// setAttributeError returns the error of failing to set the attribute key.
+func setAttributeError(key string, err error) error {
+	return attributePathError(cty.GetAttrPath(key), fmt.Errorf("Error setting %s: %s", key, err))
+}
+
+// errorDiagnostics returns the diagnostics of err, which point at the attribute of
+// the attributeError it wraps, if any.
+func errorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: attrErr.path,
+			},
+		}
+	}
+
+	return diag.FromErr(err)
+}
+
+// attributeDiagnostics returns an error diagnostic that points at the attribute at
+// path, so that Terraform shows the error next to the offending configuration.
+func attributeDiagnostics(path cty.Path, format string, a ...interface{}) diag.Diagnostics {
+	return errorDiagnostics(attributePathError(path, fmt.Errorf(format, a...)))
+}
+
+// setAttributeDiagnostics returns the diagnostics of failing to set the attribute key.
+func setAttributeDiagnostics(key string, err error) diag.Diagnostics {
+	return errorDiagnostics(setAttributeError(key, err))
+}
+
+// useContextFuncs replaces the operations of the provider's resources and data
+// sources that return an error with ones that return diagnostics, so that the
+// attributeErrors they return point at their attribute.
+func useContextFuncs(p *schema.Provider) {
+	for _, r := range p.ResourcesMap {
+		useResourceContextFuncs(r)
+	}
+	for _, r := range p.DataSourcesMap {
+		useResourceContextFuncs(r)
+	}
+}
+
+func useResourceContextFuncs(r *schema.Resource) {
+	if r.Create != nil {
+		r.CreateContext = contextFunc(r.Create)
+		r.Create = nil
+	}
+	if r.Read != nil {
+		r.ReadContext = contextFunc(r.Read)
+		r.Read = nil
+	}
+	if r.Update != nil {
+		r.UpdateContext = contextFunc(r.Update)
+		r.Update = nil
+	}
+	if r.Delete != nil {
+		r.DeleteContext = contextFunc(r.Delete)
+		r.Delete = nil
+	}
+}
+
+func contextFunc(f func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return errorDiagnostics(f(d, meta))
+	}
+}
@@ -0,0 +1,74 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestErrorDiagnostics(t *testing.T) {
+	if diags := errorDiagnostics(nil); diags != nil {
+		t.Errorf("Expected no diagnostics, got %#v", diags)
+	}
+
+	diags := errorDiagnostics(fmt.Errorf("Error creating app: boom"))
+	if len(diags) != 1 || diags[0].Summary != "Error creating app: boom" || diags[0].AttributePath != nil {
+		t.Errorf("Expected a diagnostic without attribute path, got %#v", diags)
+	}
+
+	path := cty.GetAttrPath("config_vars").IndexString("SECRET_KEY")
+	diags = errorDiagnostics(fmt.Errorf("Error updating app: %w", attributePathError(path, fmt.Errorf("boom"))))
+	if len(diags) != 1 || diags[0].Summary != "Error updating app: boom" || !diags[0].AttributePath.Equals(path) {
+		t.Errorf("Expected a diagnostic pointing at %#v, got %#v", path, diags)
+	}
+}
+
+func TestSetAttributeDiagnostics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{})
+
+	diags := setAttributeDiagnostics("name", d.Set("name", []string{"not", "a", "string"}))
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("name")) {
+		t.Errorf("Expected a diagnostic pointing at name, got %#v", diags)
+	}
+}
+
+func TestUseContextFuncs(t *testing.T) {
+	path := cty.GetAttrPath("plan")
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"heroku_test": {
+				Schema: map[string]*schema.Schema{
+					"plan": {Type: schema.TypeString, Optional: true, ForceNew: true},
+				},
+				Create: func(d *schema.ResourceData, meta interface{}) error {
+					return attributePathError(path, fmt.Errorf("invalid plan"))
+				},
+				Read: func(d *schema.ResourceData, meta interface{}) error {
+					return nil
+				},
+				Delete: func(d *schema.ResourceData, meta interface{}) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	useContextFuncs(p)
+	r := p.ResourcesMap["heroku_test"]
+	if r.Create != nil || r.Read != nil || r.Delete != nil {
+		t.Fatalf("Expected the operations returning an error to be replaced")
+	}
+	if err := p.InternalValidate(); err != nil {
+		t.Fatalf("Expected a valid provider: %s", err)
+	}
+
+	diags := r.CreateContext(context.Background(), r.TestResourceData(), nil)
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(path) {
+		t.Errorf("Expected a diagnostic pointing at plan, got %#v", diags)
+	}
+}
@@ -0,0 +1,112 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// healthCheckSchema is the optional health_check block of resources that release
+// code to an app. It only affects the wait after a new release, so changing it
+// does not require a new release.
+func healthCheckSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"path": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "/",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must be an absolute path, e.g. /health"),
+				},
+
+				"status": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      200,
+					ValidateFunc: validation.IntBetween(100, 599),
+				},
+
+				"timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      300,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// waitForHealthCheck polls the app's web URL at the configured health check path
+// until it responds with the expected status, or the health check times out. It is
+// a no-op when no health_check block is configured.
+func waitForHealthCheck(ctx context.Context, client *heroku.Service, appID string, v interface{}) error {
+	healthChecks := v.([]interface{})
+	if len(healthChecks) == 0 || healthChecks[0] == nil {
+		return nil
+	}
+	healthCheck := healthChecks[0].(map[string]interface{})
+	path := healthCheck["path"].(string)
+	status := healthCheck["status"].(int)
+	timeout := time.Duration(healthCheck["timeout"].(int)) * time.Second
+
+	app, err := client.AppInfo(ctx, appID)
+	if err != nil {
+		return fmt.Errorf("Error retrieving app %s for health check: %s", appID, err)
+	}
+	if app.WebURL == "" {
+		return fmt.Errorf("app %s has no web URL to health check", app.Name)
+	}
+
+	checkURL, err := url.Parse(strings.TrimSuffix(app.WebURL, "/") + path)
+	if err != nil {
+		return fmt.Errorf("Error parsing health check URL of app %s: %s", app.Name, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for app %s to respond with HTTP %d at %s", app.Name, status, checkURL)
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	lastResult := "no response"
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"unhealthy"},
+		Target:  []string{"healthy"},
+		Refresh: func() (interface{}, string, error) {
+			res, err := httpClient.Get(checkURL.String())
+			if err != nil {
+				lastResult = err.Error()
+				return lastResult, "unhealthy", nil
+			}
+			res.Body.Close()
+
+			lastResult = res.Status
+			if res.StatusCode != status {
+				return lastResult, "unhealthy", nil
+			}
+			return lastResult, "healthy", nil
+		},
+		Timeout:      timeout,
+		PollInterval: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("app %s did not respond with HTTP %d at %s within %s (last result: %s): %s",
+			app.Name, status, checkURL, timeout, lastResult, err)
+	}
+
+	log.Printf("[INFO] App %s is healthy", app.Name)
+
+	return nil
+}
@@ -2,10 +2,12 @@ package heroku
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
 	"github.com/heroku/terraform-provider-heroku/v4/version"
@@ -68,6 +70,23 @@ func doesHerokuAppExist(appName string, client *heroku.Service) (*heroku.App, er
 	return app, nil
 }
 
+// resourceGeneration holds the generation of an app or space, e.g. "cedar" or "fir",
+// which is not yet part of the heroku-go types.
+type resourceGeneration struct {
+	Generation *struct {
+		Name string `json:"name"`
+	} `json:"generation"`
+}
+
+// GenerationName returns the name of the generation, defaulting to "cedar" for
+// API responses that predate generations.
+func (g resourceGeneration) GenerationName() string {
+	if g.Generation == nil || g.Generation.Name == "" {
+		return "cedar"
+	}
+	return g.Generation.Name
+}
+
 func buildCompositeID(a, b string) string {
 	return fmt.Sprintf("%s:%s", a, b)
 }
@@ -95,3 +114,49 @@ func SliceContainsString(s []string, e string) bool {
 	}
 	return false
 }
+
+// stateWaitError describes a failed wait for an asynchronous operation. Timeout
+// errors already include the last observed state, so they are extended with the
+// timeouts block argument that controls how long to wait.
+func stateWaitError(what, timeoutKey string, err error) error {
+	if _, ok := err.(*resource.TimeoutError); ok {
+		return fmt.Errorf("Error waiting for %s: %s; the wait can be extended with timeouts.%s", what, err, timeoutKey)
+	}
+	return fmt.Errorf("Error waiting for %s: %s", what, err)
+}
+
+// isNotFoundError reports whether err is a not_found error of the Heroku API. It
+// unwraps the heroku.Error from a *url.Error, as returned by the API client, or
+// from any other error wrapping it, and is false for errors without one, such as
+// timeouts.
+func isNotFoundError(err error) bool {
+	var herr heroku.Error
+	if errors.As(err, &herr) {
+		return herr.ID == "not_found"
+	}
+	var herrPtr *heroku.Error
+	if errors.As(err, &herrPtr) && herrPtr != nil {
+		return herrPtr.ID == "not_found"
+	}
+	return false
+}
+
+// removeNotFound removes the resource from state when err is a not_found error,
+// so that a resource deleted outside of Terraform is planned to be created again,
+// and reports whether it did.
+func removeNotFound(d *schema.ResourceData, what string, err error) bool {
+	if !isNotFoundError(err) {
+		return false
+	}
+	log.Printf("[WARN] %s %s not found, removing from state", what, d.Id())
+	d.SetId("")
+	return true
+}
+
+// taintedCreateError explains that a resource which was created but did not
+// become ready is kept in state as tainted, rather than being orphaned while it
+// is still billed.
+func taintedCreateError(what string, err error) error {
+	return fmt.Errorf("%s\n\n%s was created but did not become ready. It is kept in the Terraform state as tainted, "+
+		"so it is destroyed and created again on the next apply. To keep it instead, run `terraform untaint` once it is ready.", err, what)
+}
@@ -0,0 +1,152 @@
+package heroku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func TestStateWaitError(t *testing.T) {
+	err := stateWaitError("build (1234) to complete", schema.TimeoutCreate, &resource.TimeoutError{
+		LastState:     "pending",
+		Timeout:       time.Minute,
+		ExpectedState: []string{"succeeded"},
+	})
+	for _, want := range []string{"Error waiting for build (1234) to complete", "last state: 'pending'", "timeouts.create"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q to contain %q", err, want)
+		}
+	}
+
+	err = stateWaitError("build (1234) to complete", schema.TimeoutCreate, errors.New("build failed"))
+	if expected := "Error waiting for build (1234) to complete: build failed"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err)
+	}
+}
+
+func TestAddonCreateTimeout_ProviderDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceHerokuAddon().Schema, nil)
+	config := &Config{AddonCreateTimeout: 45}
+
+	if timeout := addonCreateTimeout(d, config); timeout != 45*time.Minute {
+		t.Errorf("Expected the provider's addon_create_timeout of 45m, got %s", timeout)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := heroku.Error{ID: "not_found", StatusCode: 404}
+	forbidden := heroku.Error{ID: "forbidden", StatusCode: 403}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"url error", &url.Error{Op: "Get", URL: "https://api.heroku.com/apps/foo", Err: notFound}, true},
+		{"heroku error", notFound, true},
+		{"heroku error pointer", &notFound, true},
+		{"wrapped error", fmt.Errorf("Error retrieving app: %w", &url.Error{Op: "Get", Err: notFound}), true},
+		{"other heroku error", &url.Error{Op: "Get", Err: forbidden}, false},
+		{"url error without heroku error", &url.Error{Op: "Get", Err: errors.New("i/o timeout")}, false},
+		{"timeout", &resource.TimeoutError{LastState: "pending"}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := isNotFoundError(tt.err); actual != tt.expected {
+				t.Errorf("Expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRemoveNotFound(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceHerokuDrain().Schema, nil)
+	d.SetId("1234")
+
+	if removeNotFound(d, "Drain", errors.New("i/o timeout")) {
+		t.Fatalf("Expected a timeout not to remove the drain from state")
+	}
+	if d.Id() != "1234" {
+		t.Fatalf("Expected the drain to stay in state, got ID %q", d.Id())
+	}
+
+	if !removeNotFound(d, "Drain", &url.Error{Op: "Get", Err: heroku.Error{ID: "not_found"}}) {
+		t.Fatalf("Expected a not_found error to remove the drain from state")
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected the drain to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestTaintedCreateError(t *testing.T) {
+	err := taintedCreateError("Addon brave-1234", errors.New("Error waiting for Addon (1234) to be provisioned: timeout"))
+	for _, want := range []string{"Error waiting for Addon (1234) to be provisioned: timeout", "Addon brave-1234 was created", "tainted", "terraform untaint"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q to contain %q", err, want)
+		}
+	}
+}
+
+func TestResourceReadNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"id": "not_found", "message": "Couldn't find that."}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PostgresURL = srv.URL
+	config.RedisURL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		resource   *schema.Resource
+		id         string
+		attributes map[string]string
+	}{
+		{"account feature", resourceHerokuAccountFeature(), "some-feature", map[string]string{"name": "some-feature"}},
+		{"app config association", resourceHerokuAppConfigAssociation(), "some-id", map[string]string{"app_id": "some-app"}},
+		{"domains", resourceHerokuDomains(), "some-app", map[string]string{"app": "some-app"}},
+		{"enterprise account member", resourceHerokuEnterpriseAccountMember(), "some-account:someone@example.com", nil},
+		{"identity provider", resourceHerokuIdentityProvider(), "some-team:some-id", nil},
+		{"pipeline config var", resourceHerokuPipelineConfigVar(), "some-pipeline:staging", nil},
+		{"postgres maintenance window", resourceHerokuPostgresMaintenanceWindow(), "some-addon", nil},
+		{"redis maintenance window", resourceHerokuRedisMaintenanceWindow(), "some-addon", nil},
+		{"review app config", resourceHerokuReviewAppConfig(), "some-pipeline", nil},
+		{"space app access", resourceHerokuSpaceAppAccess(), "some-user", map[string]string{"space": "some-space", "email": "someone@example.com"}},
+		{"space inbound ruleset", resourceHerokuSpaceInboundRuleset(), "some-ruleset", map[string]string{"space": "some-space"}},
+		{"space outbound ruleset", resourceHerokuSpaceOutboundRuleset(), "some-ruleset", map[string]string{"space": "some-space"}},
+		{"team addon allowlist", resourceHerokuTeamAddonAllowlist(), "some-team", nil},
+		{"team collaborator", resourceHerokuTeamCollaborator(), "someone@example.com", map[string]string{"app": "some-app"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: tt.id, Attributes: tt.attributes}
+			newState, diags := tt.resource.RefreshWithoutUpgrade(context.Background(), state, config)
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+			if newState != nil && newState.ID != "" {
+				t.Fatalf("Expected the resource to be removed from state, got ID %q", newState.ID)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func TestAccHerokuAddon_importBasic(t *testing.T) {
 				ResourceName:            "heroku_addon.foobar",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"config_vars", "config"},
+				ImportStateVerifyIgnore: []string{"config_vars", "config", "final_backup", "deprovision_on_failure"},
 			},
 			{
 				Config:             testAccCheckHerokuAddonConfig_basic(appName),
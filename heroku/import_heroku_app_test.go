@@ -26,7 +26,8 @@ func TestAccHerokuApp_importBasic(t *testing.T) {
 				ImportStateVerify: true,
 
 				// Due to the nature of these two attributes, it will not be possible to import them as part of the resource import.
-				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars"},
+				// config_vars_mode only configures the resource, so it is not read from the app either.
+				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars", "config_vars_mode"},
 			},
 		},
 	})
@@ -52,7 +53,8 @@ func TestAccHerokuApp_importOrganization(t *testing.T) {
 				ImportStateVerify: true,
 
 				// Due to the nature of these two attributes, it will not be possible to import them as part of the resource import.
-				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars"},
+				// config_vars_mode only configures the resource, so it is not read from the app either.
+				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars", "config_vars_mode"},
 			},
 		},
 	})
@@ -77,7 +79,8 @@ func TestAccHerokuApp_importBuildpacks(t *testing.T) {
 				ImportStateVerify: true,
 
 				// Due to the nature of these two attributes, it will not be possible to import them as part of the resource import.
-				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars"},
+				// config_vars_mode only configures the resource, so it is not read from the app either.
+				ImportStateVerifyIgnore: []string{"config_vars", "sensitive_config_vars", "config_vars_mode"},
 			},
 		},
 	})
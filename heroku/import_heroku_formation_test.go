@@ -24,10 +24,11 @@ func TestAccHerokuFormation_importBasic(t *testing.T) {
 				Config: testAccCheckHerokuFormationConfig_WithOrg(org, appName, slugID, "standard-2x", 2),
 			},
 			{
-				ResourceName:      "heroku_formation.foobar-web",
-				ImportStateId:     buildCompositeID(appName, formationType),
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "heroku_formation.foobar-web",
+				ImportStateId:           buildCompositeID(appName, formationType),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_dynos_up"},
 			},
 		},
 	})
@@ -0,0 +1,80 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// postgresTransfer is a transfer of the Postgres API, such as a backup capture.
+type postgresTransfer struct {
+	UUID       string     `json:"uuid"`
+	Num        int        `json:"num"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Succeeded  bool       `json:"succeeded"`
+	Canceled   bool       `json:"canceled"`
+}
+
+// postgresTransferURL is an expiring download URL of a backup.
+type postgresTransferURL struct {
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// capturePostgresBackup captures a backup of a database and waits for it to
+// succeed, like `heroku pg:backups:capture`. It returns the number of the backup
+// and its expiring download URL. Backups belong to the app, so they remain after
+// the database is destroyed.
+func capturePostgresBackup(ctx context.Context, client *heroku.Service, app, addonID string, timeout time.Duration) (int, *postgresTransferURL, error) {
+	var transfer postgresTransfer
+	if err := client.Post(ctx, &transfer, fmt.Sprintf("%s/backups", postgresDatabasePath(addonID)), nil); err != nil {
+		return 0, nil, fmt.Errorf("Error capturing backup of Postgres database %s: %s", addonID, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for backup b%03d of Postgres database %s to succeed", transfer.Num, addonID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"running"},
+		Target:     []string{"succeeded"},
+		Refresh:    PostgresTransferStateRefreshFunc(client, app, transfer.Num),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return transfer.Num, nil, fmt.Errorf("Error waiting for backup b%03d of Postgres database %s: %s", transfer.Num, addonID, err)
+	}
+
+	var downloadURL postgresTransferURL
+	path := fmt.Sprintf("/client/v11/apps/%s/transfers/%d/actions/public-url", app, transfer.Num)
+	if err := client.Post(ctx, &downloadURL, path, nil); err != nil {
+		return transfer.Num, nil, fmt.Errorf("Error retrieving download URL of backup b%03d: %s", transfer.Num, err)
+	}
+
+	return transfer.Num, &downloadURL, nil
+}
+
+// PostgresTransferStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a Postgres transfer, such as a backup capture.
+func PostgresTransferStateRefreshFunc(client *heroku.Service, app string, num int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var transfer postgresTransfer
+		if err := client.Get(context.TODO(), &transfer, fmt.Sprintf("/client/v11/apps/%s/transfers/%d", app, num), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get transfer %d of app %s: %s", num, app, err)
+			return nil, "", err
+		}
+
+		if transfer.FinishedAt == nil {
+			return transfer, "running", nil
+		}
+
+		if !transfer.Succeeded || transfer.Canceled {
+			return nil, "", fmt.Errorf("backup b%03d of app %s failed", num, app)
+		}
+
+		return transfer, "succeeded", nil
+	}
+}
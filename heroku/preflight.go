@@ -0,0 +1,265 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// oauthScopeHeader is the response header listing the OAuth scopes of the token
+// a request was authenticated with.
+const oauthScopeHeader = "Oauth-Scope"
+
+// oauthImpliedScopes are the scopes granted by each broader scope, as described
+// in https://devcenter.heroku.com/articles/oauth#scopes.
+var oauthImpliedScopes = map[string][]string{
+	"global":          {"identity", "read", "write", "read-protected", "write-protected"},
+	"write-protected": {"read", "write", "read-protected"},
+	"write":           {"read"},
+	"read-protected":  {"read"},
+}
+
+// writeProtectedResources are the resources that manage config vars or
+// credentials, which require the write-protected scope.
+var writeProtectedResources = []string{
+	"heroku_app",
+	"heroku_app_config_association",
+	"heroku_pipeline_config_var",
+	"heroku_postgres_credential",
+	"heroku_redis_credential",
+	"heroku_review_app_config",
+}
+
+// oauthScopes holds the OAuth scopes of the provider's credentials, once an API
+// response lists them. Configs copied for API usage tracking share it.
+type oauthScopes struct {
+	mu     sync.Mutex
+	once   sync.Once
+	scopes []string
+}
+
+func (s *oauthScopes) set(scopes []string) {
+	if s == nil || len(scopes) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scopes = scopes
+}
+
+func (s *oauthScopes) get() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.scopes
+}
+
+// oauthScopeRecorder keeps the OAuth scopes listed by the responses of the API.
+type oauthScopeRecorder struct {
+	scopes    *oauthScopes
+	transport http.RoundTripper
+}
+
+func (t *oauthScopeRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+
+	if res != nil {
+		t.scopes.set(parseOAuthScopes(res.Header.Get(oauthScopeHeader)))
+	}
+
+	return res, err
+}
+
+// oauthScopes returns the scopes of the provider's credentials, reading the account
+// for them when no API response has listed them yet. Unknown scopes are nil.
+func (c *Config) oauthScopes(ctx context.Context) []string {
+	if c.tokenScopes == nil {
+		return nil
+	}
+	if scopes := c.tokenScopes.get(); scopes != nil {
+		return scopes
+	}
+
+	c.tokenScopes.once.Do(func() {
+		res, err := c.requestAccount(ctx)
+		if err != nil {
+			log.Printf("[WARN] Unable to read the scopes of the Heroku credentials: %s", err)
+			return
+		}
+		res.Body.Close()
+		c.tokenScopes.set(parseOAuthScopes(res.Header.Get(oauthScopeHeader)))
+	})
+
+	return c.tokenScopes.get()
+}
+
+// checkOAuthScopes wraps the CustomizeDiff of the provider's resources to fail the
+// plan of changes that the credentials lack the scopes for, instead of their apply.
+func checkOAuthScopes(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		r.CustomizeDiff = customizeDiffOAuthScopes(name, r.CustomizeDiff)
+	}
+}
+
+func customizeDiffOAuthScopes(name string, f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+		if f != nil {
+			if err := f(ctx, diff, v); err != nil {
+				return err
+			}
+		}
+
+		config, ok := v.(*Config)
+		if !ok || (diff.Id() != "" && len(diff.GetChangedKeysPrefix("")) == 0) {
+			return nil
+		}
+
+		scopes := config.oauthScopes(ctx)
+		if len(scopes) == 0 {
+			return nil
+		}
+
+		for _, scope := range oauthRequiredScopes(name, diff, config) {
+			if !oauthScopeGranted(scopes, scope) {
+				return fmt.Errorf("the Heroku credentials lack the %s scope required to change %s, they have the scopes %s",
+					scope, name, strings.Join(scopes, ", "))
+			}
+		}
+
+		return nil
+	}
+}
+
+// oauthRequiredScopes returns the scopes required to apply the planned changes of
+// a resource.
+func oauthRequiredScopes(name string, diff *schema.ResourceDiff, config *Config) []string {
+	scopes := []string{"write"}
+
+	switch name {
+	case "heroku_app":
+		// Only the config vars of apps are protected.
+		if diff.HasChange("config_vars") || diff.HasChange("sensitive_config_vars") {
+			scopes = append(scopes, "write-protected")
+		}
+	case "heroku_account_feature":
+		scopes = append(scopes, "identity")
+	case "heroku_pipeline":
+		// Pipelines without an owner are owned by the account.
+		if _, ok := diff.GetOk("owner"); !ok && diff.Id() == "" && config.DefaultTeam == "" {
+			scopes = append(scopes, "identity")
+		}
+	default:
+		for _, r := range writeProtectedResources {
+			if r == name {
+				scopes = append(scopes, "write-protected")
+			}
+		}
+	}
+
+	return scopes
+}
+
+// oauthScopeGranted returns whether the scope is one of the granted scopes, or is
+// implied by one of them.
+func oauthScopeGranted(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+		for _, implied := range oauthImpliedScopes[g] {
+			if implied == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseOAuthScopes parses the comma separated scopes of the Oauth-Scope header.
+func parseOAuthScopes(header string) []string {
+	var scopes []string
+	for _, s := range strings.Split(header, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// preflight checks the provider's credentials when it is configured, like
+// `heroku auth:whoami`, so that expired credentials or missing scopes fail the
+// plan right away instead of the apply of some resource.
+func (c *Config) preflight(ctx context.Context) error {
+	res, err := c.requestAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("Preflight error: cannot verify the credentials with the Heroku API: %s", err)
+	}
+	defer res.Body.Close()
+
+	scopes := parseOAuthScopes(res.Header.Get(oauthScopeHeader))
+	c.tokenScopes.set(scopes)
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var account struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&account); err != nil {
+			return fmt.Errorf("Preflight error: decoding the account: %s", err)
+		}
+		log.Printf("[INFO] Preflight: authenticated as %s with scopes %v", account.Email, scopes)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("Preflight error: the Heroku API rejected the provider's credentials, they are invalid or expired. " +
+			"Run `heroku login` or `heroku authorizations:create` for new credentials")
+	case http.StatusForbidden:
+		// Credentials without the identity scope cannot read the account.
+		log.Printf("[INFO] Preflight: authenticated with scopes %v", scopes)
+	default:
+		return fmt.Errorf("Preflight error: unexpected response of the Heroku API: %s", res.Status)
+	}
+
+	// Without a scope header, the scopes of the credentials are unknown.
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, scope := range c.PreflightRequiredScopes {
+		if !oauthScopeGranted(scopes, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("Preflight error: the credentials lack the required scopes %s, they have the scopes %s",
+			strings.Join(missing, ", "), strings.Join(scopes, ", "))
+	}
+
+	return nil
+}
+
+// requestAccount requests the account of the provider's credentials. The request
+// is not sent with heroku.Transport, which drops the headers of error responses,
+// as the scopes are needed even when the account cannot be read.
+func (c *Config) requestAccount(ctx context.Context) (*http.Response, error) {
+	req, err := c.Api.NewRequest(ctx, "GET", "/account", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.heroku+json; version=3")
+	req.Header.Set("User-Agent", c.userAgent())
+
+	client := &http.Client{Transport: &tokenTransport{source: c.tokenSource, transport: c.transport}}
+	return client.Do(req)
+}
@@ -0,0 +1,218 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestOAuthScopeGranted(t *testing.T) {
+	cases := []struct {
+		granted  []string
+		scope    string
+		expected bool
+	}{
+		{[]string{"global"}, "write-protected", true},
+		{[]string{"global"}, "identity", true},
+		{[]string{"write-protected"}, "read", true},
+		{[]string{"write-protected"}, "identity", false},
+		{[]string{"write"}, "read", true},
+		{[]string{"write"}, "write-protected", false},
+		{[]string{"read"}, "write", false},
+		{[]string{"identity", "read"}, "identity", true},
+		{nil, "read", false},
+	}
+
+	for _, tc := range cases {
+		if actual := oauthScopeGranted(tc.granted, tc.scope); actual != tc.expected {
+			t.Errorf("expected %t for %s granted by %v, got %t", tc.expected, tc.scope, tc.granted, actual)
+		}
+	}
+}
+
+func TestParseOAuthScopes(t *testing.T) {
+	if actual := parseOAuthScopes("write, identity"); !reflect.DeepEqual(actual, []string{"identity", "write"}) {
+		t.Errorf("got %v, want [identity write]", actual)
+	}
+	if actual := parseOAuthScopes(""); actual != nil {
+		t.Errorf("got %v, want no scopes", actual)
+	}
+}
+
+func TestConfigPreflight(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		scopes   string
+		required []string
+		err      string
+	}{
+		{name: "valid", status: http.StatusOK, scopes: "global", required: []string{"write-protected"}},
+		{name: "unknown scopes", status: http.StatusOK, required: []string{"write"}},
+		{name: "expired", status: http.StatusUnauthorized, err: "invalid or expired"},
+		{name: "without identity", status: http.StatusForbidden, scopes: "read", required: []string{"read"}},
+		{name: "missing scope", status: http.StatusOK, scopes: "read, identity", required: []string{"write", "identity"}, err: "lack the required scopes write"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/account" {
+					t.Errorf("got request of %s, want /account", r.URL.Path)
+				}
+				if tc.scopes != "" {
+					w.Header().Set(oauthScopeHeader, tc.scopes)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"email":"ops@example.com"}`))
+			}))
+			defer srv.Close()
+
+			config := NewConfig()
+			config.URL = srv.URL
+			config.APIKey = "key"
+			config.PreflightRequiredScopes = tc.required
+			if err := config.initializeAPI(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := config.preflight(context.Background())
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got error %v, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestProviderConfigurePreflightBlock(t *testing.T) {
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"preflight": []interface{}{
+			map[string]interface{}{
+				"required_scopes": []interface{}{"write"},
+			},
+		},
+	})
+
+	config := NewConfig()
+	if err := config.applySchema(d); err != nil {
+		t.Fatal(err)
+	}
+	if !config.Preflight || !reflect.DeepEqual(config.PreflightRequiredScopes, []string{"write"}) {
+		t.Fatalf("got preflight %t with scopes %v, want preflight with scopes [write]", config.Preflight, config.PreflightRequiredScopes)
+	}
+}
+
+func TestCustomizeDiffOAuthScopes(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource string
+		scopes   string
+		state    map[string]string
+		config   map[string]interface{}
+		err      string
+	}{
+		{name: "create", resource: "heroku_domain", scopes: "write", config: map[string]interface{}{"name": "foo"}},
+		{name: "create without write", resource: "heroku_domain", scopes: "identity, read", config: map[string]interface{}{"name": "foo"}, err: "lack the write scope required to change heroku_domain"},
+		{name: "unchanged without write", resource: "heroku_domain", scopes: "read", state: map[string]string{"name": "foo"}, config: map[string]interface{}{"name": "foo"}},
+		{name: "update without write", resource: "heroku_domain", scopes: "read", state: map[string]string{"name": "foo"}, config: map[string]interface{}{"name": "bar"}, err: "lack the write scope"},
+		{name: "unknown scopes", resource: "heroku_domain", config: map[string]interface{}{"name": "foo"}},
+		{name: "app without config vars", resource: "heroku_app", scopes: "write", config: map[string]interface{}{"name": "foo"}},
+		{name: "app config vars", resource: "heroku_app", scopes: "write", state: map[string]string{"name": "foo"}, config: map[string]interface{}{"name": "foo", "config_vars": map[string]interface{}{"FOO": "bar"}}, err: "lack the write-protected scope required to change heroku_app"},
+		{name: "app sensitive config vars", resource: "heroku_app", scopes: "global", config: map[string]interface{}{"name": "foo", "sensitive_config_vars": map[string]interface{}{"FOO": "bar"}}},
+		{name: "credential", resource: "heroku_redis_credential", scopes: "write", config: map[string]interface{}{"name": "foo"}, err: "lack the write-protected scope"},
+		{name: "account feature", resource: "heroku_account_feature", scopes: "write-protected", config: map[string]interface{}{"name": "foo"}, err: "lack the identity scope"},
+		{name: "pipeline without owner", resource: "heroku_pipeline", scopes: "write", config: map[string]interface{}{"name": "foo"}, err: "lack the identity scope"},
+		{name: "pipeline with owner", resource: "heroku_pipeline", scopes: "write", config: map[string]interface{}{"name": "foo", "owner": "team"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/account" {
+					t.Errorf("got request of %s, want /account", r.URL.Path)
+					http.Error(w, "unexpected request", http.StatusNotFound)
+					return
+				}
+				requests++
+				if tc.scopes != "" {
+					w.Header().Set(oauthScopeHeader, tc.scopes)
+				}
+				w.Write([]byte(`{"email":"ops@example.com"}`))
+			}))
+			defer srv.Close()
+
+			config := NewConfig()
+			config.URL = srv.URL
+			config.APIKey = "key"
+			if err := config.initializeAPI(); err != nil {
+				t.Fatal(err)
+			}
+
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name":                  {Type: schema.TypeString, Optional: true},
+					"owner":                 {Type: schema.TypeString, Optional: true},
+					"config_vars":           {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					"sensitive_config_vars": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				},
+				CustomizeDiff: customizeDiffOAuthScopes(tc.resource, nil),
+			}
+
+			var state *terraform.InstanceState
+			if tc.state != nil {
+				state = &terraform.InstanceState{ID: "id", Attributes: tc.state}
+			}
+
+			// A second plan reuses the scopes of the first.
+			for i := 0; i < 2; i++ {
+				_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), config)
+				if tc.err == "" {
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+				} else if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got error %v, want it to contain %q", err, tc.err)
+				}
+			}
+			if requests > 1 {
+				t.Errorf("got %d account requests, want the scopes read once", requests)
+			}
+		})
+	}
+}
+
+func TestOAuthScopeRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(oauthScopeHeader, "read, identity")
+		w.Write([]byte(`{"id":"01234567-89ab-cdef-0123-456789abcdef","name":"foo"}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.APIKey = "key"
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := config.Api.AppInfo(context.Background(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if actual := config.tokenScopes.get(); !reflect.DeepEqual(actual, []string{"identity", "read"}) {
+		t.Errorf("got scopes %v, want [identity read]", actual)
+	}
+}
@@ -1,6 +1,7 @@
 package heroku
 
 import (
+	"context"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,7 +11,7 @@ import (
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"email": {
 				Type:        schema.TypeString,
@@ -36,6 +37,88 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("HEROKU_API_URL", heroku.DefaultURL),
 			},
 
+			"oauth_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_OAUTH_CLIENT_ID", nil),
+			},
+
+			"oauth_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_OAUTH_CLIENT_SECRET", nil),
+			},
+
+			"oauth_refresh_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_OAUTH_REFRESH_TOKEN", nil),
+			},
+
+			"oauth_token_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_OAUTH_TOKEN_URL", DefaultOAuthTokenURL),
+			},
+
+			"default_team": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_DEFAULT_TEAM", nil),
+			},
+
+			"user_agent_suffix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("HEROKU_USER_AGENT_SUFFIX", nil),
+				ValidateFunc: validation.StringDoesNotContainAny("\r\n"),
+			},
+
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+
+			"https_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+
+			"no_proxy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"api_usage_summary": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HEROKU_API_USAGE_SUMMARY", nil),
+			},
+
+			"preflight": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"required_scopes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"global", "identity", "read", "write", "read-protected", "write-protected",
+								}, false),
+							},
+						},
+					},
+				},
+			},
+
 			"customizations": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -51,6 +134,26 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"features": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prevent_app_destroy": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  DefaultPreventAppDestroy,
+						},
+						"skip_final_addon_snapshot": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  DefaultSkipFinalAddonSnapshot,
+						},
+					},
+				},
+			},
+
 			"delays": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -75,6 +178,24 @@ func Provider() *schema.Provider {
 							Default:      DefaultPostDomainCreateDelay,
 							ValidateFunc: validation.IntAtLeast(0),
 						},
+						"post_app_update_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      DefaultPostAppUpdateDelay,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"post_addon_create_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      DefaultPostAddonCreateDelay,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"post_pipeline_coupling_create_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      DefaultPostPipelineCouplingCreateDelay,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
 					},
 				},
 			},
@@ -99,46 +220,100 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"heroku_account_feature":                   resourceHerokuAccountFeature(),
 			"heroku_addon":                             resourceHerokuAddon(),
+			"heroku_addon_action":                      resourceHerokuAddonAction(),
 			"heroku_addon_attachment":                  resourceHerokuAddonAttachment(),
 			"heroku_app":                               resourceHerokuApp(),
+			"heroku_app_alert":                         resourceHerokuAppAlert(),
 			"heroku_app_config_association":            resourceHerokuAppConfigAssociation(),
 			"heroku_app_feature":                       resourceHerokuAppFeature(),
+			"heroku_app_formation":                     resourceHerokuAppFormation(),
+			"heroku_app_lock":                          resourceHerokuAppLock(),
 			"heroku_app_release":                       resourceHerokuAppRelease(),
+			"heroku_app_setup":                         resourceHerokuAppSetup(),
+			"heroku_app_transfer_accepter":             resourceHerokuAppTransferAccepter(),
 			"heroku_app_webhook":                       resourceHerokuAppWebhook(),
 			"heroku_build":                             resourceHerokuBuild(),
 			"heroku_cert":                              resourceHerokuCert(),
 			"heroku_collaborator":                      resourceHerokuCollaborator(),
 			"heroku_config":                            resourceHerokuConfig(),
+			"heroku_container_release":                 resourceHerokuContainerRelease(),
 			"heroku_domain":                            resourceHerokuDomain(),
+			"heroku_domains":                           resourceHerokuDomains(),
 			"heroku_drain":                             resourceHerokuDrain(),
+			"heroku_enterprise_account_member":         resourceHerokuEnterpriseAccountMember(),
 			"heroku_formation":                         resourceHerokuFormation(),
+			"heroku_identity_provider":                 resourceHerokuIdentityProvider(),
+			"heroku_kafka_connector":                   resourceHerokuKafkaConnector(),
 			"heroku_pipeline":                          resourceHerokuPipeline(),
 			"heroku_pipeline_config_var":               resourceHerokuPipelineConfigVar(),
 			"heroku_pipeline_coupling":                 resourceHerokuPipelineCoupling(),
+			"heroku_pipeline_deploy":                   resourceHerokuPipelineDeploy(),
+			"heroku_pipeline_promotion":                resourceHerokuPipelinePromotion(),
+			"heroku_postgres_credential":               resourceHerokuPostgresCredential(),
+			"heroku_postgres_maintenance_window":       resourceHerokuPostgresMaintenanceWindow(),
+			"heroku_postgres_upgrade":                  resourceHerokuPostgresUpgrade(),
+			"heroku_redis_credential":                  resourceHerokuRedisCredential(),
+			"heroku_redis_maintenance_window":          resourceHerokuRedisMaintenanceWindow(),
+			"heroku_review_app":                        resourceHerokuReviewApp(),
 			"heroku_review_app_config":                 resourceHerokuReviewAppConfig(),
+			"heroku_run":                               resourceHerokuRun(),
 			"heroku_slug":                              resourceHerokuSlug(),
+			"heroku_source":                            resourceHerokuSource(),
 			"heroku_space":                             resourceHerokuSpace(),
 			"heroku_space_inbound_ruleset":             resourceHerokuSpaceInboundRuleset(),
 			"heroku_space_app_access":                  resourceHerokuSpaceAppAccess(),
+			"heroku_space_outbound_ruleset":            resourceHerokuSpaceOutboundRuleset(),
 			"heroku_space_peering_connection_accepter": resourceHerokuSpacePeeringConnectionAccepter(),
 			"heroku_space_vpn_connection":              resourceHerokuSpaceVPNConnection(),
 			"heroku_ssl":                               resourceHerokuSSL(),
+			"heroku_team_addon_allowlist":              resourceHerokuTeamAddonAllowlist(),
 			"heroku_team_collaborator":                 resourceHerokuTeamCollaborator(),
 			"heroku_team_member":                       resourceHerokuTeamMember(),
+			"heroku_test_run":                          resourceHerokuTestRun(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"heroku_addon":              dataSourceHerokuAddon(),
-			"heroku_app":                dataSourceHerokuApp(),
-			"heroku_pipeline":           dataSourceHerokuPipeline(),
-			"heroku_space":              dataSourceHerokuSpace(),
-			"heroku_space_peering_info": dataSourceHerokuSpacePeeringInfo(),
-			"heroku_team":               dataSourceHerokuTeam(),
-			"heroku_team_members":       dataSourceHerokuTeamMembers(),
+			"heroku_account":                   dataSourceHerokuAccount(),
+			"heroku_addon":                     dataSourceHerokuAddon(),
+			"heroku_addon_attachments":         dataSourceHerokuAddonAttachments(),
+			"heroku_app":                       dataSourceHerokuApp(),
+			"heroku_app_webhook_deliveries":    dataSourceHerokuAppWebhookDeliveries(),
+			"heroku_app_webhooks":              dataSourceHerokuAppWebhooks(),
+			"heroku_audit_trail_events":        dataSourceHerokuAuditTrailEvents(),
+			"heroku_build":                     dataSourceHerokuBuild(),
+			"heroku_domain":                    dataSourceHerokuDomain(),
+			"heroku_drain":                     dataSourceHerokuDrain(),
+			"heroku_dyno_metrics":              dataSourceHerokuDynoMetrics(),
+			"heroku_enterprise_account":        dataSourceHerokuEnterpriseAccount(),
+			"heroku_enterprise_teams":          dataSourceHerokuEnterpriseTeams(),
+			"heroku_enterprise_usage":          dataSourceHerokuEnterpriseUsage(),
+			"heroku_pipeline":                  dataSourceHerokuPipeline(),
+			"heroku_postgres_followers":        dataSourceHerokuPostgresFollowers(),
+			"heroku_rate_limit":                dataSourceHerokuRateLimit(),
+			"heroku_slug":                      dataSourceHerokuSlug(),
+			"heroku_ssl_certificates":          dataSourceHerokuSSLCertificates(),
+			"heroku_ssl_endpoints":             dataSourceHerokuSSLEndpoints(),
+			"heroku_space":                     dataSourceHerokuSpace(),
+			"heroku_space_apps":                dataSourceHerokuSpaceApps(),
+			"heroku_space_peering_connections": dataSourceHerokuSpacePeeringConnections(),
+			"heroku_space_peering_info":        dataSourceHerokuSpacePeeringInfo(),
+			"heroku_space_vpn_connection":      dataSourceHerokuSpaceVPNConnection(),
+			"heroku_spaces":                    dataSourceHerokuSpaces(),
+			"heroku_team":                      dataSourceHerokuTeam(),
+			"heroku_team_invitations":          dataSourceHerokuTeamInvitations(),
+			"heroku_team_members":              dataSourceHerokuTeamMembers(),
+			"heroku_team_permissions":          dataSourceHerokuTeamPermissions(),
+			"heroku_telemetry_drains":          dataSourceHerokuTelemetryDrains(),
 		},
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	checkOAuthScopes(p)
+	trackAPIUsage(p)
+	useContextFuncs(p)
+
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -166,6 +341,13 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
+	// The scopes of OAuth credentials are always checked, as they are often limited.
+	if config.Preflight || config.OAuthClientID != "" {
+		if err := config.preflight(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
 	log.Printf("[DEBUG] Heroku provider initialized: %s\n", config)
 
 	return config, nil
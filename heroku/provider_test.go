@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -92,6 +93,70 @@ func TestProviderConfigureUsesHeadersForClient(t *testing.T) {
 	}
 }
 
+func TestProviderConfigureUsesUserAgentSuffix(t *testing.T) {
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, nil)
+	d.Set("user_agent_suffix", "acme-platform/1.0")
+
+	client, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); !strings.HasSuffix(got, " acme-platform/1.0") || !strings.Contains(got, "terraform-provider-heroku/") {
+			t.Errorf("got User-Agent: %q, want the provider's User-Agent followed by the suffix", got)
+		}
+
+		_, writeErr := w.Write([]byte(`{"name":"some-app"}`))
+		if writeErr != nil {
+			t.Error(writeErr)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.(*Config).Api
+	c.URL = srv.URL
+
+	_, err = c.AppInfo(context.Background(), "does-not-matter")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderConfigureFeatures(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, nil)
+	client, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := client.(*Config)
+	if config.PreventAppDestroy != DefaultPreventAppDestroy || config.SkipFinalAddonSnapshot != DefaultSkipFinalAddonSnapshot {
+		t.Fatalf("expected default features, got prevent_app_destroy=%t skip_final_addon_snapshot=%t",
+			config.PreventAppDestroy, config.SkipFinalAddonSnapshot)
+	}
+
+	d = schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"features": []interface{}{
+			map[string]interface{}{
+				"prevent_app_destroy":       true,
+				"skip_final_addon_snapshot": false,
+			},
+		},
+	})
+	client, err = providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config = client.(*Config)
+	if !config.PreventAppDestroy || config.SkipFinalAddonSnapshot {
+		t.Fatalf("expected configured features, got prevent_app_destroy=%t skip_final_addon_snapshot=%t",
+			config.PreventAppDestroy, config.SkipFinalAddonSnapshot)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	testAccConfig.GetOrAbort(t, helper.TestConfigAPIKey)
 }
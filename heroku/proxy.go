@@ -0,0 +1,67 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyConfig returns the proxy configuration of the environment, overridden by
+// the proxy options of the provider that are set.
+func (c *Config) proxyConfig() *httpproxy.Config {
+	proxy := httpproxy.FromEnvironment()
+
+	if c.HTTPProxy != "" {
+		proxy.HTTPProxy = c.HTTPProxy
+	}
+	if c.HTTPSProxy != "" {
+		proxy.HTTPSProxy = c.HTTPSProxy
+	}
+	if c.NoProxy != "" {
+		proxy.NoProxy = c.NoProxy
+	}
+
+	return proxy
+}
+
+// configureProxy sets the transport of the provider's requests, which routes them
+// through its configured proxies. http.DefaultTransport is cloned rather than
+// changed, so that the proxies only apply to the provider's own clients.
+func (c *Config) configureProxy() error {
+	c.transport = http.DefaultTransport
+	if c.HTTPProxy == "" && c.HTTPSProxy == "" && c.NoProxy == "" {
+		return nil
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("Provider configuration error: the proxy of the HTTP transport cannot be configured")
+	}
+
+	transport := defaultTransport.Clone()
+	proxyFunc := c.proxyConfig().ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	c.transport = transport
+
+	log.Printf("[INFO] Heroku Client configured with proxies: http_proxy=%q https_proxy=%q no_proxy=%q",
+		redactProxyURL(c.HTTPProxy), redactProxyURL(c.HTTPSProxy), c.NoProxy)
+
+	return nil
+}
+
+// redactProxyURL removes the password of a proxy URL, for logging.
+func redactProxyURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxx")
+	}
+	return u.String()
+}
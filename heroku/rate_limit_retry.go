@@ -0,0 +1,59 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// rateLimitRetryTransport retries the API requests that are rate limited, with
+// the same exponential backoff as heroku-go's RoundTripWithRetryBackoff, which
+// can only send requests with http.DefaultTransport.
+type rateLimitRetryTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *rateLimitRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var res *http.Response
+	var resErr error
+
+	retryableRoundTrip := func() error {
+		res, resErr = t.transport.RoundTrip(req)
+		// https://devcenter.heroku.com/articles/platform-api-reference#client-error-responses
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("Heroku API rate limited: 429 Too Many Requests")
+		}
+		return nil
+	}
+
+	retryConfig := &backoff.ExponentialBackOff{
+		Clock:               backoff.SystemClock,
+		InitialInterval:     30 * time.Second,
+		RandomizationFactor: 0.25,
+		Multiplier:          2,
+		MaxInterval:         900 * time.Second,
+		MaxElapsedTime:      0,
+	}
+	retryConfig.Reset()
+
+	err := backoff.RetryNotify(retryableRoundTrip, retryConfig, func(err error, wait time.Duration) {
+		log.Printf("[DEBUG] Will retry Heroku API request in %s, because %s", wait, err)
+	})
+	if err != nil {
+		if res != nil {
+			res.Body.Close()
+		}
+		return nil, err
+	}
+	if resErr != nil {
+		if res != nil {
+			res.Body.Close()
+		}
+		return nil, resErr
+	}
+
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package heroku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// apiError is a Heroku API error that includes the id of the failed request, so
+// that it can be quoted to Heroku support.
+type apiError struct {
+	err       heroku.Error
+	RequestID string
+}
+
+func (e apiError) Error() string {
+	return fmt.Sprintf("%s (request id: %s)", e.err.Error(), e.RequestID)
+}
+
+// Unwrap returns the heroku.Error, so that errors.As still matches it.
+func (e apiError) Unwrap() error {
+	return e.err
+}
+
+type requestIDContextKey struct{}
+
+// requestID holds the id of a request, as recorded by requestIDRecorder.
+type requestID struct {
+	id string
+}
+
+// requestIDTransport adds the request id to the errors of transport, which is
+// the heroku.Transport that turns API error responses into errors.
+type requestIDTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rid := &requestID{}
+	res, err := t.transport.RoundTrip(req.WithContext(context.WithValue(req.Context(), requestIDContextKey{}, rid)))
+
+	var herr heroku.Error
+	if err != nil && rid.id != "" && errors.As(err, &herr) {
+		return res, apiError{err: herr, RequestID: rid.id}
+	}
+	return res, err
+}
+
+// requestIDRecorder records the id of each request, which is only set by the
+// heroku.Transport wrapping it. The id is taken from the response, as Heroku
+// replaces invalid request ids.
+type requestIDRecorder struct {
+	transport http.RoundTripper
+}
+
+func (t *requestIDRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+
+	if rid, ok := req.Context().Value(requestIDContextKey{}).(*requestID); ok {
+		rid.id = req.Header.Get("Request-Id")
+		if res != nil && res.Header.Get("Request-Id") != "" {
+			rid.id = res.Header.Get("Request-Id")
+		}
+	}
+
+	return res, err
+}
@@ -0,0 +1,35 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "01234567-89ab-cdef-0123-456789abcdef")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"id":"not_found","message":"Couldn't find that app."}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := config.Api.AppInfo(context.Background(), "some-app")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if !strings.Contains(err.Error(), "Couldn't find that app. (request id: 01234567-89ab-cdef-0123-456789abcdef)") {
+		t.Errorf("Expected the error to include the request id, got %s", err)
+	}
+	if !isNotFoundError(err) {
+		t.Errorf("Expected the error to still be a not found error, got %s", err)
+	}
+}
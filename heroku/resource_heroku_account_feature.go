@@ -51,7 +51,9 @@ func resourceHerokuAccountFeatureImport(d *schema.ResourceData, meta interface{}
 		return nil, err
 	}
 	d.SetId(d.Id())
-	d.Set("name", accountFeatureName)
+	if err := d.Set("name", accountFeatureName); err != nil {
+		return nil, setAttributeError("name", err)
+	}
 
 	readErr := resourceHerokuAccountFeatureRead(d, meta)
 	if readErr != nil {
@@ -93,13 +95,24 @@ func resourceHerokuAccountFeatureRead(d *schema.ResourceData, meta interface{})
 
 	accountFeature, err := client.AccountFeatureInfo(context.TODO(), featureName)
 	if err != nil {
+		if removeNotFound(d, "Account feature", err) {
+			return nil
+		}
 		return err
 	}
 
-	d.Set("name", accountFeature.Name)
-	d.Set("description", accountFeature.Description)
-	d.Set("state", accountFeature.State)
-	d.Set("enabled", accountFeature.Enabled)
+	if err := d.Set("name", accountFeature.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("description", accountFeature.Description); err != nil {
+		return setAttributeError("description", err)
+	}
+	if err := d.Set("state", accountFeature.State); err != nil {
+		return setAttributeError("state", err)
+	}
+	if err := d.Set("enabled", accountFeature.Enabled); err != nil {
+		return setAttributeError("enabled", err)
+	}
 
 	return nil
 }
@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
-	"net/url"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
@@ -23,11 +24,12 @@ var addonLock sync.Mutex
 
 func resourceHerokuAddon() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceHerokuAddonCreate,
-		Read:   resourceHerokuAddonRead,
-		Update: resourceHerokuAddonUpdate,
-		Delete: resourceHerokuAddonDelete,
-		Exists: resourceHerokuAddonExists,
+		Create:        resourceHerokuAddonCreate,
+		Read:          resourceHerokuAddonRead,
+		Update:        resourceHerokuAddonUpdate,
+		DeleteContext: resourceHerokuAddonDelete,
+
+		CustomizeDiff: resourceHerokuAddonCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -40,7 +42,6 @@ func resourceHerokuAddon() *schema.Resource {
 			"app": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"plan": {
@@ -73,6 +74,45 @@ func resourceHerokuAddon() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+
+			"connection_details": addonConnectionSchema(),
+
+			"web_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"billing_entity_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"final_backup": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"deprovision_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(DefaultAddonCreateTimeout) * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 	}
 }
@@ -96,11 +136,174 @@ func validateCustomAddonName(v interface{}, k string) (ws []string, errors []err
 	return ws, errors
 }
 
+// resourceHerokuAddonCustomizeDiff checks the target app's existing add-ons when a new
+// add-on is planned, so conflicts that would otherwise only fail at apply are reported
+// during plan along with a suggestion to import the existing add-on instead. Moving an
+// add-on to another app is checked in the same way.
+func resourceHerokuAddonCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	client := v.(*Config).Api
+
+	// Existing add-ons can only conflict with their new app when they move.
+	if diff.Id() != "" {
+		if !diff.HasChange("app") || !diff.NewValueKnown("app") {
+			return nil
+		}
+		o, n := diff.GetChange("app")
+		return checkAddonTransfer(ctx, client, diff.Id(), o.(string), n.(string), diff.Get("plan").(string))
+	}
+
+	app := diff.Get("app").(string)
+	plan := diff.Get("plan").(string)
+	if app == "" || plan == "" {
+		// The app or plan is not yet known, e.g. interpolated from a resource
+		// that has not been created, so there is nothing to check against.
+		return nil
+	}
+
+	existing, err := client.AddOnListByApp(ctx, app, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		// The app may not exist yet. Any real problem will surface at apply.
+		log.Printf("[DEBUG] Skipping add-on conflict detection for app %s: %s", app, err)
+		return nil
+	}
+
+	if name, ok := diff.GetOk("name"); ok {
+		for _, addon := range existing {
+			if addon.Name == name.(string) {
+				return fmt.Errorf("app %s already has an add-on named %s (%s). "+
+					"Import it with `terraform import <address> %s` instead of creating a new one",
+					app, addon.Name, addon.Plan.Name, addon.ID)
+			}
+		}
+	}
+
+	if addon := singletonAddon(ctx, client, app, plan, existing); addon != nil {
+		return fmt.Errorf("add-on service %s only allows one installation per app and app %s already has %s (%s). "+
+			"Import it with `terraform import <address> %s` instead of creating a new one",
+			addon.AddonService.Name, app, addon.Name, addon.Plan.Name, addon.ID)
+	}
+
+	return nil
+}
+
+// singletonAddon returns the add-on of existing that is billed to app, if the
+// add-on service of plan only allows one installation per app.
+func singletonAddon(ctx context.Context, client *heroku.Service, app, plan string, existing []heroku.AddOn) *heroku.AddOn {
+	serviceName := addonServiceName(plan)
+	service, err := client.AddOnServiceInfo(ctx, serviceName)
+	if err != nil {
+		log.Printf("[DEBUG] Skipping add-on singleton detection for service %s: %s", serviceName, err)
+		return nil
+	}
+
+	if service.SupportsMultipleInstallations {
+		return nil
+	}
+
+	for i, addon := range existing {
+		// Only add-ons billed to this app count towards its single installation.
+		if addon.AddonService.Name == service.Name && (addon.App.Name == app || addon.App.ID == app) {
+			return &existing[i]
+		}
+	}
+
+	return nil
+}
+
+// checkAddonTransfer reports, during plan, why an add-on cannot be moved from
+// one app to another. The apps' add-ons and attachments may not be readable yet,
+// e.g. when the new app is created in the same apply, in which case the checks
+// are left to the apply.
+func checkAddonTransfer(ctx context.Context, client *heroku.Service, id, from, to, plan string) error {
+	attachments, err := client.AddOnAttachmentListByAddOn(ctx, id, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping add-on move detection for addon %s: %s", id, err)
+		return nil
+	}
+	current, _, err := addonTransferAttachments(id, from, to, attachments)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(current))
+	for _, a := range current {
+		names[a.Name] = true
+	}
+
+	targetAttachments, err := client.AddOnAttachmentListByApp(ctx, to, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping add-on move detection for app %s: %s", to, err)
+		return nil
+	}
+	for _, a := range targetAttachments {
+		if names[a.Name] && a.Addon.ID != id {
+			return fmt.Errorf("addon %s cannot be moved to app %s, as the app already has an attachment named %s, of addon %s",
+				id, to, a.Name, a.Addon.Name)
+		}
+	}
+
+	existing, err := client.AddOnListByApp(ctx, to, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping add-on singleton detection for app %s: %s", to, err)
+		return nil
+	}
+	if addon := singletonAddon(ctx, client, to, plan, existing); addon != nil && addon.ID != id {
+		return fmt.Errorf("addon %s cannot be moved to app %s, as add-on service %s only allows one installation per app "+
+			"and the app already has %s (%s)", id, to, addon.AddonService.Name, addon.Name, addon.Plan.Name)
+	}
+
+	return nil
+}
+
+// addonTransferAttachments returns the attachments of an add-on to the app it is
+// moved from, and the names it is already attached under to the app it is moved
+// to. An add-on attached to any other app cannot be moved, as detaching it from
+// its current app could move its billing to that other app instead.
+func addonTransferAttachments(id, from, to string, attachments []heroku.AddOnAttachment) ([]heroku.AddOnAttachment, map[string]bool, error) {
+	var current []heroku.AddOnAttachment
+	attached := make(map[string]bool)
+	for _, a := range attachments {
+		switch {
+		case a.App.Name == from || a.App.ID == from:
+			current = append(current, a)
+		case a.App.Name == to || a.App.ID == to:
+			attached[a.Name] = true
+		default:
+			return nil, nil, fmt.Errorf("addon %s cannot be moved to app %s while it is also attached to app %s, "+
+				"which could be billed for it instead; detach it from app %s first", id, to, a.App.Name, a.App.Name)
+		}
+	}
+	if len(current) == 0 {
+		return nil, nil, fmt.Errorf("addon %s cannot be moved to app %s: it is not attached to app %s", id, to, from)
+	}
+	return current, attached, nil
+}
+
+// addonServiceName returns the add-on service portion of a plan, e.g. "heroku-redis"
+// for "heroku-redis:premium-0".
+func addonServiceName(plan string) string {
+	if idx := strings.IndexRune(plan, ':'); idx > -1 {
+		return plan[:idx]
+	}
+	return plan
+}
+
 func resourceHerokuAddonCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	if err := createAddon(d, config); err != nil {
+		return err
+	}
+
+	// Delay once addonLock is released, so that other add-ons are created meanwhile.
+	time.Sleep(time.Duration(config.PostAddonCreateDelay) * time.Second)
+
+	return resourceHerokuAddonRead(d, meta)
+}
+
+// createAddon creates an Addon and waits for it to be provisioned.
+func createAddon(d *schema.ResourceData, config *Config) error {
 	addonLock.Lock()
 	defer addonLock.Unlock()
 
-	config := meta.(*Config)
 	client := config.Api
 
 	app := d.Get("app").(string)
@@ -126,25 +329,55 @@ func resourceHerokuAddonCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Track the Addon in state before waiting, so that an Addon that fails to be
+	// provisioned is tainted and deleted on the next apply, rather than orphaned.
+	d.SetId(a.ID)
+	log.Printf("[INFO] Addon ID: %s", d.Id())
+
 	// Wait for the Addon to be provisioned
 	log.Printf("[DEBUG] Waiting for Addon (%s) to be provisioned", a.ID)
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"provisioning"},
 		Target:  []string{"provisioned"},
 		Refresh: AddOnStateRefreshFunc(client, app, a.ID),
-		Timeout: time.Duration(config.AddonCreateTimeout) * time.Minute,
+		Timeout: addonCreateTimeout(d, config),
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Addon (%s) to be provisioned: %s", d.Id(), err)
+		waitErr := stateWaitError(fmt.Sprintf("Addon (%s) to be provisioned", a.ID), schema.TimeoutCreate, err)
+		if d.Get("deprovision_on_failure").(bool) {
+			return deprovisionFailedAddon(d, client, app, a, waitErr)
+		}
+		return taintedCreateError(fmt.Sprintf("Addon %s", a.Name), waitErr)
 	}
 	log.Printf("[INFO] Addon provisioned: %s", d.Id())
 
-	// This should be only set after the addon provisioning has been fully completed.
-	d.SetId(a.ID)
-	log.Printf("[INFO] Addon ID: %s", d.Id())
+	return nil
+}
 
-	return resourceHerokuAddonRead(d, meta)
+// deprovisionFailedAddon deletes an Addon that failed to be provisioned or timed
+// out, so that the next apply starts clean. The Addon stays in state as tainted
+// if it cannot be deleted.
+func deprovisionFailedAddon(d *schema.ResourceData, client *heroku.Service, app string, a *heroku.AddOn, waitErr error) error {
+	log.Printf("[INFO] Deprovisioning Addon %s (%s) after it failed to be provisioned", a.Name, a.ID)
+	if _, err := client.AddOnDelete(context.TODO(), app, a.ID); err != nil && !isNotFoundError(err) {
+		return taintedCreateError(fmt.Sprintf("Addon %s", a.Name),
+			fmt.Errorf("%s\n\nError deprovisioning the failed Addon: %s", waitErr, err))
+	}
+
+	d.SetId("")
+	return fmt.Errorf("%s\n\nAddon %s was deprovisioned, so it is created again on the next apply.", waitErr, a.Name)
+}
+
+// addonCreateTimeout is how long to wait for an addon to be provisioned. A
+// timeouts.create that differs from the default takes precedence over the
+// provider's addon_create_timeout.
+func addonCreateTimeout(d *schema.ResourceData, config *Config) time.Duration {
+	defaultTimeout := time.Duration(DefaultAddonCreateTimeout) * time.Minute
+	if timeout := d.Timeout(schema.TimeoutCreate); timeout != defaultTimeout {
+		return timeout
+	}
+	return time.Duration(config.AddonCreateTimeout) * time.Minute
 }
 
 func resourceHerokuAddonRead(d *schema.ResourceData, meta interface{}) error {
@@ -152,6 +385,9 @@ func resourceHerokuAddonRead(d *schema.ResourceData, meta interface{}) error {
 
 	addon, err := resourceHerokuAddonRetrieve(d.Id(), client)
 	if err != nil {
+		if removeNotFound(d, "Addon", err) {
+			return nil
+		}
 		return err
 	}
 
@@ -168,14 +404,52 @@ func resourceHerokuAddonRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	d.Set("name", addon.Name)
-	d.Set("app", addon.App.Name)
-	d.Set("plan", plan)
-	d.Set("provider_id", addon.ProviderID)
+	if err := d.Set("name", addon.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("app", addon.App.Name); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("plan", plan); err != nil {
+		return setAttributeError("plan", err)
+	}
+	if err := d.Set("provider_id", addon.ProviderID); err != nil {
+		return setAttributeError("provider_id", err)
+	}
 	if err := d.Set("config_vars", addon.ConfigVars); err != nil {
 		return err
 	}
 
+	connection, err := addonConnection(context.TODO(), client, addon)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_details", connection); err != nil {
+		return err
+	}
+
+	return setAddonDashboardAttributes(d, addon)
+}
+
+// setAddonDashboardAttributes sets the web_url of the add-on's dashboard, and the
+// entity, e.g. the app or team, that the add-on is billed to.
+func setAddonDashboardAttributes(d *schema.ResourceData, addon *heroku.AddOn) error {
+	webURL := ""
+	if addon.WebURL != nil {
+		webURL = *addon.WebURL
+	}
+	if err := d.Set("web_url", webURL); err != nil {
+		return setAttributeError("web_url", err)
+	}
+	if err := d.Set("billing_entity_id", addon.BillingEntity.ID); err != nil {
+		return setAttributeError("billing_entity_id", err)
+	}
+	if err := d.Set("billing_entity_name", addon.BillingEntity.Name); err != nil {
+		return setAttributeError("billing_entity_name", err)
+	}
+	if err := d.Set("billing_entity_type", addon.BillingEntity.Type); err != nil {
+		return setAttributeError("billing_entity_type", err)
+	}
 	return nil
 }
 
@@ -185,6 +459,22 @@ func resourceHerokuAddonUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	app := d.Get("app").(string)
 
+	// Move the add-on to its new app rather than replacing it, which would
+	// destroy its data. The previous state is kept if the move fails, as the
+	// add-on is then still billed to its previous app.
+	if d.HasChange("app") {
+		d.Partial(true)
+		o, _ := d.GetChange("app")
+		if err := transferAddon(context.TODO(), client, d.Id(), o.(string), app); err != nil {
+			return attributePathError(cty.GetAttrPath("app"), err)
+		}
+		d.Partial(false)
+	}
+
+	if !d.HasChange("plan") && !d.HasChange("name") {
+		return resourceHerokuAddonRead(d, meta)
+	}
+
 	if d.HasChange("plan") {
 		opts.Plan = d.Get("plan").(string)
 	}
@@ -196,6 +486,13 @@ func resourceHerokuAddonUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	ad, updateErr := client.AddOnUpdate(context.TODO(), app, d.Id(), opts)
 	if updateErr != nil {
+		// Point at the only changed attribute, as the error is about it.
+		if opts.Name == nil {
+			return attributePathError(cty.GetAttrPath("plan"), fmt.Errorf("Error updating addon plan to %s: %w", opts.Plan, updateErr))
+		}
+		if opts.Plan == "" {
+			return attributePathError(cty.GetAttrPath("name"), fmt.Errorf("Error renaming addon to %s: %w", *opts.Name, updateErr))
+		}
 		return updateErr
 	}
 
@@ -205,40 +502,138 @@ func resourceHerokuAddonUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceHerokuAddonRead(d, meta)
 }
 
-func resourceHerokuAddonDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).Api
+// transferAddon moves an add-on to another app, which is then billed for it, by
+// attaching it to the app under the name of each of its attachments to the current
+// app, and then detaching it from the current app. The config vars of the
+// attachments, e.g. DATABASE_URL, are therefore kept. The add-on is attached back
+// to its current app if it cannot be moved.
+func transferAddon(ctx context.Context, client *heroku.Service, id, from, to string) error {
+	addonLock.Lock()
+	defer addonLock.Unlock()
 
-	log.Printf("[INFO] Deleting Addon: %s", d.Id())
+	attachments, err := client.AddOnAttachmentListByAddOn(ctx, id, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return fmt.Errorf("Error retrieving the attachments of addon %s: %s", id, err)
+	}
 
-	// Destroy the app
-	_, err := client.AddOnDelete(context.TODO(), d.Get("app").(string), d.Id())
+	current, attached, err := addonTransferAttachments(id, from, to, attachments)
 	if err != nil {
-		return fmt.Errorf("Error deleting addon: %s", err)
+		return err
+	}
+
+	var created []*heroku.AddOnAttachment
+	for _, a := range current {
+		if attached[a.Name] {
+			continue
+		}
+		name := a.Name
+		log.Printf("[INFO] Attaching addon %s to app %s as %s", id, to, name)
+		opts := heroku.AddOnAttachmentCreateOpts{Addon: id, App: to, Name: &name}
+		attachment, err := client.AddOnAttachmentCreate(ctx, opts)
+		if err != nil {
+			err = fmt.Errorf("Error attaching addon %s to app %s as %s: %s", id, to, name, err)
+			return rollbackAddonTransfer(ctx, client, id, nil, created, err)
+		}
+		attached[name] = true
+		created = append(created, attachment)
+	}
+
+	var detached []heroku.AddOnAttachment
+	for _, a := range current {
+		log.Printf("[INFO] Detaching addon %s from app %s as %s", id, from, a.Name)
+		if _, err := client.AddOnAttachmentDelete(ctx, a.ID); err != nil {
+			err = fmt.Errorf("Error detaching addon %s from app %s as %s: %s", id, from, a.Name, err)
+			return rollbackAddonTransfer(ctx, client, id, detached, created, err)
+		}
+		detached = append(detached, a)
+	}
+
+	addon, err := client.AddOnInfo(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error retrieving addon %s: %s", id, err)
+		return rollbackAddonTransfer(ctx, client, id, detached, created, err)
+	}
+	if addon.App.Name != to && addon.App.ID != to {
+		err = fmt.Errorf("Error moving addon %s to app %s: it is billed to app %s", id, to, addon.App.Name)
+		return rollbackAddonTransfer(ctx, client, id, detached, created, err)
 	}
 
-	d.SetId("")
 	return nil
 }
 
-func resourceHerokuAddonExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*Config).Api
+// rollbackAddonTransfer undoes a failed move of an add-on, by attaching it back to
+// its previous app under each of the detached names, and then detaching it from
+// the app it was attached to by the move. It returns moveErr, along with any
+// error undoing the move.
+func rollbackAddonTransfer(ctx context.Context, client *heroku.Service, id string, detached []heroku.AddOnAttachment, created []*heroku.AddOnAttachment, moveErr error) error {
+	var errs []string
+
+	for _, a := range detached {
+		name := a.Name
+		log.Printf("[INFO] Attaching addon %s back to app %s as %s", id, a.App.Name, name)
+		opts := heroku.AddOnAttachmentCreateOpts{Addon: id, App: a.App.ID, Name: &name}
+		if _, err := client.AddOnAttachmentCreate(ctx, opts); err != nil {
+			errs = append(errs, fmt.Sprintf("Error attaching addon %s back to app %s as %s: %s", id, a.App.Name, name, err))
+		}
+	}
 
-	_, err := client.AddOnInfo(context.TODO(), d.Id())
-	if err != nil {
-		if herr, ok := err.(*url.Error).Err.(heroku.Error); ok && herr.ID == "not_found" {
-			return false, nil
+	// The add-on is left attached to both apps rather than to neither.
+	if len(errs) == 0 {
+		for _, a := range created {
+			log.Printf("[INFO] Detaching addon %s from app %s as %s", id, a.App.Name, a.Name)
+			if _, err := client.AddOnAttachmentDelete(ctx, a.ID); err != nil && !isNotFoundError(err) {
+				errs = append(errs, fmt.Sprintf("Error detaching addon %s from app %s as %s: %s", id, a.App.Name, a.Name, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s\n\nThe move could not be undone:\n%s", moveErr, strings.Join(errs, "\n"))
+	}
+	return moveErr
+}
+
+func resourceHerokuAddonDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	var diags diag.Diagnostics
+	app := d.Get("app").(string)
+
+	// The backup is captured first, so that the add-on is not destroyed without it.
+	finalBackup := d.Get("final_backup").(bool) || !config.SkipFinalAddonSnapshot
+	if finalBackup && addonServiceName(d.Get("plan").(string)) == "heroku-postgresql" {
+		log.Printf("[INFO] Capturing final backup of Addon: %s", d.Id())
+		num, downloadURL, err := capturePostgresBackup(ctx, config.PostgresAPI, app, d.Id(), d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		return false, err
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Captured final backup b%03d of add-on %s", num, d.Get("name").(string)),
+			Detail: fmt.Sprintf("The backup remains available from app %s, with `heroku pg:backups:download b%03d --app %s`. "+
+				"Until %s, it can also be downloaded from:\n%s", app, num, app, downloadURL.ExpiresAt, downloadURL.URL),
+		})
 	}
 
-	return true, nil
+	log.Printf("[INFO] Deleting Addon: %s", d.Id())
+
+	// Destroy the app
+	_, err := client.AddOnDelete(ctx, app, d.Id())
+	if err != nil {
+		return append(diags, diag.Errorf("Error deleting addon: %s", err)...)
+	}
+
+	d.SetId("")
+	return diags
 }
 
 func resourceHerokuAddonRetrieve(id string, client *heroku.Service) (*heroku.AddOn, error) {
 	addon, err := client.AddOnInfo(context.TODO(), id)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error retrieving addon: %s", err)
+		return nil, fmt.Errorf("Error retrieving addon: %w", err)
 	}
 
 	return addon, nil
@@ -248,7 +643,7 @@ func resourceHerokuAddonRetrieveByApp(app string, id string, client *heroku.Serv
 	addon, err := client.AddOnInfoByApp(context.TODO(), app, id)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error retrieving addon: %s", err)
+		return nil, fmt.Errorf("Error retrieving addon: %w", err)
 	}
 
 	return addon, nil
@@ -0,0 +1,225 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// postgresDatabase is a Heroku Postgres database of the Postgres API.
+type postgresDatabase struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Following *string `json:"following"`
+	Info      []struct {
+		Name   string   `json:"name"`
+		Values []string `json:"values"`
+	} `json:"info"`
+}
+
+// infoValues returns the values of the database info entry of the given name,
+// such as "Followers" or "Behind By", as shown by heroku pg:info.
+func (db *postgresDatabase) infoValues(name string) []string {
+	for _, i := range db.Info {
+		if i.Name == name {
+			return i.Values
+		}
+	}
+	return nil
+}
+
+// postgresWaitStatus is the status of a database's ongoing operation, such as
+// provisioning, unfollowing or restarting.
+type postgresWaitStatus struct {
+	Waiting bool   `json:"waiting?"`
+	Message string `json:"message"`
+}
+
+func resourceHerokuAddonAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAddonActionCreate,
+		ReadContext:   resourceHerokuAddonActionRead,
+		DeleteContext: resourceHerokuAddonActionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"promote",
+					"unfollow",
+					"restart",
+					"credentials_repair",
+				}, false),
+			},
+
+			"app": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"completed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: resourceHerokuAddonActionCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+// resourceHerokuAddonActionCustomizeDiff requires the app of the promote action,
+// which is the app whose DATABASE_URL is set to the promoted database.
+func resourceHerokuAddonActionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Get("action").(string) == "promote" && diff.NewValueKnown("app") && diff.Get("app").(string) == "" {
+		return fmt.Errorf("app must be set to promote a database")
+	}
+	return nil
+}
+
+func resourceHerokuAddonActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.PostgresAPI
+
+	addonID := d.Get("addon_id").(string)
+	action := d.Get("action").(string)
+
+	log.Printf("[INFO] Running %s action of add-on %s", action, addonID)
+	var err error
+	switch action {
+	case "promote":
+		err = promotePostgresDatabase(ctx, config, addonID, d.Get("app").(string), d.Timeout(schema.TimeoutCreate))
+	case "unfollow":
+		err = client.Put(ctx, nil, fmt.Sprintf("%s/unfollow", postgresDatabasePath(addonID)), nil)
+	case "restart":
+		err = client.Post(ctx, nil, fmt.Sprintf("%s/restart", postgresDatabasePath(addonID)), nil)
+	case "credentials_repair":
+		// Like the other credential endpoints, see postgresCredentialsPath, repairing
+		// the default credential is only part of the v0 API, not of postgresDatabasePath.
+		err = client.Post(ctx, nil, fmt.Sprintf("/postgres/v0/databases/%s/repair-default", addonID), nil)
+	}
+	if err != nil {
+		return diag.Errorf("Error running %s action of add-on %s: %s", action, addonID, err)
+	}
+
+	if err := waitForPostgresDatabase(client, addonID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("Error waiting for %s action of add-on %s to complete: %s", action, addonID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%d", addonID, action, time.Now().Unix()))
+	if err := d.Set("completed_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("completed_at", err)
+	}
+
+	log.Printf("[INFO] Completed %s action of add-on %s", action, addonID)
+
+	return nil
+}
+
+// resourceHerokuAddonActionRead is a no-op, as actions have no state to read.
+func resourceHerokuAddonActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceHerokuAddonActionDelete only removes the action from state, as completed
+// actions cannot be undone.
+func resourceHerokuAddonActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for add-on action resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+// promotePostgresDatabase makes a database the DATABASE of an app, like
+// `heroku pg:promote`. A follower is unfollowed first, so that it is writable.
+func promotePostgresDatabase(ctx context.Context, config *Config, addonID, app string, timeout time.Duration) error {
+	var database postgresDatabase
+	if err := config.PostgresAPI.Get(ctx, &database, postgresDatabasePath(addonID), nil, nil); err != nil {
+		return err
+	}
+
+	if database.Following != nil && *database.Following != "" {
+		log.Printf("[DEBUG] Unfollowing Postgres database %s before promoting it", addonID)
+		if err := config.PostgresAPI.Put(ctx, nil, fmt.Sprintf("%s/unfollow", postgresDatabasePath(addonID)), nil); err != nil {
+			return err
+		}
+		if err := waitForPostgresDatabase(config.PostgresAPI, addonID, timeout); err != nil {
+			return err
+		}
+	}
+
+	name := "DATABASE"
+	log.Printf("[DEBUG] Attaching Postgres database %s as %s of app %s", addonID, name, app)
+	_, err := config.Api.AddOnAttachmentCreate(ctx, heroku.AddOnAttachmentCreateOpts{
+		Addon:   addonID,
+		App:     app,
+		Name:    &name,
+		Confirm: &app,
+	})
+	return err
+}
+
+// waitForPostgresDatabase waits for the ongoing operation of a database to complete.
+func waitForPostgresDatabase(client *heroku.Service, addonID string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"waiting"},
+		Target:     []string{"available"},
+		Refresh:    PostgresDatabaseWaitStateRefreshFunc(client, addonID),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// PostgresDatabaseWaitStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch the ongoing operation of a Postgres database.
+func PostgresDatabaseWaitStateRefreshFunc(client *heroku.Service, addonID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var status postgresWaitStatus
+		if err := client.Get(context.TODO(), &status, fmt.Sprintf("%s/wait_status", postgresDatabasePath(addonID)), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get wait status of Postgres database %s: %s", addonID, err)
+			return nil, "", err
+		}
+
+		if status.Waiting {
+			log.Printf("[DEBUG] Postgres database %s: %s", addonID, status.Message)
+			return status, "waiting", nil
+		}
+
+		return status, "available", nil
+	}
+}
+
+func postgresDatabasePath(addonID string) string {
+	return fmt.Sprintf("/client/v11/databases/%s", addonID)
+}
@@ -0,0 +1,120 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceHerokuAddonActionCreate_Paths(t *testing.T) {
+	tests := []struct {
+		action string
+		method string
+		path   string
+	}{
+		{"unfollow", http.MethodPut, "/client/v11/databases/addon-id/unfollow"},
+		{"restart", http.MethodPost, "/client/v11/databases/addon-id/restart"},
+		{"credentials_repair", http.MethodPost, "/postgres/v0/databases/addon-id/repair-default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			var actionRequested bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == tt.method && r.URL.Path == tt.path:
+					actionRequested = true
+					w.Write([]byte(`{}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/client/v11/databases/addon-id/wait_status":
+					w.Write([]byte(`{"waiting?": false, "message": "Available"}`))
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+					http.Error(w, "unexpected request", http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			config := NewConfig()
+			config.PostgresURL = srv.URL
+			if err := config.initializeAPI(); err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceHerokuAddonAction().Schema, map[string]interface{}{
+				"addon_id": "addon-id",
+				"action":   tt.action,
+			})
+			if diags := resourceHerokuAddonActionCreate(context.Background(), d, config); diags.HasError() {
+				t.Fatalf("Unexpected error: %#v", diags)
+			}
+			if !actionRequested {
+				t.Errorf("Expected the %s action to request %s %s", tt.action, tt.method, tt.path)
+			}
+		})
+	}
+}
+
+func TestAccHerokuAddonAction_Restart(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAddonActionConfig(appName, "restart"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_addon_action.foobar", "action", "restart"),
+					resource.TestCheckResourceAttrSet("heroku_addon_action.foobar", "completed_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuAddonAction_PromoteWithoutApp(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuAddonActionConfig(appName, "promote"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`app must be set to promote a database`),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAddonActionConfig(appName, action string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "database" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+}
+
+resource "heroku_addon_action" "foobar" {
+  addon_id = heroku_addon.database.id
+  action   = "%s"
+}
+`, appName, action)
+}
@@ -88,13 +88,24 @@ func resourceHerokuAddonAttachmentRead(d *schema.ResourceData, meta interface{})
 
 	addonattachment, err := client.AddOnAttachmentInfo(context.TODO(), d.Id())
 	if err != nil {
+		if removeNotFound(d, "Addon attachment", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving addon attachment: %s", err)
 	}
 
-	d.Set("app_id", addonattachment.App.Name)
-	d.Set("addon_id", addonattachment.Addon.ID)
-	d.Set("name", addonattachment.Name)
-	d.Set("namespace", addonattachment.Namespace)
+	if err := d.Set("app_id", addonattachment.App.Name); err != nil {
+		return setAttributeError("app_id", err)
+	}
+	if err := d.Set("addon_id", addonattachment.Addon.ID); err != nil {
+		return setAttributeError("addon_id", err)
+	}
+	if err := d.Set("name", addonattachment.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("namespace", addonattachment.Namespace); err != nil {
+		return setAttributeError("namespace", err)
+	}
 
 	return nil
 }
@@ -36,6 +36,11 @@ func migrateAddonAttachmentStateV0toV1(is *terraform.InstanceState, client *hero
 
 	addon, err := client.AddOnInfoByApp(context.TODO(), attachmentAppId, attachmentAddOnId)
 	if err != nil {
+		// The read that follows the migration removes the attachment from state.
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Addon %s not found, skipping migration", attachmentAddOnId)
+			return is, nil
+		}
 		return nil, fmt.Errorf("Error retrieving addon: %s", err)
 	}
 
@@ -41,6 +41,11 @@ func migrateAddonIdsStateV0toV1(is *terraform.InstanceState, client *heroku.Serv
 
 	addon, err := client.AddOnInfoByApp(context.TODO(), addonAppId, currentAddonId)
 	if err != nil {
+		// The read that follows the migration removes the addon from state.
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Addon %s not found, skipping migration", currentAddonId)
+			return is, nil
+		}
 		return nil, fmt.Errorf("error retrieving addon: %s", err)
 	}
 
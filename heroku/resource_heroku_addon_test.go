@@ -2,16 +2,443 @@ package heroku
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
+func TestDeprovisionFailedAddon(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = r.URL.Path
+		}
+		w.Write([]byte(`{"id":"01234567-89ab-cdef-0123-456789abcdef","name":"brave-1234","state":"deprovisioned"}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuAddon().Schema, map[string]interface{}{
+		"app":                    "some-app",
+		"plan":                   "heroku-postgresql",
+		"deprovision_on_failure": true,
+	})
+	a := &heroku.AddOn{ID: "01234567-89ab-cdef-0123-456789abcdef", Name: "brave-1234"}
+	d.SetId(a.ID)
+
+	err := deprovisionFailedAddon(d, config.Api, "some-app", a, errors.New("Error waiting for Addon to be provisioned"))
+	if err == nil || !strings.Contains(err.Error(), "brave-1234 was deprovisioned") {
+		t.Fatalf("Expected a deprovisioned error, got %v", err)
+	}
+	if expected := "/apps/some-app/addons/" + a.ID; deleted != expected {
+		t.Errorf("Expected %s to be deleted, got %q", expected, deleted)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected the Addon to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceHerokuAddonCreate_PostAddonCreateDelay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/apps/some-app/addons":
+			var opts heroku.AddOnCreateOpts
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil || opts.Name == nil {
+				t.Errorf("Unexpected addon: %#v, %v", opts, err)
+				http.Error(w, "unexpected addon", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "%s", "name": "%s", "state": "provisioning"}`, *opts.Name, *opts.Name)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/apps/some-app/addons/"),
+			r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/addons/"):
+			name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			fmt.Fprintf(w, `{"id": "%s", "name": "%s", "state": "provisioned", "app": {"name": "some-app"},
+				"plan": {"name": "papertrail:choklad"}}`, name, name)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PostAddonCreateDelay = 1
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating two add-ons at once takes a single delay, as the delay is not
+	// held under addonLock.
+	start := time.Now()
+	errs := make(chan error, 2)
+	for _, name := range []string{"papertrail-one", "papertrail-two"} {
+		d := schema.TestResourceDataRaw(t, resourceHerokuAddon().Schema, map[string]interface{}{
+			"app":  "some-app",
+			"plan": "papertrail:choklad",
+			"name": name,
+		})
+		go func() { errs <- resourceHerokuAddonCreate(d, config) }()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second || elapsed >= 2*time.Second {
+		t.Fatalf("Expected the add-ons to be created after a single 1s delay, took %s", elapsed)
+	}
+}
+
+func TestSetAddonDashboardAttributes(t *testing.T) {
+	webURL := "https://addons-sso.heroku.com/apps/some-app/addons/some-addon"
+	addon := &heroku.AddOn{WebURL: &webURL}
+	addon.BillingEntity.ID = "01234567-89ab-cdef-0123-456789abcdef"
+	addon.BillingEntity.Name = "some-team"
+	addon.BillingEntity.Type = "team"
+
+	d := resourceHerokuAddon().TestResourceData()
+	if err := setAddonDashboardAttributes(d, addon); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"web_url":             webURL,
+		"billing_entity_id":   "01234567-89ab-cdef-0123-456789abcdef",
+		"billing_entity_name": "some-team",
+		"billing_entity_type": "team",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+
+	// Add-ons without a dashboard have no web URL.
+	if err := setAddonDashboardAttributes(d, &heroku.AddOn{}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := d.Get("web_url").(string); actual != "" {
+		t.Errorf("Expected an empty web_url, got %q", actual)
+	}
+}
+
+func TestTransferAddon(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/addons/addon-id/addon-attachments":
+			w.Write([]byte(`[{"id": "attachment-id", "name": "DATABASE", "app": {"id": "old-id", "name": "old-app"}}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/addon-attachments":
+			var opts heroku.AddOnAttachmentCreateOpts
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Error(err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if opts.App != "new-app" || opts.Name == nil || *opts.Name != "DATABASE" {
+				t.Errorf("Unexpected attachment: %#v", opts)
+				http.Error(w, "unexpected request body", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"id": "new-attachment-id"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/addon-attachments/attachment-id":
+			w.Write([]byte(`{"id": "attachment-id"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/addons/addon-id":
+			w.Write([]byte(`{"id": "addon-id", "app": {"id": "new-id", "name": "new-app"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := transferAddon(context.Background(), config.Api, "addon-id", "old-app", "new-app"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET /addons/addon-id/addon-attachments",
+		"POST /addon-attachments",
+		"DELETE /addon-attachments/attachment-id",
+		"GET /addons/addon-id",
+	}
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+
+	if err := transferAddon(context.Background(), config.Api, "addon-id", "other-app", "new-app"); err == nil {
+		t.Fatal("Expected an error moving an addon from an app it is not attached to")
+	}
+}
+
+func TestTransferAddon_Rollback(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/apps/") && !strings.HasPrefix(r.URL.Path, "/addon-services/") {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/addons/addon-id/addon-attachments":
+			w.Write([]byte(`[{"id": "attachment-id", "name": "DATABASE", "app": {"id": "old-id", "name": "old-app"}}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/addon-attachments":
+			w.Write([]byte(`{"id": "new-attachment-id", "app": {"id": "new-id", "name": "new-app"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/addon-attachments/attachment-id":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"id": "invalid_params", "message": "The attachment cannot be removed."}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/addon-attachments/new-attachment-id":
+			w.Write([]byte(`{"id": "new-attachment-id"}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/apps/new-app/"):
+			w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/addon-services/heroku-postgresql":
+			w.Write([]byte(`{"name": "heroku-postgresql", "supports_multiple_installations": true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := transferAddon(context.Background(), config.Api, "addon-id", "old-app", "new-app")
+	if err == nil || !strings.Contains(err.Error(), "Error detaching addon addon-id from app old-app") {
+		t.Fatalf("Expected a detach error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "could not be undone") {
+		t.Fatalf("Expected the move to be undone, got %v", err)
+	}
+
+	// The attachment to the new app is removed, leaving the add-on as it was.
+	expected := []string{
+		"GET /addons/addon-id/addon-attachments",
+		"POST /addon-attachments",
+		"DELETE /addon-attachments/attachment-id",
+		"DELETE /addon-attachments/new-attachment-id",
+	}
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+
+	// The add-on stays in state with its previous app.
+	state := &terraform.InstanceState{
+		ID: "addon-id",
+		Attributes: map[string]string{
+			"id":                     "addon-id",
+			"app":                    "old-app",
+			"plan":                   "heroku-postgresql:standard-0",
+			"name":                   "postgresql-some",
+			"final_backup":           "false",
+			"deprovision_on_failure": "false",
+		},
+	}
+	raw := map[string]interface{}{
+		"app":  "new-app",
+		"plan": "heroku-postgresql:standard-0",
+	}
+	diff, err := resourceHerokuAddon().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newState, diags := resourceHerokuAddon().Apply(context.Background(), state, diff, config)
+	if !diags.HasError() {
+		t.Fatal("Expected the move to fail")
+	}
+	if app := newState.Attributes["app"]; app != "old-app" {
+		t.Fatalf("Expected app to stay old-app in state, got %s", app)
+	}
+}
+
+func TestTransferAddon_MultipleAttachments(t *testing.T) {
+	tests := []struct {
+		name          string
+		failDetach    string
+		expectedErr   string
+		expectedCalls []string
+	}{
+		{"moved", "", "", []string{
+			"GET /addons/addon-id/addon-attachments",
+			"POST /addon-attachments DATABASE",
+			"POST /addon-attachments HEROKU_POSTGRESQL_RED",
+			"DELETE /addon-attachments/database-id",
+			"DELETE /addon-attachments/red-id",
+			"GET /addons/addon-id",
+		}},
+		{"rolled back", "red-id", "Error detaching addon addon-id from app old-app as HEROKU_POSTGRESQL_RED", []string{
+			"GET /addons/addon-id/addon-attachments",
+			"POST /addon-attachments DATABASE",
+			"POST /addon-attachments HEROKU_POSTGRESQL_RED",
+			"DELETE /addon-attachments/database-id",
+			"DELETE /addon-attachments/red-id",
+			"POST /addon-attachments DATABASE",
+			"DELETE /addon-attachments/new-DATABASE",
+			"DELETE /addon-attachments/new-HEROKU_POSTGRESQL_RED",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/addons/addon-id/addon-attachments":
+					w.Write([]byte(`[{"id": "database-id", "name": "DATABASE", "app": {"id": "old-id", "name": "old-app"}},
+						{"id": "red-id", "name": "HEROKU_POSTGRESQL_RED", "app": {"id": "old-id", "name": "old-app"}}]`))
+				case r.Method == http.MethodPost && r.URL.Path == "/addon-attachments":
+					var opts heroku.AddOnAttachmentCreateOpts
+					if err := json.NewDecoder(r.Body).Decode(&opts); err != nil || opts.Name == nil {
+						t.Errorf("Unexpected attachment: %#v, %v", opts, err)
+						http.Error(w, "unexpected attachment", http.StatusBadRequest)
+						return
+					}
+					requests = append(requests, r.Method+" "+r.URL.Path+" "+*opts.Name)
+					fmt.Fprintf(w, `{"id": "new-%s", "name": "%s", "app": {"id": "%s"}}`, *opts.Name, *opts.Name, opts.App)
+					return
+				case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/addon-attachments/"):
+					if strings.HasSuffix(r.URL.Path, "/"+tt.failDetach) {
+						requests = append(requests, r.Method+" "+r.URL.Path)
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						w.Write([]byte(`{"id": "invalid_params", "message": "The attachment cannot be removed."}`))
+						return
+					}
+					w.Write([]byte(`{}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/addons/addon-id":
+					w.Write([]byte(`{"id": "addon-id", "app": {"id": "new-id", "name": "new-app"}}`))
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+					http.Error(w, "unexpected request", http.StatusNotFound)
+					return
+				}
+				requests = append(requests, r.Method+" "+r.URL.Path)
+			}))
+			defer srv.Close()
+
+			config := NewConfig()
+			config.URL = srv.URL
+			if err := config.initializeAPI(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := transferAddon(context.Background(), config.Api, "addon-id", "old-app", "new-app")
+			if tt.expectedErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr) ||
+				strings.Contains(err.Error(), "could not be undone")) {
+				t.Fatalf("Expected an undone error containing %q, got %v", tt.expectedErr, err)
+			}
+			if strings.Join(requests, ", ") != strings.Join(tt.expectedCalls, ", ") {
+				t.Fatalf("Expected requests %v, got %v", tt.expectedCalls, requests)
+			}
+		})
+	}
+}
+
+func TestAddonTransferAttachments(t *testing.T) {
+	attachment := func(app, name string) heroku.AddOnAttachment {
+		var a heroku.AddOnAttachment
+		a.App.Name = app
+		a.Name = name
+		return a
+	}
+
+	current, attached, err := addonTransferAttachments("addon-id", "old-app", "new-app", []heroku.AddOnAttachment{
+		attachment("old-app", "DATABASE"), attachment("old-app", "HEROKU_POSTGRESQL_RED"), attachment("new-app", "DATABASE"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current) != 2 || current[0].Name != "DATABASE" || current[1].Name != "HEROKU_POSTGRESQL_RED" {
+		t.Fatalf("Unexpected attachments %#v", current)
+	}
+	if !attached["DATABASE"] || attached["HEROKU_POSTGRESQL_RED"] {
+		t.Fatalf("Unexpected attached names %v", attached)
+	}
+
+	// Detaching the add-on from old-app could move its billing to other-app.
+	_, _, err = addonTransferAttachments("addon-id", "old-app", "new-app",
+		[]heroku.AddOnAttachment{attachment("old-app", "DATABASE"), attachment("other-app", "DATABASE")})
+	if err == nil || !strings.Contains(err.Error(), "also attached to app other-app") {
+		t.Fatalf("Expected an error for the attachment to other-app, got %v", err)
+	}
+}
+
+func TestResourceHerokuAddon_TransferDiff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/addons/01234567-89ab-cdef-0123-456789abcdef/addon-attachments":
+			w.Write([]byte(`[{"id": "attachment-id", "name": "REDIS", "app": {"id": "old-id", "name": "old-app"}}]`))
+		case "/apps/new-app/addon-attachments":
+			w.Write([]byte(`[]`))
+		case "/apps/new-app/addons":
+			w.Write([]byte(`[{"id": "other-id", "name": "redis-other", "app": {"id": "new-id", "name": "new-app"},
+				"addon_service": {"name": "heroku-redis"}, "plan": {"name": "heroku-redis:premium-0"}}]`))
+		case "/addon-services/heroku-redis":
+			w.Write([]byte(`{"name": "heroku-redis", "supports_multiple_installations": false}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	meta := NewConfig()
+	meta.URL = srv.URL
+	if err := meta.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "01234567-89ab-cdef-0123-456789abcdef",
+		Attributes: map[string]string{
+			"id":                     "01234567-89ab-cdef-0123-456789abcdef",
+			"app":                    "old-app",
+			"plan":                   "heroku-redis:premium-0",
+			"name":                   "redis-some",
+			"final_backup":           "false",
+			"deprovision_on_failure": "false",
+		},
+	}
+	raw := map[string]interface{}{
+		"app":  "new-app",
+		"plan": "heroku-redis:premium-0",
+	}
+
+	_, err := resourceHerokuAddon().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err == nil || !strings.Contains(err.Error(), "only allows one installation per app") {
+		t.Fatalf("Expected a singleton error, got %v", err)
+	}
+}
+
 func TestAccHerokuAddon_Basic(t *testing.T) {
 	var addon heroku.AddOn
 	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
@@ -151,6 +578,26 @@ func TestAccHerokuAddon_CustomName_FirstCharNum(t *testing.T) {
 	})
 }
 
+func TestAccHerokuAddon_ConflictingName(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	customName := fmt.Sprintf("custom-addonname-%s", acctest.RandString(15))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAddonConfig_CustomName(appName, customName),
+			},
+			{
+				Config:      testAccCheckHerokuAddonConfig_ConflictingName(appName, customName),
+				ExpectError: regexp.MustCompile(`already has an add-on named`),
+			},
+		},
+	})
+}
+
 func TestAccHerokuAddon_Disappears(t *testing.T) {
 	var addon heroku.AddOn
 	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
@@ -282,3 +729,66 @@ resource "heroku_addon" "foobar" {
     name = "%s"
 }`, appName, customAddonName)
 }
+
+func testAccCheckHerokuAddonConfig_ConflictingName(appName, customAddonName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_addon" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    plan = "memcachier"
+    name = "%s"
+}
+
+resource "heroku_addon" "conflict" {
+    app = "${heroku_app.foobar.name}"
+    plan = "memcachier"
+    name = "%s"
+}`, appName, customAddonName, customAddonName)
+}
+
+func TestAccHerokuAddon_FinalBackup(t *testing.T) {
+	var addon heroku.AddOn
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAddonConfig_finalBackup(appName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAddonExists("heroku_addon.foobar", &addon),
+					resource.TestCheckResourceAttr(
+						"heroku_addon.foobar", "final_backup", "true"),
+					resource.TestCheckResourceAttr(
+						"heroku_addon.foobar", "connection_details.0.scheme", "postgres"),
+					resource.TestCheckResourceAttr(
+						"heroku_addon.foobar", "connection_details.0.port", "5432"),
+					resource.TestCheckResourceAttr(
+						"heroku_addon.foobar", "connection_details.0.sslmode", "require"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_addon.foobar", "connection_details.0.password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAddonConfig_finalBackup(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_addon" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    plan = "heroku-postgresql:essential-0"
+    final_backup = true
+}`, appName)
+}
@@ -7,8 +7,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,6 +34,13 @@ type herokuApplication struct {
 	Personal        bool
 	Acm             bool
 	ID              string
+	Generation      string
+}
+
+// appWithGeneration is an app as returned by the Platform API, including its generation.
+type appWithGeneration struct {
+	heroku.App
+	resourceGeneration
 }
 
 // type application is used to store all the details of a heroku app
@@ -42,15 +52,35 @@ type application struct {
 	Vars       map[string]string  // Represents all vars on a heroku app.
 	Buildpacks []string           // The application's buildpack names or URLs
 	IsTeamApp  bool               // Is the application a team (organization) app
+	Features   map[string]bool    // Is each of the appFeatureAttributes enabled
+}
+
+// appFeatureAttributes are the app features that heroku_app manages as attributes,
+// so that their prerequisites are validated along with the app.
+var appFeatureAttributes = []struct {
+	attribute string
+	feature   string
+}{
+	{"preboot", appFeaturePreboot},
+	{"router_2_0", appFeatureRouter2},
 }
 
+const (
+	// configVarsModeManaged only reconciles the declared config vars, and
+	// leaves vars set outside of Terraform alone.
+	configVarsModeManaged = "managed"
+
+	// configVarsModeStrict also removes the vars that are not declared, other
+	// than those set by add-ons.
+	configVarsModeStrict = "strict"
+)
+
 func resourceHerokuApp() *schema.Resource {
 	return &schema.Resource{
 		Create: switchHerokuAppCreate,
 		Read:   resourceHerokuAppRead,
 		Update: resourceHerokuAppUpdate,
 		Delete: resourceHerokuAppDelete,
-		Exists: resourceHerokuAppExists,
 
 		Importer: &schema.ResourceImporter{
 			State: resourceHerokuAppImport,
@@ -113,6 +143,20 @@ func resourceHerokuApp() *schema.Resource {
 				Sensitive: true,
 			},
 
+			"config_vars_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      configVarsModeManaged,
+				ValidateFunc: validation.StringInSlice([]string{configVarsModeManaged, configVarsModeStrict}, false),
+			},
+
+			"unmanaged_config_var_names": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"all_config_vars": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -138,16 +182,41 @@ func resourceHerokuApp() *schema.Resource {
 				Computed: true,
 			},
 
+			"preboot": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"router_2_0": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
 			"heroku_hostname": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"internal_hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"generation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cedar", "fir"}, false),
+			},
+
 			"organization": {
 				Type:     schema.TypeList,
 				MinItems: 0,
 				MaxItems: 1,
 				Optional: true,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -178,9 +247,110 @@ func resourceHerokuApp() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: resourceHerokuAppCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
+// resourceHerokuAppCustomizeDiff plans the generation of a new app from the space
+// it is created in, and rejects options that Fir generation apps do not support.
+// A configured generation must match the space's, as apps cannot change generation.
+// It also rejects config vars that are declared twice, or that are set by add-ons.
+func resourceHerokuAppCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	configVars := diff.Get("config_vars").(map[string]interface{})
+	sensitiveConfigVars := diff.Get("sensitive_config_vars").(map[string]interface{})
+	if dupes := duplicateConfigVarNames(configVars, sensitiveConfigVars); len(dupes) > 0 {
+		return fmt.Errorf("config vars %s are declared in both config_vars and sensitive_config_vars. "+
+			"Declare each var in only one of them", strings.Join(dupes, ", "))
+	}
+
+	if err := validateAppFeatureAttributes(ctx, diff, v.(*Config).Api); err != nil {
+		return err
+	}
+
+	if diff.Id() != "" {
+		if diff.HasChange("generation") && diff.NewValueKnown("generation") {
+			o, n := diff.GetChange("generation")
+			if o.(string) != "" {
+				return fmt.Errorf("app %s is a %s generation app and cannot be changed to %s, "+
+					"as apps cannot migrate between generations", diff.Id(), o, n)
+			}
+		}
+
+		// Only newly declared vars are checked, so that changing the value of a
+		// declared var does not read the app's add-on attachments.
+		if added := addedConfigVarNames(diff); len(added) > 0 {
+			addonVars, err := addonConfigVarNames(ctx, v.(*Config), diff.Id())
+			if err != nil {
+				// Any real problem will surface at apply.
+				log.Printf("[DEBUG] Skipping add-on config var validation for app %s: %s", diff.Id(), err)
+			} else if names := addonOwnedConfigVarNames(added, addonVars); len(names) > 0 {
+				return fmt.Errorf("config vars %s are set by add-ons of app %s and cannot be declared. "+
+					"Add-on vars are managed with heroku_addon_attachment", strings.Join(names, ", "), diff.Id())
+			}
+		}
+
+		// In strict mode, plan the removal of the vars that are not declared.
+		if diff.Get("config_vars_mode").(string) == configVarsModeStrict &&
+			diff.Get("unmanaged_config_var_names").(*schema.Set).Len() > 0 {
+			return diff.SetNew("unmanaged_config_var_names", []string{})
+		}
+		return nil
+	}
+
+	if !diff.NewValueKnown("space") {
+		return nil
+	}
+
+	// The configured generation, if any, is checked against the space's, which
+	// it is otherwise planned from.
+	generation := diff.Get("generation").(string)
+	space := diff.Get("space").(string)
+	if space == "" {
+		if generation == "fir" {
+			return fmt.Errorf("Fir generation apps must be created in a Fir generation space, but no space is set")
+		}
+	} else {
+		spaceGeneration, err := retrieveSpaceGeneration(space, v.(*Config).Api)
+		if err != nil {
+			// The space may not exist yet. Any real problem will surface at apply.
+			log.Printf("[DEBUG] Skipping generation validation for space %s: %s", space, err)
+		} else {
+			if generation != "" && generation != spaceGeneration {
+				return fmt.Errorf("generation is %s, but apps in space %s are of the %s generation", generation, space, spaceGeneration)
+			}
+			generation = spaceGeneration
+		}
+	}
+
+	if generation == "fir" {
+		if v, ok := diff.GetOk("stack"); ok && v.(string) != "" {
+			return fmt.Errorf("stack cannot be set for apps in space %s, which is a Fir generation space", space)
+		}
+		if v, ok := diff.GetOk("buildpacks"); ok && len(v.([]interface{})) > 0 {
+			return fmt.Errorf("buildpacks cannot be set for apps in space %s, which is a Fir generation space. "+
+				"Declare Cloud Native Buildpacks in the source's project.toml instead", space)
+		}
+		for _, f := range appFeatureAttributes {
+			if diff.Get(f.attribute).(bool) {
+				if err := validateAppFeature(f.feature, diff.Get("name").(string), generation, space, nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if generation == "" {
+		return nil
+	}
+	return diff.SetNew("generation", generation)
+}
+
 func resourceHerokuAppImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	client := m.(*Config).Api
 
@@ -206,6 +376,13 @@ func resourceHerokuAppImport(d *schema.ResourceData, m interface{}) ([]*schema.R
 }
 
 func switchHerokuAppCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	if team := meta.(*Config).DefaultTeam; team != "" && !isTeamApp(d) {
+		log.Printf("[DEBUG] Creating app in the provider's default team %s", team)
+		if err := d.Set("organization", []interface{}{map[string]interface{}{"name": team}}); err != nil {
+			return err
+		}
+	}
+
 	if isTeamApp(d) {
 		err = resourceHerokuTeamAppCreate(d, meta)
 	} else {
@@ -328,8 +505,10 @@ func resourceHerokuTeamAppCreate(d *schema.ResourceData, meta interface{}) error
 	return resourceHerokuAppRead(d, meta)
 }
 
-func setTeamDetails(d *schema.ResourceData, app *application) (err error) {
-	err = d.Set("space", app.App.Space)
+func setTeamDetails(d *schema.ResourceData, app *application) error {
+	if err := d.Set("space", app.App.Space); err != nil {
+		return setAttributeError("space", err)
+	}
 
 	teamAppDetails := map[string]interface{}{
 		"name":   app.App.TeamName,
@@ -338,23 +517,70 @@ func setTeamDetails(d *schema.ResourceData, app *application) (err error) {
 		// Platform API does not return this value so set state to resource schema value.
 		"personal": d.Get("personal"),
 	}
-	err = d.Set("organization", []interface{}{teamAppDetails})
+	if err := d.Set("organization", []interface{}{teamAppDetails}); err != nil {
+		return setAttributeError("organization", err)
+	}
 
-	return err
+	return nil
 }
 
-func setAppDetails(d *schema.ResourceData, app *application) (err error) {
-	err = d.Set("name", app.App.Name)
-	err = d.Set("stack", app.App.Stack)
-	err = d.Set("internal_routing", app.App.InternalRouting)
-	err = d.Set("region", app.App.Region)
-	err = d.Set("git_url", app.App.GitURL)
-	err = d.Set("web_url", app.App.WebURL)
-	err = d.Set("acm", app.App.Acm)
-	err = d.Set("uuid", app.App.ID)
-	err = d.Set("heroku_hostname", fmt.Sprintf("%s.herokuapp.com", app.App.Name))
+func setAppDetails(d *schema.ResourceData, app *application) error {
+	if err := d.Set("name", app.App.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("stack", app.App.Stack); err != nil {
+		return setAttributeError("stack", err)
+	}
+	if err := d.Set("internal_routing", app.App.InternalRouting); err != nil {
+		return setAttributeError("internal_routing", err)
+	}
+	if err := d.Set("region", app.App.Region); err != nil {
+		return setAttributeError("region", err)
+	}
+	if err := d.Set("git_url", app.App.GitURL); err != nil {
+		return setAttributeError("git_url", err)
+	}
+	if err := d.Set("web_url", app.App.WebURL); err != nil {
+		return setAttributeError("web_url", err)
+	}
+	if err := d.Set("acm", app.App.Acm); err != nil {
+		return setAttributeError("acm", err)
+	}
+	for _, f := range appFeatureAttributes {
+		if err := d.Set(f.attribute, app.Features[f.feature]); err != nil {
+			return setAttributeError(f.attribute, err)
+		}
+	}
+	if err := d.Set("uuid", app.App.ID); err != nil {
+		return setAttributeError("uuid", err)
+	}
+	if err := d.Set("generation", app.App.Generation); err != nil {
+		return setAttributeError("generation", err)
+	}
+	if err := d.Set("heroku_hostname", herokuHostname(app.App)); err != nil {
+		return setAttributeError("heroku_hostname", err)
+	}
 
-	return err
+	// Internally routed apps are only reachable at their hostname from within their space.
+	internalHostname := ""
+	if app.App.InternalRouting {
+		internalHostname = herokuHostname(app.App)
+	}
+	if err := d.Set("internal_hostname", internalHostname); err != nil {
+		return setAttributeError("internal_hostname", err)
+	}
+
+	return nil
+}
+
+// herokuHostname returns the default hostname of the app, as found in its web URL.
+// Newer apps have a unique suffix in their hostname, so it cannot be derived from the
+// app name alone.
+func herokuHostname(app *herokuApplication) string {
+	if u, err := url.Parse(app.WebURL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return fmt.Sprintf("%s.herokuapp.com", app.Name)
 }
 
 func resourceHerokuAppRead(d *schema.ResourceData, meta interface{}) error {
@@ -371,6 +597,9 @@ func resourceHerokuAppRead(d *schema.ResourceData, meta interface{}) error {
 	// The "all_config_vars" field has all of them.
 	app, err := resourceHerokuAppRetrieve(d.Id(), client)
 	if err != nil {
+		if removeNotFound(d, "App", err) {
+			return nil
+		}
 		return err
 	}
 
@@ -380,40 +609,61 @@ func resourceHerokuAppRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	for k, v := range app.Vars {
-		if _, ok := care[k]; ok {
-			configVars[k] = v
-		}
-	}
-
 	if s, ok := d.GetOk("sensitive_config_vars"); ok {
 		for k := range s.(map[string]interface{}) {
 			careSensitive[k] = struct{}{}
 		}
 	}
 
+	// A var declared in both maps is only read into sensitive_config_vars, so
+	// that its value is never exposed by config_vars.
+	for k, v := range app.Vars {
+		if _, ok := careSensitive[k]; ok {
+			continue
+		}
+		if _, ok := care[k]; ok {
+			configVars[k] = v
+		}
+	}
+
 	for k, v := range app.Vars {
 		if _, ok := careSensitive[k]; ok {
 			sensitiveConfigVars[k] = v
 		}
 	}
 
+	// In strict mode, track the names of the vars that are neither declared nor
+	// set by add-ons, so that their removal is planned.
+	unmanaged := []string{}
+	if d.Get("config_vars_mode").(string) == configVarsModeStrict {
+		addonVars, err := addonConfigVarNames(context.TODO(), config, d.Id())
+		if err != nil {
+			return err
+		}
+		unmanaged = unmanagedConfigVarNames(app.Vars, care, careSensitive, addonVars)
+	}
+	if err := d.Set("unmanaged_config_var_names", unmanaged); err != nil {
+		return setAttributeError("unmanaged_config_var_names", err)
+	}
+
 	log.Printf("[LOG] Setting config vars: %s", configVars)
 	if err := d.Set("config_vars", configVars); err != nil {
-		log.Printf("[WARN] Error setting config vars: %s", err)
+		return setAttributeError("config_vars", err)
 	}
 
-	log.Printf("[LOG] Setting sensitive config vars: %s", sensitiveConfigVars)
+	log.Printf("[LOG] Setting sensitive config vars: %s", sortedKeys(sensitiveConfigVars))
 	if err := d.Set("sensitive_config_vars", sensitiveConfigVars); err != nil {
-		log.Printf("[WARN] Error setting sensitive config vars: %s", err)
+		return setAttributeError("sensitive_config_vars", err)
 	}
 
 	// Set `all_config_vars` to empty map initially. Only set this attribute
 	// if set_app_all_config_vars_in_state is `true`.
-	d.Set("all_config_vars", map[string]string{})
+	if err := d.Set("all_config_vars", map[string]string{}); err != nil {
+		return setAttributeError("all_config_vars", err)
+	}
 	if config.SetAppAllConfigVarsInState {
 		if err := d.Set("all_config_vars", app.Vars); err != nil {
-			log.Printf("[WARN] Error setting all_config_vars: %s", err)
+			return setAttributeError("all_config_vars", err)
 		}
 	}
 
@@ -508,8 +758,23 @@ func resourceHerokuAppUpdate(d *schema.ResourceData, meta interface{}) error {
 	// Merge the vars
 	allOldVars = combineVars(oldConfigVars, oldSensitiveConfigVars)
 	allNewVars = combineVars(newConfigVars, newSensitiveConfigVars)
-	if err := updateConfigVars(d.Id(), client, allOldVars, allNewVars); err != nil {
-		return err
+
+	// In strict mode, unset the vars that are not declared, unless they have
+	// been declared since they were read.
+	if d.Get("config_vars_mode").(string) == configVarsModeStrict {
+		declared := combineVars(d.Get("config_vars").(map[string]interface{}), d.Get("sensitive_config_vars").(map[string]interface{}))
+		o, _ := d.GetChange("unmanaged_config_var_names")
+		for _, name := range o.(*schema.Set).List() {
+			if _, ok := declared[name.(string)]; !ok {
+				allOldVars[name.(string)] = ""
+			}
+		}
+	}
+	if err := updateConfigVars(d.Id(), client, allOldVars, allNewVars, d.Timeout(schema.TimeoutUpdate), schema.TimeoutUpdate); err != nil {
+		if !d.HasChange("config_vars") && d.HasChange("sensitive_config_vars") {
+			return attributePathError(cty.GetAttrPath("sensitive_config_vars"), err)
+		}
+		return attributePathError(cty.GetAttrPath("config_vars"), err)
 	}
 
 	// Make changes (if any) to the app's ACM.
@@ -520,6 +785,14 @@ func resourceHerokuAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	for _, f := range appFeatureAttributes {
+		if d.HasChange(f.attribute) {
+			if err := updateAppFeature(d.Id(), client, f.feature, d.Get(f.attribute).(bool)); err != nil {
+				return attributePathError(cty.GetAttrPath(f.attribute), err)
+			}
+		}
+	}
+
 	// Make changes (if any) to the app organization lock state.
 	if d.HasChange("organization") {
 		v := d.Get("organization").([]interface{})
@@ -538,11 +811,19 @@ func resourceHerokuAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	config := meta.(*Config)
+	time.Sleep(time.Duration(config.PostAppUpdateDelay) * time.Second)
+
 	return resourceHerokuAppRead(d, meta)
 }
 
 func resourceHerokuAppDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).Api
+	config := meta.(*Config)
+	client := config.Api
+
+	if config.PreventAppDestroy {
+		return fmt.Errorf("App %s cannot be destroyed, as the prevent_app_destroy feature of the provider is enabled", d.Get("name").(string))
+	}
 
 	log.Printf("[INFO] Deleting App: %s", d.Id())
 	_, err := client.AppDelete(context.TODO(), d.Id())
@@ -554,33 +835,13 @@ func resourceHerokuAppDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceHerokuAppExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	var err error
-	client := meta.(*Config).Api
-
-	if isTeamApp(d) {
-		_, err = client.TeamAppInfo(context.TODO(), d.Id())
-	} else {
-		_, err = client.AppInfo(context.TODO(), d.Id())
-	}
-	if err != nil {
-		// Make sure it's a missing app error.
-		if herr, ok := err.(*url.Error).Err.(heroku.Error); ok && herr.ID == "not_found" {
-			return false, nil
-		}
-		return false, err
-	}
-
-	return true, nil
-}
-
 func resourceHerokuAppRetrieve(id string, client *heroku.Service) (*application, error) {
 	app := application{Id: id, Client: client, IsTeamApp: false}
 
 	err := app.Update()
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving app: %s", err)
+		return nil, fmt.Errorf("error retrieving app: %w", err)
 	}
 
 	return &app, nil
@@ -588,7 +849,7 @@ func resourceHerokuAppRetrieve(id string, client *heroku.Service) (*application,
 
 // Updates the application to have the latest from remote
 func (a *application) Update() error {
-	app, appGetErr := a.Client.AppInfo(context.TODO(), a.Id)
+	app, appGetErr := retrieveAppWithGeneration(a.Id, a.Client)
 	if appGetErr != nil {
 		return appGetErr
 	}
@@ -601,6 +862,7 @@ func (a *application) Update() error {
 	a.App.WebURL = app.WebURL
 	a.App.Acm = app.Acm
 	a.App.ID = app.ID
+	a.App.Generation = app.GenerationName()
 
 	if app.InternalRouting != nil {
 		a.App.InternalRouting = *app.InternalRouting
@@ -638,6 +900,11 @@ func (a *application) Update() error {
 		errs = append(errs, err)
 	}
 
+	a.Features, err = retrieveAppFeatures(a.Id, a.Client)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		return &multierror.Error{Errors: errs}
 	}
@@ -645,6 +912,15 @@ func (a *application) Update() error {
 	return nil
 }
 
+// retrieveAppWithGeneration returns the app info along with the app's generation.
+func retrieveAppWithGeneration(id string, client *heroku.Service) (*appWithGeneration, error) {
+	var app appWithGeneration
+	if err := client.Get(context.TODO(), &app, fmt.Sprintf("/apps/%v", id), nil, nil); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
 func isTeamApp(d *schema.ResourceData) bool {
 	v := d.Get("organization").([]interface{})
 	return len(v) > 0 && v[0] != nil
@@ -673,6 +949,20 @@ func retrieveAcm(id string, client *heroku.Service) (bool, error) {
 	return result.Acm, nil
 }
 
+// retrieveAppFeatures returns whether each of the appFeatureAttributes is enabled.
+func retrieveAppFeatures(id string, client *heroku.Service) (map[string]bool, error) {
+	features, err := client.AppFeatureList(context.TODO(), id, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool)
+	for _, f := range features {
+		enabled[f.Name] = f.Enabled
+	}
+	return enabled, nil
+}
+
 func retrieveConfigVars(id string, client *heroku.Service) (map[string]string, error) {
 	vars, err := client.ConfigVarInfoForApp(context.TODO(), id)
 
@@ -691,7 +981,9 @@ func retrieveConfigVars(id string, client *heroku.Service) (map[string]string, e
 }
 
 // Updates the config vars for from an expanded configuration.
-func updateConfigVars(id string, client *heroku.Service, o, n map[string]interface{}) error {
+// updateConfigVars updates the app's config vars and waits up to timeout for the
+// resulting release, whose duration is set by the timeoutKey operation.
+func updateConfigVars(id string, client *heroku.Service, o, n map[string]interface{}, timeout time.Duration, timeoutKey string) error {
 	vars := make(map[string]*string)
 
 	for k := range o {
@@ -725,11 +1017,11 @@ func updateConfigVars(id string, client *heroku.Service, o, n map[string]interfa
 		Pending: []string{"pending"},
 		Target:  []string{"succeeded"},
 		Refresh: releaseStateRefreshFunc(client, id, releases[0].ID),
-		Timeout: 20 * time.Minute,
+		Timeout: timeout,
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for new release (%s) to succeed: %s", releases[0].ID, err)
+		return stateWaitError(fmt.Sprintf("new release (%s) to succeed", releases[0].ID), timeoutKey, err)
 	}
 
 	return nil
@@ -769,6 +1061,143 @@ func updateAcm(id string, client *heroku.Service, enabled bool) error {
 	return nil
 }
 
+func updateAppFeature(id string, client *heroku.Service, feature string, enabled bool) error {
+	log.Printf("[DEBUG] Setting feature %s of app %s to enabled: %t", feature, id, enabled)
+	if _, err := client.AppFeatureUpdate(context.TODO(), id, feature, heroku.AppFeatureUpdateOpts{Enabled: enabled}); err != nil {
+		return fmt.Errorf("Error updating feature %s of app %s: %s", feature, id, err)
+	}
+	return nil
+}
+
+// validateAppFeatureAttributes checks the prerequisites of the appFeatureAttributes
+// that are planned to be enabled. New apps have no formation yet, so only their
+// space is checked.
+func validateAppFeatureAttributes(ctx context.Context, diff *schema.ResourceDiff, client *heroku.Service) error {
+	for _, f := range appFeatureAttributes {
+		if !diff.HasChange(f.attribute) || !diff.Get(f.attribute).(bool) {
+			continue
+		}
+
+		if diff.Id() != "" {
+			if err := checkAppFeaturePrerequisites(ctx, client, diff.Id(), f.feature); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if space := diff.Get("space").(string); space != "" && diff.NewValueKnown("space") {
+			if err := validateAppFeature(f.feature, diff.Get("name").(string), "", space, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addonAttachmentConfigVars is an add-on attachment along with the names of the
+// config vars it sets, which the Platform API only includes when requested.
+type addonAttachmentConfigVars struct {
+	Name       string   `json:"name"`
+	ConfigVars []string `json:"config_vars"`
+}
+
+// addonConfigVarNames returns the names of the config vars that add-on
+// attachments of the app set, which are never unmanaged. The vars are those of
+// each attachment, so that the vars of add-ons billed to other apps, and of
+// attachments with custom names, e.g. HEROKU_POSTGRESQL_FOO_URL, are included.
+func addonConfigVarNames(ctx context.Context, config *Config, appID string) (map[string]struct{}, error) {
+	var attachments []addonAttachmentConfigVars
+	err := config.inclusionAPI("config_vars").Get(ctx, &attachments, fmt.Sprintf("/apps/%s/addon-attachments", appID),
+		nil, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving add-on attachments of app %s: %s", appID, err)
+	}
+
+	names := make(map[string]struct{})
+	for _, a := range attachments {
+		for _, name := range a.ConfigVars {
+			names[name] = struct{}{}
+		}
+	}
+	return names, nil
+}
+
+// addedConfigVarNames returns the vars that are declared in either config var map
+// and were not declared in either before.
+func addedConfigVarNames(diff *schema.ResourceDiff) map[string]interface{} {
+	oldVars, newVars := diff.GetChange("config_vars")
+	oldSensitive, newSensitive := diff.GetChange("sensitive_config_vars")
+	declared := combineVars(oldVars.(map[string]interface{}), oldSensitive.(map[string]interface{}))
+
+	added := make(map[string]interface{})
+	for k, v := range combineVars(newVars.(map[string]interface{}), newSensitive.(map[string]interface{})) {
+		if _, ok := declared[k]; !ok {
+			added[k] = v
+		}
+	}
+	return added
+}
+
+// unmanagedConfigVarNames returns the sorted names of the app's vars that are not
+// declared in either config var map and are not set by add-ons.
+func unmanagedConfigVarNames(vars map[string]string, declared, declaredSensitive, addonVars map[string]struct{}) []string {
+	names := []string{}
+	for k := range vars {
+		if _, ok := declared[k]; ok {
+			continue
+		}
+		if _, ok := declaredSensitive[k]; ok {
+			continue
+		}
+		if _, ok := addonVars[k]; ok {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// duplicateConfigVarNames returns the sorted names of the vars declared in both
+// config_vars and sensitive_config_vars.
+func duplicateConfigVarNames(configVars, sensitiveConfigVars map[string]interface{}) []string {
+	var dupes []string
+	for k := range configVars {
+		if _, ok := sensitiveConfigVars[k]; ok {
+			dupes = append(dupes, k)
+		}
+	}
+	sort.Strings(dupes)
+	return dupes
+}
+
+// addonOwnedConfigVarNames returns the sorted names of the declared vars that are
+// set by add-ons.
+func addonOwnedConfigVarNames(declared map[string]interface{}, addonVars map[string]struct{}) []string {
+	var names []string
+	for k := range declared {
+		if _, ok := addonVars[k]; ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedKeys returns the sorted names of vars, so that they can be logged without
+// their values.
+func sortedKeys(vars map[string]string) []string {
+	names := make([]string, 0, len(vars))
+	for k := range vars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// combineVars merges the config var maps. sensitiveConfigVars takes precedence,
+// although a var declared in both is rejected at plan time.
 func combineVars(configVars, sensitiveConfigVars map[string]interface{}) map[string]interface{} {
 	vars := make(map[string]interface{})
 
@@ -803,7 +1232,7 @@ func performAppPostCreateTasks(d *schema.ResourceData, client *heroku.Service) e
 
 	allConfigVars = combineVars(configVars, sensitiveConfigVars)
 
-	if err := updateConfigVars(d.Id(), client, nil, allConfigVars); err != nil {
+	if err := updateConfigVars(d.Id(), client, nil, allConfigVars, d.Timeout(schema.TimeoutCreate), schema.TimeoutCreate); err != nil {
 		return err
 	}
 
@@ -822,6 +1251,14 @@ func performAppPostCreateTasks(d *schema.ResourceData, client *heroku.Service) e
 		}
 	}
 
+	for _, f := range appFeatureAttributes {
+		if v, ok := d.GetOk(f.attribute); ok {
+			if err := updateAppFeature(d.Id(), client, f.feature, v.(bool)); err != nil {
+				return attributePathError(cty.GetAttrPath(f.attribute), err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -833,16 +1270,37 @@ func releaseStateRefreshFunc(client *heroku.Service, appID, releaseID string) re
 			return nil, "", err
 		}
 
+		// A release fails when its release phase command fails.
+		if release.Status == "failed" {
+			return nil, "", releaseFailure(release)
+		}
+
 		// The type conversion here can be dropped when the vendored version of
 		// heroku-go is updated.
 		return (*heroku.Release)(release), release.Status, nil
 	}
 }
 
+// releaseFailure describes a failed release, including the tail of its release phase output.
+func releaseFailure(release *heroku.Release) error {
+	msg := fmt.Sprintf("Release v%d (%s) failed", release.Version, release.ID)
+	if release.OutputStreamURL == nil {
+		return fmt.Errorf("%s", msg)
+	}
+
+	msg = fmt.Sprintf("%s, see release phase logs: curl \"%s\"", msg, *release.OutputStreamURL)
+	if output, err := fetchOutputStream(*release.OutputStreamURL); err == nil {
+		msg = fmt.Sprintf("%s\n\nLast lines of the release phase output:\n%s", msg, tailLines(output, buildLogTailLines))
+	} else {
+		log.Printf("[WARN] Unable to fetch release phase output of release %s: %s", release.ID, err)
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
 func checkIfDupeConfigVars(d *schema.ResourceData) error {
 	log.Printf("[INFO] Checking for duplicate config vars")
 
-	var dupes []string
 	var configVars, sensitiveConfigVars map[string]interface{}
 	if c, ok := d.GetOk("config_vars"); ok {
 		configVars = c.(map[string]interface{})
@@ -852,18 +1310,13 @@ func checkIfDupeConfigVars(d *schema.ResourceData) error {
 		sensitiveConfigVars = s.(map[string]interface{})
 	}
 
-	if configVars != nil && sensitiveConfigVars != nil {
-		for k := range configVars {
-			if _, ok := sensitiveConfigVars[k]; ok {
-				dupes = append(dupes, k)
-			}
-		}
-	}
+	dupes := duplicateConfigVarNames(configVars, sensitiveConfigVars)
 
 	log.Printf("[INFO] List of Duplicate config vars %s", dupes)
 
 	if len(dupes) > 0 {
-		return fmt.Errorf("[ERROR] Detected duplicate config vars: %s", dupes)
+		return attributePathError(cty.GetAttrPath("sensitive_config_vars").IndexString(dupes[0]),
+			fmt.Errorf("[ERROR] Detected duplicate config vars: %s", dupes))
 	}
 
 	return nil
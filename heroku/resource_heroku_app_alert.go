@@ -0,0 +1,282 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appAlert is a threshold alert of the metrics API, which is not part of the
+// Platform API and has no heroku-go client.
+type appAlert struct {
+	ID                   string   `json:"id,omitempty"`
+	Name                 string   `json:"name"`
+	ProcessType          string   `json:"process_type,omitempty"`
+	State                string   `json:"state"`
+	Value                float64  `json:"value"`
+	Period               int      `json:"period"`
+	ReminderFrequency    int      `json:"reminder_frequency"`
+	NotificationChannels []string `json:"notification_channels"`
+	Email                *string  `json:"email,omitempty"`
+}
+
+// appAlertThresholds are the allowed thresholds of each alert: milliseconds of
+// 95th percentile response time for latency, and a percentage of failed requests
+// for error_rate.
+var appAlertThresholds = map[string][2]float64{
+	"latency":    {50, 30000},
+	"error_rate": {0.1, 100},
+}
+
+func resourceHerokuAppAlert() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAppAlertCreate,
+		ReadContext:   resourceHerokuAppAlertRead,
+		UpdateContext: resourceHerokuAppAlertUpdate,
+		DeleteContext: resourceHerokuAppAlertDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuAppAlertImport,
+		},
+
+		CustomizeDiff: resourceHerokuAppAlertCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"process_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "web",
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"latency", "error_rate"}, false),
+			},
+
+			"threshold": {
+				Type:     schema.TypeFloat,
+				Required: true,
+			},
+
+			"sensitivity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntInSlice([]int{1, 5, 10}),
+			},
+
+			"reminder_frequency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntInSlice([]int{5, 60, 1440}),
+			},
+
+			"notification_channels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"app", "email"}, false),
+				},
+			},
+
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// resourceHerokuAppAlertCustomizeDiff checks the threshold against the range of the
+// alert, and that an email address is set for the email notification channel.
+func resourceHerokuAppAlertCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	name := diff.Get("name").(string)
+	if bounds, ok := appAlertThresholds[name]; ok && diff.NewValueKnown("threshold") {
+		if t := diff.Get("threshold").(float64); t < bounds[0] || t > bounds[1] {
+			return fmt.Errorf("threshold of %s alert must be between %g and %g, got %g", name, bounds[0], bounds[1], t)
+		}
+	}
+
+	if diff.NewValueKnown("notification_channels") && diff.NewValueKnown("email") {
+		channels := diff.Get("notification_channels").(*schema.Set)
+		if channels.Contains("email") && diff.Get("email").(string) == "" {
+			return fmt.Errorf("email must be set to use the email notification channel")
+		}
+	}
+
+	return nil
+}
+
+func resourceHerokuAppAlertImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	appID, rest, err := parseCompositeID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	processType, alertID, err := parseCompositeID(rest)
+	if err != nil {
+		return nil, fmt.Errorf("error: Import app alert ID must be <app ID>:<process type>:<alert ID>")
+	}
+
+	d.SetId(alertID)
+	if err := d.Set("app_id", appID); err != nil {
+		return nil, setAttributeError("app_id", err)
+	}
+	if err := d.Set("process_type", processType); err != nil {
+		return nil, setAttributeError("process_type", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuAppAlertCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).MetricsAPI
+
+	appID := d.Get("app_id").(string)
+	processType := d.Get("process_type").(string)
+	opts := appAlertFromResourceData(d)
+
+	log.Printf("[DEBUG] Creating %s alert of app %s %s dynos", opts.Name, appID, processType)
+	var alert appAlert
+	if err := client.Post(ctx, &alert, appAlertsPath(appID, processType), opts); err != nil {
+		return diag.Errorf("Error creating %s alert of app %s: %s", d.Get("name").(string), appID, err)
+	}
+
+	d.SetId(alert.ID)
+
+	log.Printf("[INFO] Created app alert ID: %s", d.Id())
+
+	return resourceHerokuAppAlertRead(ctx, d, meta)
+}
+
+func resourceHerokuAppAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).MetricsAPI
+
+	appID := d.Get("app_id").(string)
+	processType := d.Get("process_type").(string)
+
+	var alert appAlert
+	err := client.Get(ctx, &alert, fmt.Sprintf("%s/%s", appAlertsPath(appID, processType), d.Id()), nil, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] App alert %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error retrieving app alert %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("name", strings.ToLower(alert.Name)); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("threshold", alert.Value); err != nil {
+		return setAttributeDiagnostics("threshold", err)
+	}
+	if err := d.Set("sensitivity", alert.Period); err != nil {
+		return setAttributeDiagnostics("sensitivity", err)
+	}
+	if err := d.Set("reminder_frequency", alert.ReminderFrequency); err != nil {
+		return setAttributeDiagnostics("reminder_frequency", err)
+	}
+	if err := d.Set("notification_channels", alert.NotificationChannels); err != nil {
+		return setAttributeDiagnostics("notification_channels", err)
+	}
+	if err := d.Set("enabled", alert.State == "ACTIVE"); err != nil {
+		return setAttributeDiagnostics("enabled", err)
+	}
+	if alert.Email != nil {
+		if err := d.Set("email", *alert.Email); err != nil {
+			return setAttributeDiagnostics("email", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceHerokuAppAlertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).MetricsAPI
+
+	appID := d.Get("app_id").(string)
+	processType := d.Get("process_type").(string)
+	opts := appAlertFromResourceData(d)
+
+	log.Printf("[DEBUG] Updating app alert %s", d.Id())
+	var alert appAlert
+	if err := client.Patch(ctx, &alert, fmt.Sprintf("%s/%s", appAlertsPath(appID, processType), d.Id()), opts); err != nil {
+		return diag.Errorf("Error updating app alert %s: %s", d.Id(), err)
+	}
+
+	return resourceHerokuAppAlertRead(ctx, d, meta)
+}
+
+func resourceHerokuAppAlertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).MetricsAPI
+
+	appID := d.Get("app_id").(string)
+	processType := d.Get("process_type").(string)
+
+	log.Printf("[INFO] Deleting app alert: %s", d.Id())
+	if err := client.Delete(ctx, nil, fmt.Sprintf("%s/%s", appAlertsPath(appID, processType), d.Id())); err != nil {
+		return diag.Errorf("Error deleting app alert %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func appAlertsPath(appID, processType string) string {
+	return fmt.Sprintf("/apps/%s/formation/%s/alerts", appID, processType)
+}
+
+func appAlertFromResourceData(d *schema.ResourceData) appAlert {
+	alert := appAlert{
+		Name:                 strings.ToUpper(d.Get("name").(string)),
+		State:                "INACTIVE",
+		Value:                d.Get("threshold").(float64),
+		Period:               d.Get("sensitivity").(int),
+		ReminderFrequency:    d.Get("reminder_frequency").(int),
+		NotificationChannels: []string{"app"},
+	}
+
+	if d.Get("enabled").(bool) {
+		alert.State = "ACTIVE"
+	}
+
+	if v, ok := d.GetOk("notification_channels"); ok && v.(*schema.Set).Len() > 0 {
+		alert.NotificationChannels = nil
+		for _, c := range v.(*schema.Set).List() {
+			alert.NotificationChannels = append(alert.NotificationChannels, c.(string))
+		}
+	}
+
+	if v, ok := d.GetOk("email"); ok {
+		vs := v.(string)
+		alert.Email = &vs
+	}
+
+	return alert
+}
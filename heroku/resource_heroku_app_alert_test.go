@@ -0,0 +1,73 @@
+package heroku
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuAppAlert_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppAlertConfig(appName, "latency", 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("heroku_app_alert.foobar", "app_id", "heroku_app.foobar", "uuid"),
+					resource.TestCheckResourceAttr("heroku_app_alert.foobar", "process_type", "web"),
+					resource.TestCheckResourceAttr("heroku_app_alert.foobar", "threshold", "1000"),
+					resource.TestCheckResourceAttr("heroku_app_alert.foobar", "sensitivity", "5"),
+					resource.TestCheckResourceAttr("heroku_app_alert.foobar", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuAppAlertConfig(appName, "latency", 2000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_app_alert.foobar", "threshold", "2000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuAppAlert_InvalidThreshold(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuAppAlertConfig(appName, "error_rate", 500),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`threshold of error_rate alert must be between 0.1 and 100`),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppAlertConfig(appName, name string, threshold float64) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_app_alert" "foobar" {
+  app_id    = heroku_app.foobar.uuid
+  name      = "%s"
+  threshold = %g
+}
+`, appName, name, threshold)
+}
@@ -45,6 +45,18 @@ func resourceHerokuAppConfigAssociation() *schema.Resource {
 					Sensitive: true,
 				},
 			},
+
+			"clear_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -72,7 +84,7 @@ func resourceHerokuAppConfigAssociationCreate(d *schema.ResourceData, m interfac
 	combinedVars := mergeVars(vars, sensitiveVars)
 
 	// Update vars on the app
-	if err := updateVars(appId, client, nil, combinedVars); err != nil {
+	if err := updateVars(appId, client, nil, combinedVars, d.Timeout(schema.TimeoutCreate), schema.TimeoutCreate); err != nil {
 		return err
 	}
 
@@ -92,16 +104,19 @@ func resourceHerokuAppConfigAssociationRead(d *schema.ResourceData, m interface{
 
 	remoteAppVars, remoteAppGetErr := retrieveConfigVars(appId, client)
 	if remoteAppGetErr != nil {
+		if removeNotFound(d, "Config association of app", remoteAppGetErr) {
+			return nil
+		}
 		return remoteAppGetErr
 	}
 
 	vettedConfigVars, vettedSensitiveConfigVars := vetVarsForState(getVars(d), getSensitiveVars(d), remoteAppVars)
 
 	if err := d.Set("vars", vettedConfigVars); err != nil {
-		log.Printf("[WARN] Error setting app config vars: %s", err)
+		return setAttributeError("vars", err)
 	}
 	if err := d.Set("sensitive_vars", vettedSensitiveConfigVars); err != nil {
-		log.Printf("[WARN] Error setting app config sensitive vars: %s", err)
+		return setAttributeError("sensitive_vars", err)
 	}
 
 	return nil
@@ -120,7 +135,7 @@ func resourceHerokuAppConfigAssociationUpdate(d *schema.ResourceData, m interfac
 	allNewVars = mergeVars(newVars, newSensitiveVars)
 
 	// Update vars on the app
-	if err := updateVars(appId, client, allOldVars, allNewVars); err != nil {
+	if err := updateVars(appId, client, allOldVars, allNewVars, d.Timeout(schema.TimeoutUpdate), schema.TimeoutUpdate); err != nil {
 		return err
 	}
 
@@ -131,12 +146,19 @@ func resourceHerokuAppConfigAssociationDelete(d *schema.ResourceData, m interfac
 	client := m.(*Config).Api
 	appId := getAppId(d)
 
+	// Leave the vars on the app, e.g. when another configuration takes them over.
+	if !d.Get("clear_on_destroy").(bool) {
+		log.Printf("[INFO] Leaving config vars on app %s, as clear_on_destroy is false. Resource will be removed from state.", appId)
+		d.SetId("")
+		return nil
+	}
+
 	vars := getVars(d)
 	sensitiveVars := getSensitiveVars(d)
 	allVars := mergeVars(vars, sensitiveVars)
 
 	// Essentially execute an update to delete all the vars listed in the schema only
-	if err := updateVars(appId, client, allVars, nil); err != nil {
+	if err := updateVars(appId, client, allVars, nil, d.Timeout(schema.TimeoutDelete), schema.TimeoutDelete); err != nil {
 		return err
 	}
 
@@ -146,7 +168,7 @@ func resourceHerokuAppConfigAssociationDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func updateVars(id string, client *heroku.Service, o map[string]interface{}, n map[string]interface{}) error {
+func updateVars(id string, client *heroku.Service, o map[string]interface{}, n map[string]interface{}, timeout time.Duration, timeoutKey string) error {
 	vars := constructVars(o, n)
 
 	log.Printf("[INFO] Updating config vars: *%#v", vars)
@@ -171,11 +193,11 @@ func updateVars(id string, client *heroku.Service, o map[string]interface{}, n m
 		Pending: []string{"pending"},
 		Target:  []string{"succeeded"},
 		Refresh: releaseStateRefreshFunc(client, id, releases[0].ID),
-		Timeout: 20 * time.Minute,
+		Timeout: timeout,
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("error waiting for new release (%s) to succeed: %s", releases[0].ID, err)
+		return stateWaitError(fmt.Sprintf("new release (%s) to succeed", releases[0].ID), timeoutKey, err)
 	}
 
 	return nil
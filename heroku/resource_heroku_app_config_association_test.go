@@ -55,6 +55,58 @@ func TestAccHerokuAppConfigAssociation_Advanced(t *testing.T) {
 	})
 }
 
+func TestAccHerokuAppConfigAssociation_KeepOnDestroy(t *testing.T) {
+	org := testAccConfig.GetOrganizationOrSkip(t)
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppConfigAssociation_KeepOnDestroy(org, appName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_app_config_association.foobar-config", "clear_on_destroy", "false"),
+				),
+			},
+			{
+				// Destroy the association, which leaves its vars on the app.
+				Config: testAccCheckHerokuAppConfigAssociation_KeepOnDestroy(org, appName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAppConfigVarsExist("heroku_app.foobar", "RAILS_ENV"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppConfigVarsExist(n string, vars ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("app not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Config).Api
+
+		remoteConfig, err := client.ConfigVarInfoForApp(context.TODO(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, variable := range vars {
+			if _, ok := remoteConfig[variable]; !ok {
+				return fmt.Errorf("Config var %s doesn't exist on app %s", variable, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckHerokuAppConfigAssociationExists(n string, vars ...string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -139,3 +191,28 @@ resource "heroku_app_config_association" "foobar-config" {
     sensitive_vars = "${heroku_config.config.sensitive_vars}"
 }`, appName, org)
 }
+
+func testAccCheckHerokuAppConfigAssociation_KeepOnDestroy(org, appName string, withAssociation bool) string {
+	association := ""
+	if withAssociation {
+		association = `
+resource "heroku_app_config_association" "foobar-config" {
+    app_id = "${heroku_app.foobar.id}"
+    clear_on_destroy = false
+
+    vars = {
+       RAILS_ENV = "PROD"
+    }
+}`
+	}
+
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+  organization {
+    name = "%s"
+  }
+}
+%s`, appName, org, association)
+}
@@ -2,12 +2,24 @@ package heroku
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
+const (
+	// appFeaturePreboot starts the new web dynos of a release before stopping the
+	// old ones.
+	appFeaturePreboot = "preboot"
+
+	// appFeatureRouter2 routes the app's requests with Router 2.0.
+	appFeatureRouter2 = "http-routing-2-dot-0"
+)
+
 func resourceHerokuAppFeature() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceHerokuAppFeatureCreate,
@@ -19,6 +31,8 @@ func resourceHerokuAppFeature() *schema.Resource {
 			State: resourceHerokuAppFeatureImport,
 		},
 
+		CustomizeDiff: resourceHerokuAppFeatureCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"app": {
 				Type:     schema.TypeString,
@@ -60,12 +74,21 @@ func resourceHerokuAppFeatureRead(d *schema.ResourceData, meta interface{}) erro
 
 	feature, err := client.AppFeatureInfo(context.TODO(), app, id)
 	if err != nil {
+		if removeNotFound(d, "App feature", err) {
+			return nil
+		}
 		return err
 	}
 
-	d.Set("app", app)
-	d.Set("name", feature.Name)
-	d.Set("enabled", feature.Enabled)
+	if err := d.Set("app", app); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("name", feature.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("enabled", feature.Enabled); err != nil {
+		return setAttributeError("enabled", err)
+	}
 
 	return nil
 }
@@ -118,3 +141,78 @@ func resourceHerokuAppFeatureDelete(d *schema.ResourceData, meta interface{}) er
 	d.SetId("")
 	return nil
 }
+
+// resourceHerokuAppFeatureCustomizeDiff checks the prerequisites of the features
+// that are enabled, so that unsupported combinations fail at plan time.
+func resourceHerokuAppFeatureCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if !diff.Get("enabled").(bool) || (diff.Id() != "" && !diff.HasChange("enabled")) {
+		return nil
+	}
+	if !diff.NewValueKnown("app") || !diff.NewValueKnown("name") {
+		return nil
+	}
+
+	return checkAppFeaturePrerequisites(ctx, v.(*Config).Api, diff.Get("app").(string), diff.Get("name").(string))
+}
+
+// checkAppFeaturePrerequisites retrieves the app and its formation to validate
+// the prerequisites of a feature. Validation is skipped when the app cannot be
+// retrieved, as it may not exist yet.
+func checkAppFeaturePrerequisites(ctx context.Context, client *heroku.Service, appName, feature string) error {
+	if feature != appFeaturePreboot && feature != appFeatureRouter2 {
+		return nil
+	}
+
+	app, err := retrieveAppWithGeneration(appName, client)
+	if err != nil {
+		log.Printf("[DEBUG] Skipping %s feature validation for app %s: %s", feature, appName, err)
+		return nil
+	}
+
+	formations, err := client.FormationList(ctx, app.ID, &heroku.ListRange{Field: "type", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping %s feature formation validation for app %s: %s", feature, appName, err)
+	}
+
+	space := ""
+	if app.Space != nil {
+		space = app.Space.Name
+	}
+
+	return validateAppFeature(feature, appName, app.GenerationName(), space, formations)
+}
+
+// validateAppFeature checks that an app of the given generation, in the given
+// space if any, and with the given formations supports a feature:
+//   - preboot requires Cedar generation apps running professional dynos only.
+//   - Router 2.0 is only available to Cedar generation apps of the Common Runtime.
+func validateAppFeature(feature, appName, generation, space string, formations []heroku.Formation) error {
+	switch feature {
+	case appFeaturePreboot:
+		if generation == "fir" {
+			return fmt.Errorf("%s cannot be enabled for app %s, which is a Fir generation app", feature, appName)
+		}
+
+		var types []string
+		for _, f := range formations {
+			if f.Quantity > 0 && dynoTier(f.Size) != "professional" {
+				types = append(types, fmt.Sprintf("%s (%s)", f.Type, f.Size))
+			}
+		}
+		if len(types) > 0 {
+			sort.Strings(types)
+			return fmt.Errorf("%s cannot be enabled for app %s, as it requires professional dynos, but the app runs %s. "+
+				"Scale the app to standard or performance dynos first", feature, appName, strings.Join(types, ", "))
+		}
+	case appFeatureRouter2:
+		if generation == "fir" {
+			return fmt.Errorf("%s cannot be enabled for app %s, which is a Fir generation app", feature, appName)
+		}
+		if space != "" {
+			return fmt.Errorf("%s cannot be enabled for app %s, as it is only available in the Common Runtime, "+
+				"but the app is in space %s", feature, appName, space)
+		}
+	}
+
+	return nil
+}
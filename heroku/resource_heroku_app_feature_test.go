@@ -133,3 +133,35 @@ resource "heroku_app_feature" "runtime_metrics" {
 }
 `, appName)
 }
+
+func TestValidateAppFeature(t *testing.T) {
+	professional := []heroku.Formation{{Type: "web", Quantity: 2, Size: "Standard-1X"}}
+	basic := []heroku.Formation{
+		{Type: "web", Quantity: 1, Size: "Basic"},
+		{Type: "worker", Quantity: 0, Size: "Eco"},
+	}
+
+	cases := []struct {
+		name       string
+		feature    string
+		generation string
+		space      string
+		formations []heroku.Formation
+		valid      bool
+	}{
+		{"preboot", appFeaturePreboot, "cedar", "", professional, true},
+		{"preboot on basic dynos", appFeaturePreboot, "cedar", "", basic, false},
+		{"preboot on Fir", appFeaturePreboot, "fir", "", nil, false},
+		{"router", appFeatureRouter2, "cedar", "", basic, true},
+		{"router in space", appFeatureRouter2, "cedar", "some-space", nil, false},
+		{"router on Fir", appFeatureRouter2, "fir", "", nil, false},
+		{"other feature", "log-runtime-metrics", "fir", "some-space", basic, true},
+	}
+
+	for _, tc := range cases {
+		err := validateAppFeature(tc.feature, "some-app", tc.generation, tc.space, tc.formations)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: expected valid %t, got error %v", tc.name, tc.valid, err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// formationUpdate is an element of heroku.FormationBatchUpdateOpts.Updates.
+type formationUpdate = struct {
+	Quantity *int    `json:"quantity,omitempty" url:"quantity,omitempty,key"`
+	Size     *string `json:"size,omitempty" url:"size,omitempty,key"`
+	Type     string  `json:"type" url:"type,key"`
+}
+
+func resourceHerokuAppFormation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAppFormationCreate,
+		ReadContext:   resourceHerokuAppFormationRead,
+		UpdateContext: resourceHerokuAppFormationUpdate,
+		DeleteContext: resourceHerokuAppFormationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuAppFormationImport,
+		},
+
+		CustomizeDiff: resourceHerokuAppFormationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"process": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Set:      appFormationProcessHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"quantity": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"size": {
+							Type:      schema.TypeString,
+							Required:  true,
+							StateFunc: formatSize,
+						},
+					},
+				},
+			},
+
+			"wait_for_dynos_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"app_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+// appFormationProcessHash hashes a process ignoring the capitalization of its
+// size, which Heroku returns capitalized, e.g. "Standard-1X".
+func appFormationProcessHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s-%d-%s", m["type"].(string), m["quantity"].(int), strings.ToLower(m["size"].(string))))
+}
+
+// resourceHerokuAppFormationCustomizeDiff rejects process types declared twice, and
+// checks that the planned dyno sizes belong to the generation of the app and
+// respect the limits of their dyno tiers.
+func resourceHerokuAppFormationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if !diff.NewValueKnown("process") {
+		return nil
+	}
+
+	processes := expandAppFormationProcesses(diff.Get("process").(*schema.Set))
+	seen := make(map[string]struct{})
+	for _, p := range processes {
+		if _, ok := seen[p.Type]; ok {
+			return fmt.Errorf("process type %s is declared more than once", p.Type)
+		}
+		seen[p.Type] = struct{}{}
+
+		if err := validateFormationQuantity(p); err != nil {
+			return err
+		}
+	}
+
+	appName := diff.Get("app").(string)
+	if appName == "" || !diff.NewValueKnown("app") || !diff.HasChange("process") {
+		return nil
+	}
+
+	client := v.(*Config).Api
+	app, err := retrieveAppWithGeneration(appName, client)
+	if err != nil {
+		// The app may not exist yet. Any real problem will surface at apply.
+		log.Printf("[DEBUG] Skipping formation size validation for app %s: %s", appName, err)
+		return nil
+	}
+
+	for _, p := range processes {
+		if p.Size == nil || *p.Size == "" {
+			continue
+		}
+		if err := validateFormationSize(app, appName, *p.Size); err != nil {
+			return fmt.Errorf("process type %s: %s", p.Type, err)
+		}
+	}
+
+	formations, err := client.FormationList(ctx, app.ID, &heroku.ListRange{Field: "type", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping formation tier validation for app %s: %s", appName, err)
+		return nil
+	}
+
+	o, n := diff.GetChange("process")
+	return validateFormationTiers(appName, appFormationUpdates(o.(*schema.Set), n.(*schema.Set)), formations)
+}
+
+func resourceHerokuAppFormationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	appName := d.Get("app").(string)
+
+	app, err := client.AppInfo(ctx, appName)
+	if err != nil {
+		return diag.Errorf("Error retrieving app %s: %s", appName, err)
+	}
+
+	updates := expandAppFormationProcesses(d.Get("process").(*schema.Set))
+	formations, err := batchUpdateFormations(ctx, client, app.ID, updates)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(app.ID)
+
+	if d.Get("wait_for_dynos_up").(bool) {
+		for i := range formations {
+			if err := waitForFormationDynosUp(client, &formations[i], d.Timeout(schema.TimeoutCreate), schema.TimeoutCreate); err != nil {
+				return diag.FromErr(taintedCreateError(fmt.Sprintf("Formation of app %s", app.Name), err))
+			}
+		}
+	}
+
+	return resourceHerokuAppFormationRead(ctx, d, meta)
+}
+
+func resourceHerokuAppFormationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	formations, err := client.FormationList(ctx, d.Id(), &heroku.ListRange{Field: "type", Max: 1000})
+	if err != nil {
+		if removeNotFound(d, "Formation of app", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving formation of app %s: %s", d.Id(), err)
+	}
+
+	// Only the process types in state are managed, except when importing, which
+	// manages all of the app's process types.
+	managed := make(map[string]struct{})
+	for _, p := range expandAppFormationProcesses(d.Get("process").(*schema.Set)) {
+		managed[p.Type] = struct{}{}
+	}
+
+	var processes []interface{}
+	for _, f := range formations {
+		if _, ok := managed[f.Type]; !ok && len(managed) > 0 {
+			continue
+		}
+		processes = append(processes, map[string]interface{}{
+			"type":     f.Type,
+			"quantity": f.Quantity,
+			"size":     f.Size,
+		})
+		if err := d.Set("app_id", f.App.ID); err != nil {
+			return setAttributeDiagnostics("app_id", err)
+		}
+	}
+
+	if err := d.Set("process", processes); err != nil {
+		return setAttributeDiagnostics("process", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuAppFormationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	if d.HasChange("process") {
+		o, n := d.GetChange("process")
+		updates := appFormationUpdates(o.(*schema.Set), n.(*schema.Set))
+
+		formations, err := batchUpdateFormations(ctx, client, d.Id(), updates)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if d.Get("wait_for_dynos_up").(bool) {
+			for i := range formations {
+				if err := waitForFormationDynosUp(client, &formations[i], d.Timeout(schema.TimeoutUpdate), schema.TimeoutUpdate); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
+	}
+
+	return resourceHerokuAppFormationRead(ctx, d, meta)
+}
+
+// resourceHerokuAppFormationDelete only removes the formation from state, like
+// heroku_formation, as there's no DELETE endpoint for formations.
+func resourceHerokuAppFormationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for app formation resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+func resourceHerokuAppFormationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Config).Api
+
+	app, err := client.AppInfo(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(app.ID)
+	if err := d.Set("app", app.Name); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("wait_for_dynos_up", false); err != nil {
+		return nil, setAttributeError("wait_for_dynos_up", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandAppFormationProcesses returns the updates of the process blocks, sorted
+// by process type.
+func expandAppFormationProcesses(s *schema.Set) []formationUpdate {
+	var updates []formationUpdate
+	for _, v := range s.List() {
+		p := v.(map[string]interface{})
+		quantity := p["quantity"].(int)
+		size := p["size"].(string)
+		updates = append(updates, formationUpdate{
+			Type:     p["type"].(string),
+			Quantity: &quantity,
+			Size:     &size,
+		})
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].Type < updates[j].Type })
+	return updates
+}
+
+// appFormationUpdates returns the updates from the old to the new process blocks.
+// Process types that are no longer declared are scaled down, rather than left
+// running unmanaged.
+func appFormationUpdates(o, n *schema.Set) []formationUpdate {
+	updates := expandAppFormationProcesses(n)
+
+	declared := make(map[string]struct{})
+	for _, u := range updates {
+		declared[u.Type] = struct{}{}
+	}
+	for _, u := range expandAppFormationProcesses(o) {
+		if _, ok := declared[u.Type]; !ok {
+			zero := 0
+			updates = append(updates, formationUpdate{Type: u.Type, Quantity: &zero})
+		}
+	}
+
+	return updates
+}
+
+// batchUpdateFormations scales the process types of an app in a single request, so
+// that they are updated together.
+func batchUpdateFormations(ctx context.Context, client *heroku.Service, appID string, updates []formationUpdate) ([]heroku.Formation, error) {
+	var types []string
+	for _, u := range updates {
+		types = append(types, u.Type)
+	}
+	log.Printf("[DEBUG] Updating the %s process types of app %s", strings.Join(types, ", "), appID)
+	formations, err := client.FormationBatchUpdate(ctx, appID, heroku.FormationBatchUpdateOpts{Updates: updates})
+	if err != nil {
+		return nil, fmt.Errorf("Error updating the formation of app %s: %s", appID, err)
+	}
+	return formations, nil
+}
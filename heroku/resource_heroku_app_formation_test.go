@@ -0,0 +1,185 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func TestAccHerokuAppFormation_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	slugID := testAccConfig.GetSlugIDOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppFormationConfig_Basic(appName, slugID, "standard-1x", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAppFormationQuantity("heroku_app_formation.foobar", "web", 1),
+					resource.TestCheckResourceAttr(
+						"heroku_app_formation.foobar", "process.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuAppFormationConfig_Basic(appName, slugID, "standard-2x", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAppFormationQuantity("heroku_app_formation.foobar", "web", 2),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"heroku_app_formation.foobar", "process.*", map[string]string{
+							"type":     "web",
+							"quantity": "2",
+							"size":     "Standard-2X",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppFormationQuantity(n, processType string, quantity int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("App formation not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Config).Api
+
+		formation, err := client.FormationInfo(context.TODO(), rs.Primary.ID, processType)
+		if err != nil {
+			return err
+		}
+
+		if formation.Quantity != quantity {
+			return fmt.Errorf("%s quantity is not correct. Found: %d | Expected: %d", processType, formation.Quantity, quantity)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuAppFormationConfig_Basic(appName, slugID, dynoSize string, dynoQuant int) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+resource "heroku_app_release" "foobar-release" {
+	app = "${heroku_app.foobar.name}"
+	slug_id = "%s"
+}
+resource "heroku_app_formation" "foobar" {
+	app = "${heroku_app_release.foobar-release.app}"
+
+	process {
+		type = "web"
+		size = "%s"
+		quantity = %d
+	}
+}
+`, appName, slugID, dynoSize, dynoQuant)
+}
+
+func TestAppFormationUpdates(t *testing.T) {
+	process := func(processType string, quantity int, size string) interface{} {
+		return map[string]interface{}{"type": processType, "quantity": quantity, "size": size}
+	}
+	o := schema.NewSet(appFormationProcessHash, []interface{}{
+		process("web", 1, "Standard-1X"),
+		process("worker", 2, "Standard-1X"),
+	})
+	n := schema.NewSet(appFormationProcessHash, []interface{}{
+		process("web", 3, "standard-2x"),
+		process("clock", 1, "standard-1x"),
+	})
+
+	var actual []string
+	for _, u := range appFormationUpdates(o, n) {
+		size := ""
+		if u.Size != nil {
+			size = *u.Size
+		}
+		actual = append(actual, fmt.Sprintf("%s=%d:%s", u.Type, *u.Quantity, size))
+	}
+
+	expected := []string{"clock=1:standard-1x", "web=3:standard-2x", "worker=0:"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected updates %v, got %v", expected, actual)
+	}
+}
+
+func TestAppFormationProcessHash(t *testing.T) {
+	a := map[string]interface{}{"type": "web", "quantity": 2, "size": "standard-1x"}
+	b := map[string]interface{}{"type": "web", "quantity": 2, "size": "Standard-1X"}
+	if appFormationProcessHash(a) != appFormationProcessHash(b) {
+		t.Fatal("Expected the hash of a process to ignore the capitalization of its size")
+	}
+}
+
+func TestResourceHerokuAppFormationCreate(t *testing.T) {
+	var batch heroku.FormationBatchUpdateOpts
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/some-app":
+			w.Write([]byte(`{"id": "01234567-89ab-cdef-0123-456789abcdef", "name": "some-app"}`))
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/formation"):
+			if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+				t.Error(err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/formation"):
+			w.Write([]byte(`[
+				{"app": {"id": "01234567-89ab-cdef-0123-456789abcdef"}, "type": "web", "quantity": 2, "size": "Standard-1X"},
+				{"app": {"id": "01234567-89ab-cdef-0123-456789abcdef"}, "type": "release", "quantity": 0, "size": "Standard-1X"}
+			]`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := resourceHerokuAppFormation().TestResourceData()
+	d.Set("app", "some-app")
+	d.Set("process", []interface{}{
+		map[string]interface{}{"type": "web", "quantity": 2, "size": "standard-1x"},
+	})
+
+	if diags := resourceHerokuAppFormationCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if len(batch.Updates) != 1 || batch.Updates[0].Type != "web" || *batch.Updates[0].Quantity != 2 {
+		t.Fatalf("Unexpected batch update: %#v", batch)
+	}
+	if d.Id() != "01234567-89ab-cdef-0123-456789abcdef" {
+		t.Fatalf("Unexpected id: %s", d.Id())
+	}
+	// The release process type is not managed, as it's not declared.
+	if n := d.Get("process").(*schema.Set).Len(); n != 1 {
+		t.Fatalf("Expected 1 managed process type, got %d", n)
+	}
+}
@@ -0,0 +1,92 @@
+package heroku
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuAppLock() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAppLockSet,
+		ReadContext:   resourceHerokuAppLockRead,
+		UpdateContext: resourceHerokuAppLockSet,
+		DeleteContext: resourceHerokuAppLockDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceHerokuAppLockSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	app := d.Get("app").(string)
+	locked := d.Get("locked").(bool)
+
+	log.Printf("[DEBUG] Setting locked of team app %s to %t", app, locked)
+	teamApp, err := client.TeamAppUpdateLocked(ctx, app, heroku.TeamAppUpdateLockedOpts{Locked: locked})
+	if err != nil {
+		return diag.Errorf("Error setting locked of app %s, only team apps can be locked: %s", app, err)
+	}
+
+	d.SetId(teamApp.ID)
+
+	return resourceHerokuAppLockRead(ctx, d, meta)
+}
+
+func resourceHerokuAppLockRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	teamApp, err := client.TeamAppInfo(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "Team app", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving team app %s: %s", d.Id(), err)
+	}
+
+	// Keep the configured app name or ID, as either identifies the app.
+	if v := d.Get("app").(string); v != teamApp.ID && v != teamApp.Name {
+		if err := d.Set("app", teamApp.Name); err != nil {
+			return setAttributeDiagnostics("app", err)
+		}
+	}
+	if err := d.Set("locked", teamApp.Locked); err != nil {
+		return setAttributeDiagnostics("locked", err)
+	}
+
+	return nil
+}
+
+// resourceHerokuAppLockDelete unlocks the app.
+func resourceHerokuAppLockDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	log.Printf("[INFO] Unlocking team app %s", d.Id())
+	if _, err := client.TeamAppUpdateLocked(ctx, d.Id(), heroku.TeamAppUpdateLockedOpts{Locked: false}); err != nil {
+		return diag.Errorf("Error unlocking team app %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuAppLock_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppLockConfig(appName, org, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_app_lock.foobar", "locked", "true"),
+					resource.TestCheckResourceAttrPair("heroku_app_lock.foobar", "id", "heroku_app.foobar", "uuid"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuAppLockConfig(appName, org, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_app_lock.foobar", "locked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppLockConfig(appName, org string, locked bool) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+
+  organization {
+    name = "%s"
+  }
+}
+
+resource "heroku_app_lock" "foobar" {
+  app    = heroku_app.foobar.id
+  locked = %t
+}
+`, appName, org, locked)
+}
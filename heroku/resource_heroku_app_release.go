@@ -31,16 +31,52 @@ func resourceHerokuAppRelease() *schema.Resource {
 			},
 
 			"slug_id": { // An existing Heroku release cannot be updated so ForceNew is required
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"slug_id", "source_app"},
+			},
+
+			"source_app": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 			},
 
+			"source_release_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"source_app"},
+			},
+
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 			},
+
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"health_check": healthCheckSchema(),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -58,6 +94,15 @@ func resourceHerokuAppReleaseCreate(d *schema.ResourceData, meta interface{}) er
 		opts.Slug = vs
 	}
 
+	if v, ok := d.GetOk("source_app"); ok {
+		slugID, err := resolveSourceAppSlug(client, appName, v.(string), d.Get("source_release_id").(string))
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Slug Id from source app %s: %s", v.(string), slugID)
+		opts.Slug = slugID
+	}
+
 	if v, ok := d.GetOk("description"); ok {
 		vs := v.(string)
 		log.Printf("[DEBUG] description: %s", vs)
@@ -74,19 +119,24 @@ func resourceHerokuAppReleaseCreate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("[INFO] New release ID: %s", newRelease.ID)
 	log.Printf("[INFO] Begin Checking if new Release %s is successful", newRelease.ID)
 
+	// Track the release in state before waiting, so that a release that fails or
+	// times out is tainted and replaced on the next apply.
+	d.SetId(newRelease.ID)
+
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"pending"},
 		Target:  []string{"succeeded"},
 		Refresh: releaseStateRefreshFunc(client, appName, newRelease.ID),
-		Timeout: 20 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("[ERROR] Error waiting for new release (%s) to succeed: %s", newRelease.ID, err)
+		return taintedCreateError(fmt.Sprintf("Release v%d", newRelease.Version), stateWaitError(fmt.Sprintf("new release (%s) to succeed", newRelease.ID), schema.TimeoutCreate, err))
 	}
 
-	// Set the ID after the release is successful
-	d.SetId(newRelease.ID)
+	if err := waitForHealthCheck(context.TODO(), client, appName, d.Get("health_check")); err != nil {
+		return err
+	}
 
 	return resourceHerokuAppReleaseRead(d, meta)
 }
@@ -99,16 +149,78 @@ func resourceHerokuAppReleaseRead(d *schema.ResourceData, meta interface{}) erro
 	appRelease, err := client.ReleaseInfo(context.TODO(), appName, d.Id())
 
 	if err != nil {
+		if removeNotFound(d, "App release", err) {
+			return nil
+		}
 		return fmt.Errorf("[ERROR] error retrieving app release: %s", err)
 	}
 
-	d.Set("app", appRelease.App.Name)
-	d.Set("slug_id", appRelease.Slug.ID)
-	d.Set("description", appRelease.Description)
+	if err := d.Set("app", appRelease.App.Name); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("slug_id", appRelease.Slug.ID); err != nil {
+		return setAttributeError("slug_id", err)
+	}
+	if err := d.Set("description", appRelease.Description); err != nil {
+		return setAttributeError("description", err)
+	}
+	if err := d.Set("version", appRelease.Version); err != nil {
+		return setAttributeError("version", err)
+	}
+	if err := d.Set("status", appRelease.Status); err != nil {
+		return setAttributeError("status", err)
+	}
+	if err := d.Set("created_at", appRelease.CreatedAt.Format(time.RFC3339)); err != nil {
+		return setAttributeError("created_at", err)
+	}
 
 	return nil
 }
 
+// resolveSourceAppSlug returns the slug of a release of the source app, or of its current
+// release when releaseID is empty, after checking that the slug's stack matches the
+// stack of the target app.
+func resolveSourceAppSlug(client *heroku.Service, appName, sourceApp, releaseID string) (string, error) {
+	var release *heroku.Release
+	if releaseID != "" {
+		r, err := client.ReleaseInfo(context.TODO(), sourceApp, releaseID)
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving release %s of source app %s: %s", releaseID, sourceApp, err)
+		}
+		release = r
+	} else {
+		releases, err := client.ReleaseList(context.TODO(), sourceApp, &heroku.ListRange{Descending: true, Field: "version", Max: 1})
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving releases of source app %s: %s", sourceApp, err)
+		}
+		if len(releases) == 0 || !releases[0].Current {
+			return "", fmt.Errorf("source app %s has no current release", sourceApp)
+		}
+		release = &releases[0]
+	}
+
+	if release.Slug == nil {
+		return "", fmt.Errorf("release v%d (%s) of source app %s has no slug to promote", release.Version, release.ID, sourceApp)
+	}
+
+	slug, err := client.SlugInfo(context.TODO(), sourceApp, release.Slug.ID)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving slug %s of source app %s: %s", release.Slug.ID, sourceApp, err)
+	}
+
+	app, err := client.AppInfo(context.TODO(), appName)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving app %s: %s", appName, err)
+	}
+
+	if slug.Stack.Name != app.Stack.Name {
+		return "", fmt.Errorf("slug %s of source app %s was built for the %s stack, which does not match the %s stack of app %s",
+			slug.ID, sourceApp, slug.Stack.Name, app.Stack.Name, appName)
+	}
+
+	return slug.ID, nil
+}
+
 // resourceHerokuAppReleaseUpdate will be a no-op method as there is no UPDATE endpoint for the release resource
 // in the Heroku Platform APIs.
 func resourceHerokuAppReleaseUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -151,9 +263,15 @@ func resourceHerokuAppReleaseImport(d *schema.ResourceData, meta interface{}) ([
 	}
 
 	d.SetId(appRelease.ID)
-	d.Set("app", appRelease.App.Name)
-	d.Set("slug_id", appRelease.Slug.ID)
-	d.Set("description", appRelease.Description)
+	if err := d.Set("app", appRelease.App.Name); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("slug_id", appRelease.Slug.ID); err != nil {
+		return nil, setAttributeError("slug_id", err)
+	}
+	if err := d.Set("description", appRelease.Description); err != nil {
+		return nil, setAttributeError("description", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -3,6 +3,7 @@ package heroku
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -30,6 +31,12 @@ func TestAccHerokuAppRelease_Basic(t *testing.T) {
 					testAccCheckHerokuAppReleaseExists("heroku_app_release.foobar-release", &appRelease),
 					resource.TestCheckResourceAttr(
 						"heroku_app_release.foobar-release", "slug_id", slugID),
+					resource.TestCheckResourceAttr(
+						"heroku_app_release.foobar-release", "status", "succeeded"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_app_release.foobar-release", "version"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_app_release.foobar-release", "created_at"),
 				),
 			},
 		},
@@ -65,6 +72,53 @@ func TestAccHerokuAppRelease_OrgBasic(t *testing.T) {
 	})
 }
 
+func TestAccHerokuAppRelease_SourceApp(t *testing.T) {
+	var appRelease heroku.Release
+
+	randString := acctest.RandString(10)
+	sourceAppName := fmt.Sprintf("tftest-src-%s", randString)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppRelease_SourceApp(sourceAppName, appName, "heroku-20"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAppReleaseExists("heroku_app_release.foobar-release", &appRelease),
+					resource.TestCheckResourceAttrPair(
+						"heroku_app_release.foobar-release", "slug_id",
+						"heroku_build.source", "slug_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuAppRelease_SourceAppStackMismatch(t *testing.T) {
+	randString := acctest.RandString(10)
+	sourceAppName := fmt.Sprintf("tftest-src-%s", randString)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuAppRelease_SourceApp(sourceAppName, appName, "heroku-18"),
+				ExpectError: regexp.MustCompile(`does not match the heroku-18 stack`),
+			},
+		},
+	})
+}
+
 func testAccCheckHerokuAppReleaseExists(n string, appRelease *heroku.Release) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -124,3 +178,29 @@ resource "heroku_app_release" "foobar-release" {
 }
 `, appName, org, slugId, desc)
 }
+
+func testAccCheckHerokuAppRelease_SourceApp(sourceAppName, appName, stack string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "source" {
+	name = "%s"
+	region = "us"
+	stack = "heroku-20"
+}
+resource "heroku_build" "source" {
+	app = heroku_app.source.id
+	source {
+		path = "test-fixtures/app"
+	}
+}
+resource "heroku_app" "foobar" {
+	name = "%s"
+	region = "us"
+	stack = "%s"
+}
+resource "heroku_app_release" "foobar-release" {
+	app = heroku_app.foobar.name
+	source_app = heroku_app.source.id
+	depends_on = [heroku_build.source]
+}
+`, sourceAppName, appName, stack)
+}
@@ -0,0 +1,448 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuAppSetup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAppSetupCreate,
+		ReadContext:   resourceHerokuAppSetupRead,
+		DeleteContext: resourceHerokuAppSetupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"checksum": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"path": {
+							Type:          schema.TypeString,
+							ConflictsWith: []string{"source.0.url"},
+							Optional:      true,
+							ForceNew:      true,
+						},
+
+						"url": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateSourceUrl,
+						},
+
+						"version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"app": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"organization": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"locked": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"personal": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"space": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"stack": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"buildpacks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"env": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"app_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"app_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"build_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"output_stream_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"failure_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"manifest_errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"postdeploy_exit_code": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"postdeploy_output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resolved_success_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			// App setups include a build, so they are allowed to take as long as builds.
+			Create: schema.DefaultTimeout(26 * time.Hour),
+		},
+	}
+}
+
+func resourceHerokuAppSetupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	opts := heroku.AppSetupCreateOpts{}
+
+	source := d.Get("source").([]interface{})[0].(map[string]interface{})
+	if v := source["version"].(string); v != "" {
+		opts.SourceBlob.Version = &v
+	}
+
+	if v := source["path"].(string); v != "" {
+		if source["checksum"].(string) != "" {
+			return diag.Errorf("source.checksum should be empty when source.path is set (checksum is auto-generated)")
+		}
+
+		getURL, checksum, err := uploadSourcePath(client, v, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		opts.SourceBlob.URL = &getURL
+		opts.SourceBlob.Checksum = &checksum
+	} else if v := source["url"].(string); v != "" {
+		opts.SourceBlob.URL = &v
+		if c := source["checksum"].(string); c != "" {
+			opts.SourceBlob.Checksum = &c
+		}
+	} else {
+		return diag.Errorf("App setup requires either source.path or source.url")
+	}
+
+	if v, ok := d.GetOk("app"); ok {
+		appArg := v.([]interface{})[0].(map[string]interface{})
+		opts.App = &struct {
+			Locked       *bool   `json:"locked,omitempty" url:"locked,omitempty,key"`
+			Name         *string `json:"name,omitempty" url:"name,omitempty,key"`
+			Organization *string `json:"organization,omitempty" url:"organization,omitempty,key"`
+			Personal     *bool   `json:"personal,omitempty" url:"personal,omitempty,key"`
+			Region       *string `json:"region,omitempty" url:"region,omitempty,key"`
+			Space        *string `json:"space,omitempty" url:"space,omitempty,key"`
+			Stack        *string `json:"stack,omitempty" url:"stack,omitempty,key"`
+		}{}
+
+		if v := appArg["name"].(string); v != "" {
+			opts.App.Name = &v
+		}
+		if v := appArg["organization"].(string); v != "" {
+			opts.App.Organization = &v
+		}
+		if v := appArg["region"].(string); v != "" {
+			opts.App.Region = &v
+		}
+		if v := appArg["space"].(string); v != "" {
+			opts.App.Space = &v
+		}
+		if v := appArg["stack"].(string); v != "" {
+			opts.App.Stack = &v
+		}
+		if v := appArg["locked"].(bool); v {
+			opts.App.Locked = &v
+		}
+		if v := appArg["personal"].(bool); v {
+			opts.App.Personal = &v
+		}
+	}
+
+	_, hasBuildpacks := d.GetOk("buildpacks")
+	_, hasEnv := d.GetOk("env")
+	if hasBuildpacks || hasEnv {
+		opts.Overrides = &struct {
+			Buildpacks []*struct {
+				URL *string `json:"url,omitempty" url:"url,omitempty,key"`
+			} `json:"buildpacks,omitempty" url:"buildpacks,omitempty,key"`
+			Env map[string]string `json:"env,omitempty" url:"env,omitempty,key"`
+		}{}
+
+		for _, b := range d.Get("buildpacks").([]interface{}) {
+			url := b.(string)
+			opts.Overrides.Buildpacks = append(opts.Overrides.Buildpacks, &struct {
+				URL *string `json:"url,omitempty" url:"url,omitempty,key"`
+			}{URL: &url})
+		}
+
+		if env := d.Get("env").(map[string]interface{}); len(env) > 0 {
+			opts.Overrides.Env = make(map[string]string)
+			for k, v := range env {
+				opts.Overrides.Env[k] = v.(string)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Creating app setup from %s", *opts.SourceBlob.URL)
+	setup, err := client.AppSetupCreate(ctx, opts)
+	if err != nil {
+		return diag.Errorf("Error creating app setup: %s", err)
+	}
+
+	// The app exists as soon as the setup is accepted, so track it in state
+	// even if the build or postdeploy script fail below, along with the app that
+	// destroying the tainted setup deletes.
+	d.SetId(setup.ID)
+	if err := setAppSetupState(d, setup); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Waiting for app setup (%s) to complete", setup.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"succeeded"},
+		Refresh: AppSetupStateRefreshFunc(client, setup.ID),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("App setup %s", setup.ID), stateWaitError(fmt.Sprintf("app setup (%s) to complete", setup.ID), schema.TimeoutCreate, err)))
+	}
+
+	log.Printf("[INFO] Created app setup ID: %s", d.Id())
+
+	return resourceHerokuAppSetupRead(ctx, d, meta)
+}
+
+func resourceHerokuAppSetupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	setup, err := client.AppSetupInfo(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "App setup", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving app setup: %s", err)
+	}
+
+	if err := setAppSetupState(d, setup); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceHerokuAppSetupDelete deletes the app created by the app setup, as the
+// app setup itself cannot be deleted.
+func resourceHerokuAppSetupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	appID := d.Get("app_id").(string)
+	if appID == "" {
+		log.Printf("[INFO] App setup %s has no app, so this is a no-op. Resource will be removed from state.", d.Id())
+		return nil
+	}
+
+	if config.PreventAppDestroy {
+		return diag.Errorf("App %s created by app setup %s cannot be destroyed, as the prevent_app_destroy feature of the provider is enabled",
+			d.Get("app_name").(string), d.Id())
+	}
+
+	log.Printf("[INFO] Deleting app %s created by app setup %s", appID, d.Id())
+	if _, err := client.AppDelete(ctx, appID); err != nil {
+		return diag.Errorf("Error deleting app created by app setup: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setAppSetupState(d *schema.ResourceData, setup *heroku.AppSetup) error {
+	if err := d.Set("app_id", setup.App.ID); err != nil {
+		return setAttributeError("app_id", err)
+	}
+	if err := d.Set("app_name", setup.App.Name); err != nil {
+		return setAttributeError("app_name", err)
+	}
+	if err := d.Set("status", setup.Status); err != nil {
+		return setAttributeError("status", err)
+	}
+	if err := d.Set("manifest_errors", setup.ManifestErrors); err != nil {
+		return setAttributeError("manifest_errors", err)
+	}
+
+	if setup.Build != nil {
+		if err := d.Set("build_id", setup.Build.ID); err != nil {
+			return setAttributeError("build_id", err)
+		}
+		if err := d.Set("output_stream_url", setup.Build.OutputStreamURL); err != nil {
+			return setAttributeError("output_stream_url", err)
+		}
+	}
+
+	if setup.FailureMessage != nil {
+		if err := d.Set("failure_message", *setup.FailureMessage); err != nil {
+			return setAttributeError("failure_message", err)
+		}
+	}
+
+	if setup.Postdeploy != nil {
+		if err := d.Set("postdeploy_exit_code", setup.Postdeploy.ExitCode); err != nil {
+			return setAttributeError("postdeploy_exit_code", err)
+		}
+		if err := d.Set("postdeploy_output", setup.Postdeploy.Output); err != nil {
+			return setAttributeError("postdeploy_output", err)
+		}
+	}
+
+	if setup.ResolvedSuccessURL != nil {
+		if err := d.Set("resolved_success_url", *setup.ResolvedSuccessURL); err != nil {
+			return setAttributeError("resolved_success_url", err)
+		}
+	}
+
+	return nil
+}
+
+// AppSetupStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch an AppSetup.
+func AppSetupStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		setup, err := client.AppSetupInfo(context.TODO(), id)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get app setup status: %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		if setup.Status == "failed" {
+			return nil, "", appSetupFailure(setup)
+		}
+
+		return setup, setup.Status, nil
+	}
+}
+
+// appSetupFailure describes why an app setup failed, including any manifest errors,
+// the build log location and postdeploy output.
+func appSetupFailure(setup *heroku.AppSetup) error {
+	msg := fmt.Sprintf("App setup failed (%s)", setup.ID)
+	if setup.FailureMessage != nil {
+		msg = fmt.Sprintf("%s: %s", msg, *setup.FailureMessage)
+	}
+
+	if len(setup.ManifestErrors) > 0 {
+		msg = fmt.Sprintf("%s\n\napp.json manifest errors:\n  %s", msg, strings.Join(setup.ManifestErrors, "\n  "))
+	}
+
+	if setup.Build != nil && setup.Build.Status == "failed" {
+		msg = fmt.Sprintf("%s\n\nBuild %s failed, see logs: curl \"%s\"", msg, setup.Build.ID, setup.Build.OutputStreamURL)
+		if output, err := fetchOutputStream(setup.Build.OutputStreamURL); err == nil {
+			msg = fmt.Sprintf("%s\n\nLast lines of the build output:\n%s", msg, tailLines(output, buildLogTailLines))
+		} else {
+			log.Printf("[WARN] Unable to fetch output of failed build %s: %s", setup.Build.ID, err)
+		}
+	}
+
+	if setup.Postdeploy != nil && setup.Postdeploy.ExitCode != 0 {
+		msg = fmt.Sprintf("%s\n\nPostdeploy script exited with code %d:\n%s", msg, setup.Postdeploy.ExitCode, setup.Postdeploy.Output)
+	}
+
+	return fmt.Errorf("%s", msg)
+}
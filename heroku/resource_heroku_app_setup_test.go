@@ -0,0 +1,169 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccHerokuAppSetup_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppSetupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppSetupConfig_basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_app_setup.foobar", "status", "succeeded"),
+					resource.TestCheckResourceAttr("heroku_app_setup.foobar", "app_name", appName),
+					resource.TestCheckResourceAttr("heroku_app_setup.foobar", "postdeploy_exit_code", "0"),
+					resource.TestCheckResourceAttrSet("heroku_app_setup.foobar", "app_id"),
+					resource.TestCheckResourceAttrSet("heroku_app_setup.foobar", "build_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuAppSetup_NoSource(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuAppSetupConfig_noSource(appName),
+				ExpectError: regexp.MustCompile(`App setup requires either`),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuAppSetupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).Api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_app_setup" {
+			continue
+		}
+
+		if _, err := client.AppInfo(context.TODO(), rs.Primary.Attributes["app_id"]); err == nil {
+			return fmt.Errorf("App created by app setup still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuAppSetupConfig_basic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app_setup" "foobar" {
+  app {
+    name   = "%s"
+    region = "us"
+  }
+
+  env = {
+    FIXTURE_MESSAGE = "hello from terraform"
+  }
+
+  source {
+    path = "test-fixtures/app-setup"
+  }
+}`, appName)
+}
+
+func testAccCheckHerokuAppSetupConfig_noSource(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app_setup" "foobar" {
+  app {
+    name   = "%s"
+    region = "us"
+  }
+
+  source {
+    version = "v0"
+  }
+}`, appName)
+}
+
+func TestResourceHerokuAppSetupDelete_PreventAppDestroy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PreventAppDestroy = true
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuAppSetup().Schema, nil)
+	d.SetId("01234567-89ab-cdef-0123-456789abcdef")
+	d.Set("app_id", "11234567-89ab-cdef-0123-456789abcdef")
+	d.Set("app_name", "foo")
+
+	diags := resourceHerokuAppSetupDelete(context.Background(), d, config)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "prevent_app_destroy") {
+		t.Fatalf("got %#v, want an error about prevent_app_destroy", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the app setup to remain in state")
+	}
+}
+
+func TestResourceHerokuAppSetupCreate_FailedSetupKeepsApp(t *testing.T) {
+	setupID := "01234567-89ab-cdef-0123-456789abcdef"
+	appID := "11234567-89ab-cdef-0123-456789abcdef"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/app-setups":
+			fmt.Fprintf(w, `{"id":%q,"status":"pending","app":{"id":%q,"name":"foo"}}`, setupID, appID)
+		case r.Method == "GET" && r.URL.Path == "/app-setups/"+setupID:
+			fmt.Fprintf(w, `{"id":%q,"status":"failed","failure_message":"build failed","app":{"id":%q,"name":"foo"}}`, setupID, appID)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuAppSetup().Schema, map[string]interface{}{
+		"source": []interface{}{
+			map[string]interface{}{"url": "https://example.com/source.tgz"},
+		},
+	})
+
+	diags := resourceHerokuAppSetupCreate(context.Background(), d, config)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "build failed") {
+		t.Fatalf("got %#v, want an error about the failed setup", diags)
+	}
+	if d.Id() != setupID {
+		t.Fatalf("got ID %q, want the tainted setup %s in state", d.Id(), setupID)
+	}
+	if d.Get("app_id").(string) != appID || d.Get("app_name").(string) != "foo" {
+		t.Fatalf("got app %q (%q), want the app %s of the setup in state", d.Get("app_name"), d.Get("app_id"), appID)
+	}
+}
@@ -4,14 +4,364 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
+func TestUnmanagedConfigVarNames(t *testing.T) {
+	vars := map[string]string{
+		"RAILS_ENV":    "production",
+		"SECRET_KEY":   "secret",
+		"DATABASE_URL": "postgres://example.com/db",
+		"LEGACY_FLAG":  "1",
+		"DEBUG":        "true",
+	}
+	declared := map[string]struct{}{"RAILS_ENV": {}}
+	declaredSensitive := map[string]struct{}{"SECRET_KEY": {}}
+	addonVars := map[string]struct{}{"DATABASE_URL": {}}
+
+	names := unmanagedConfigVarNames(vars, declared, declaredSensitive, addonVars)
+	if expected := []string{"DEBUG", "LEGACY_FLAG"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
+
+func TestAddonConfigVarNames(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apps/some-app/addon-attachments" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		if inclusion := r.Header.Get("Accept-Inclusion"); inclusion != "config_vars" {
+			t.Errorf("Expected the config vars of the attachments to be requested, got %q", inclusion)
+			http.Error(w, "missing inclusion", http.StatusBadRequest)
+			return
+		}
+		// The second attachment is of an add-on billed to another app, under a
+		// custom name.
+		w.Write([]byte(`[
+			{"name": "DATABASE", "app": {"name": "some-app"}, "addon": {"app": {"name": "some-app"}}, "config_vars": ["DATABASE_URL"]},
+			{"name": "HEROKU_POSTGRESQL_FOO", "app": {"name": "some-app"}, "addon": {"app": {"name": "other-app"}}, "config_vars": ["HEROKU_POSTGRESQL_FOO_URL"]}
+		]`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := addonConfigVarNames(context.Background(), config, "some-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]struct{}{"DATABASE_URL": {}, "HEROKU_POSTGRESQL_FOO_URL": {}}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected add-on config vars %v, got %v", expected, names)
+	}
+}
+
+func TestResourceHerokuApp_GenerationDiff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spaces/fir-space":
+			w.Write([]byte(`{"name":"fir-space","generation":{"name":"fir"}}`))
+		case "/spaces/cedar-space":
+			w.Write([]byte(`{"name":"cedar-space","generation":{"name":"cedar"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"id":"not_found","message":"Not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	meta := NewConfig()
+	meta.URL = srv.URL
+	if err := meta.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name               string
+		raw                map[string]interface{}
+		expectedGeneration string
+		expectedErr        string
+	}{
+		{"from space", map[string]interface{}{"space": "fir-space"}, "fir", ""},
+		{"matching space", map[string]interface{}{"space": "cedar-space", "generation": "cedar"}, "cedar", ""},
+		{"mismatched space", map[string]interface{}{"space": "cedar-space", "generation": "fir"}, "", "apps in space cedar-space are of the cedar generation"},
+		{"fir without space", map[string]interface{}{"generation": "fir"}, "", "must be created in a Fir generation space"},
+		{"unknown space", map[string]interface{}{"space": "new-space", "generation": "fir", "stack": "heroku-20"}, "", "stack cannot be set"},
+		{"preboot on fir", map[string]interface{}{"space": "fir-space", "preboot": true}, "", "preboot cannot be enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":   "some-app",
+				"region": "us",
+			}
+			for k, v := range tt.raw {
+				raw[k] = v
+			}
+
+			diff, err := resourceHerokuApp().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), meta)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+			if actual := diff.Attributes["generation"].New; actual != tt.expectedGeneration {
+				t.Fatalf("Expected generation %q, got %q", tt.expectedGeneration, actual)
+			}
+		})
+	}
+
+	state := &terraform.InstanceState{
+		ID: "some-app",
+		Attributes: map[string]string{
+			"id":               "some-app",
+			"name":             "some-app",
+			"region":           "us",
+			"config_vars_mode": "managed",
+			"generation":       "cedar",
+		},
+	}
+	raw := map[string]interface{}{"name": "some-app", "region": "us", "generation": "fir"}
+	_, err := resourceHerokuApp().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err == nil || !strings.Contains(err.Error(), "cannot migrate between generations") {
+		t.Fatalf("Expected a generation change to be rejected, got %v", err)
+	}
+}
+
+func TestResourceHerokuApp_ConfigVarsDiff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"config_vars":["DATABASE_URL"]}]`))
+	}))
+	defer srv.Close()
+
+	meta := NewConfig()
+	meta.URL = srv.URL
+	if err := meta.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "some-app",
+		Attributes: map[string]string{
+			"id":               "some-app",
+			"name":             "some-app",
+			"region":           "us",
+			"config_vars_mode": "managed",
+		},
+	}
+
+	tests := []struct {
+		name                string
+		configVars          map[string]interface{}
+		sensitiveConfigVars map[string]interface{}
+		expectedErr         string
+	}{
+		{"separate vars", map[string]interface{}{"RAILS_ENV": "production"}, map[string]interface{}{"SECRET_KEY": "secret"}, ""},
+		{"duplicate var", map[string]interface{}{"SECRET_KEY": "public"}, map[string]interface{}{"SECRET_KEY": "secret"}, "config vars SECRET_KEY are declared in both"},
+		{"add-on var", map[string]interface{}{"DATABASE_URL": "postgres://example.com/db"}, nil, "config vars DATABASE_URL are set by add-ons"},
+		{"sensitive add-on var", nil, map[string]interface{}{"DATABASE_URL": "postgres://example.com/db"}, "config vars DATABASE_URL are set by add-ons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":   "some-app",
+				"region": "us",
+			}
+			if tt.configVars != nil {
+				raw["config_vars"] = tt.configVars
+			}
+			if tt.sensitiveConfigVars != nil {
+				raw["sensitive_config_vars"] = tt.sensitiveConfigVars
+			}
+
+			_, err := resourceHerokuApp().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("Expected an error containing %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestResourceHerokuApp_ConfigVarsDiffAttachments(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"id":"unavailable","message":"Service unavailable"}`))
+	}))
+	defer srv.Close()
+
+	meta := NewConfig()
+	meta.URL = srv.URL
+	if err := meta.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "some-app",
+		Attributes: map[string]string{
+			"id":                    "some-app",
+			"name":                  "some-app",
+			"region":                "us",
+			"config_vars_mode":      "managed",
+			"config_vars.%":         "1",
+			"config_vars.RAILS_ENV": "development",
+		},
+	}
+
+	// Changing the value of a declared var does not read the attachments.
+	raw := map[string]interface{}{
+		"name":        "some-app",
+		"region":      "us",
+		"config_vars": map[string]interface{}{"RAILS_ENV": "production"},
+	}
+	if _, err := resourceHerokuApp().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if requests != 0 {
+		t.Fatalf("Expected no requests, got %d", requests)
+	}
+
+	// The plan does not fail when the attachments cannot be read.
+	raw["config_vars"] = map[string]interface{}{"RAILS_ENV": "production", "NEW_VAR": "1"}
+	if _, err := resourceHerokuApp().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if requests == 0 {
+		t.Fatal("Expected the attachments of the app to be read for a new var")
+	}
+}
+
+func TestResourceHerokuAppUpdate_PostAppUpdateDelay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /apps/some-app", "GET /apps/some-app":
+			w.Write([]byte(`{"id": "app-id", "name": "some-app"}`))
+		case "PATCH /apps/some-app/config-vars", "GET /apps/some-app/config-vars":
+			w.Write([]byte(`{}`))
+		case "GET /apps/some-app/releases":
+			w.Write([]byte(`[{"id": "release-id", "status": "succeeded"}]`))
+		case "GET /apps/some-app/releases/release-id":
+			w.Write([]byte(`{"id": "release-id", "status": "succeeded"}`))
+		case "GET /apps/some-app/buildpack-installations", "GET /apps/some-app/features":
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PostAppUpdateDelay = 1
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuApp().Schema, map[string]interface{}{
+		"name":   "some-app",
+		"region": "us",
+	})
+	d.SetId("some-app")
+
+	start := time.Now()
+	if err := resourceHerokuAppUpdate(d, config); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the app to be updated after a 1s delay, took %s", elapsed)
+	}
+}
+
+func TestReleaseStateRefreshFunc(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/some-app/releases/failed-release":
+			fmt.Fprintf(w, `{"id": "failed-release", "version": 7, "status": "failed", "output_stream_url": "%s/streams/release"}`, srvURL)
+		case "/apps/some-app/releases/succeeded-release":
+			w.Write([]byte(`{"id": "succeeded-release", "version": 8, "status": "succeeded"}`))
+		case "/streams/release":
+			w.Write([]byte("Running release command...\nrake aborted!\n"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := releaseStateRefreshFunc(config.Api, "some-app", "failed-release")()
+	if err == nil {
+		t.Fatal("Expected an error for the failed release")
+	}
+	for _, expected := range []string{
+		"Release v7 (failed-release) failed",
+		fmt.Sprintf(`curl "%s/streams/release"`, srv.URL),
+		"rake aborted!",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to contain %q, got %q", expected, err)
+		}
+	}
+
+	result, state, err := releaseStateRefreshFunc(config.Api, "some-app", "succeeded-release")()
+	if err != nil {
+		t.Fatalf("Unexpected error for the succeeded release: %s", err)
+	}
+	if state != "succeeded" {
+		t.Errorf("Expected state succeeded, got %q", state)
+	}
+	if release, ok := result.(*heroku.Release); !ok || release.ID != "succeeded-release" {
+		t.Errorf("Expected the succeeded release, got %#v", result)
+	}
+}
+
+func TestReleaseFailure_WithoutOutputStream(t *testing.T) {
+	err := releaseFailure(&heroku.Release{ID: "some-release", Version: 3})
+	if expected := "Release v3 (some-release) failed"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
 func TestAccHerokuApp_Basic(t *testing.T) {
 	var app heroku.App
 	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
@@ -31,6 +381,12 @@ func TestAccHerokuApp_Basic(t *testing.T) {
 						"heroku_app.foobar", "name", appName),
 					resource.TestCheckResourceAttrSet(
 						"heroku_app.foobar", "uuid"),
+					resource.TestCheckResourceAttr(
+						"heroku_app.foobar", "generation", "cedar"),
+					resource.TestMatchResourceAttr(
+						"heroku_app.foobar", "heroku_hostname", regexp.MustCompile(`\.herokuapp\.com$`)),
+					resource.TestCheckResourceAttr(
+						"heroku_app.foobar", "internal_hostname", ""),
 					resource.TestCheckResourceAttr(
 						"heroku_app.foobar", "config_vars.FOO", "bar"),
 					resource.TestCheckResourceAttr(
@@ -300,6 +656,35 @@ func TestAccHerokuApp_Organization(t *testing.T) {
 	})
 }
 
+func TestAccHerokuApp_DefaultTeam(t *testing.T) {
+	var app heroku.TeamApp
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := testAccConfig.GetOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuAppConfig_defaultTeam(appName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuAppExistsOrg("heroku_app.foobar", &app),
+					testAccCheckHerokuAppAttributesOrg(&app, appName, "", org, false),
+					resource.TestCheckResourceAttr("heroku_app.foobar", "organization.0.name", org),
+				),
+			},
+			{
+				// The app adopted by the default team has no diff.
+				Config:   testAccCheckHerokuAppConfig_defaultTeam(appName, org),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccHerokuApp_Space(t *testing.T) {
 	var app heroku.TeamApp
 	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
@@ -982,6 +1367,18 @@ resource "heroku_app" "foobar" {
 }`, appName, org, locked)
 }
 
+func testAccCheckHerokuAppConfig_defaultTeam(appName, org string) string {
+	return fmt.Sprintf(`
+provider "heroku" {
+  default_team = "%s"
+}
+
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}`, org, appName)
+}
+
 func testAccCheckHerokuAppConfig_DontSetConfigVars(appName, appStack string) string {
 	return fmt.Sprintf(`
 provider "heroku" {
@@ -0,0 +1,146 @@
+package heroku
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+const (
+	appTransferStateAccepted = "accepted"
+	appTransferStateDeclined = "declined"
+)
+
+func resourceHerokuAppTransferAccepter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuAppTransferAccepterCreate,
+		ReadContext:   resourceHerokuAppTransferAccepterRead,
+		DeleteContext: resourceHerokuAppTransferAccepterDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      appTransferStateAccepted,
+				ValidateFunc: validation.StringInSlice([]string{appTransferStateAccepted, appTransferStateDeclined}, false),
+			},
+
+			"app_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"owner_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"recipient_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuAppTransferAccepterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	app := d.Get("app").(string)
+	state := d.Get("state").(string)
+
+	// The sending side may be applied after this resource, from another workspace,
+	// so wait for the transfer to be created.
+	var transfer *heroku.AppTransfer
+	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		transfer, err = client.AppTransferInfo(ctx, app)
+		if err != nil {
+			if isNotFoundError(err) {
+				log.Printf("[DEBUG] Waiting for a transfer of app %s to be created", app)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			return diag.Errorf("Error waiting for a transfer of app %s to be created: timeout after %s; "+
+				"the wait can be extended with timeouts.%s", app, d.Timeout(schema.TimeoutCreate), schema.TimeoutCreate)
+		}
+		return diag.Errorf("Error retrieving the transfer of app %s: %s", app, err)
+	}
+
+	switch transfer.State {
+	case "pending":
+		log.Printf("[INFO] Setting the transfer of app %s from %s to %s", app, transfer.Owner.Email, state)
+		transfer, err = client.AppTransferUpdate(ctx, transfer.ID, heroku.AppTransferUpdateOpts{State: state})
+		if err != nil {
+			return diag.Errorf("Error setting the transfer of app %s to %s: %s", app, state, err)
+		}
+	case state:
+		log.Printf("[INFO] The transfer of app %s is already %s", app, state)
+	default:
+		return diag.Errorf("The transfer of app %s cannot be %s, as it is %s", app, state, transfer.State)
+	}
+
+	d.SetId(transfer.ID)
+
+	return setAppTransferAccepterAttributes(d, transfer)
+}
+
+func resourceHerokuAppTransferAccepterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	transfer, err := client.AppTransferInfo(ctx, d.Id())
+	if err != nil {
+		// Heroku removes transfers once they are accepted or declined, which
+		// does not undo them, so the resource stays in state.
+		if isNotFoundError(err) {
+			log.Printf("[DEBUG] The transfer %s of app %s is complete", d.Id(), d.Get("app").(string))
+			return nil
+		}
+		return diag.Errorf("Error retrieving the transfer of app %s: %s", d.Get("app").(string), err)
+	}
+
+	return setAppTransferAccepterAttributes(d, transfer)
+}
+
+// resourceHerokuAppTransferAccepterDelete only removes the transfer from state, as
+// an accepted or declined transfer cannot be undone.
+func resourceHerokuAppTransferAccepterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for app transfer accepter resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+func setAppTransferAccepterAttributes(d *schema.ResourceData, transfer *heroku.AppTransfer) diag.Diagnostics {
+	if err := d.Set("app_id", transfer.App.ID); err != nil {
+		return setAttributeDiagnostics("app_id", err)
+	}
+	if err := d.Set("owner_email", transfer.Owner.Email); err != nil {
+		return setAttributeDiagnostics("owner_email", err)
+	}
+	if err := d.Set("recipient_email", transfer.Recipient.Email); err != nil {
+		return setAttributeDiagnostics("recipient_email", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResourceHerokuAppTransferAccepterCreate(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  string
+		desired  string
+		updated  bool
+		hasError bool
+	}{
+		{"accept pending", "pending", "accepted", true, false},
+		{"decline pending", "pending", "declined", true, false},
+		{"already accepted", "accepted", "accepted", false, false},
+		{"already declined", "declined", "accepted", false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var updatedState string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				transfer := map[string]interface{}{
+					"id":        "01234567-89ab-cdef-0123-456789abcdef",
+					"app":       map[string]string{"id": "11234567-89ab-cdef-0123-456789abcdef", "name": "some-app"},
+					"owner":     map[string]string{"email": "owner@example.com"},
+					"recipient": map[string]string{"email": "recipient@example.com"},
+					"state":     tc.current,
+				}
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/account/app-transfers/some-app"):
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/account/app-transfers/01234567-89ab-cdef-0123-456789abcdef"):
+					var opts map[string]string
+					if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+						t.Error(err)
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					updatedState = opts["state"]
+					transfer["state"] = updatedState
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+					http.Error(w, "unexpected request", http.StatusNotFound)
+					return
+				}
+				json.NewEncoder(w).Encode(transfer)
+			}))
+			defer srv.Close()
+
+			config := NewConfig()
+			config.URL = srv.URL
+			if err := config.initializeAPI(); err != nil {
+				t.Fatal(err)
+			}
+
+			d := resourceHerokuAppTransferAccepter().TestResourceData()
+			d.Set("app", "some-app")
+			d.Set("state", tc.desired)
+
+			diags := resourceHerokuAppTransferAccepterCreate(context.Background(), d, config)
+			if diags.HasError() != tc.hasError {
+				t.Fatalf("Expected error %t, got %v", tc.hasError, diags)
+			}
+			if tc.updated && updatedState != tc.desired {
+				t.Fatalf("Expected the transfer to be %s, got %q", tc.desired, updatedState)
+			}
+			if !tc.updated && updatedState != "" {
+				t.Fatalf("Expected the transfer not to be updated, got %q", updatedState)
+			}
+			if !tc.hasError && d.Get("owner_email").(string) != "owner@example.com" {
+				t.Fatalf("Unexpected owner_email: %s", d.Get("owner_email"))
+			}
+		})
+	}
+}
@@ -43,18 +43,8 @@ func resourceHerokuAppWebhook() *schema.Resource {
 				Required: true,
 				MinItems: 1,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{
-						"api:addon-attachment",
-						"api:addon",
-						"api:app",
-						"api:build",
-						"api:collaborator",
-						"api:domain",
-						"api:dyno",
-						"api:formation",
-						"api:release",
-						"api:sni-endpoint"}, true),
+					Type:         schema.TypeString,
+					ValidateFunc: validateWebhookInclude,
 				},
 			},
 
@@ -113,12 +103,21 @@ func resourceHerokuAppWebhookRead(d *schema.ResourceData, meta interface{}) erro
 
 	webhook, err := client.AppWebhookInfo(context.TODO(), appId, d.Id())
 	if err != nil {
+		if removeNotFound(d, "App webhook", err) {
+			return nil
+		}
 		return err
 	}
 
-	d.Set("url", webhook.URL)
-	d.Set("level", webhook.Level)
-	d.Set("include", webhook.Include)
+	if err := d.Set("url", webhook.URL); err != nil {
+		return setAttributeError("url", err)
+	}
+	if err := d.Set("level", webhook.Level); err != nil {
+		return setAttributeError("level", err)
+	}
+	if err := d.Set("include", webhook.Include); err != nil {
+		return setAttributeError("include", err)
+	}
 
 	return nil
 }
@@ -205,10 +204,18 @@ func resourceHerokuAppWebhookImport(d *schema.ResourceData, meta interface{}) ([
 	}
 
 	d.SetId(webhook.ID)
-	d.Set("app_id", webhook.App.ID)
-	d.Set("url", webhook.URL)
-	d.Set("level", webhook.Level)
-	d.Set("include", webhook.Include)
+	if err := d.Set("app_id", webhook.App.ID); err != nil {
+		return nil, setAttributeError("app_id", err)
+	}
+	if err := d.Set("url", webhook.URL); err != nil {
+		return nil, setAttributeError("url", err)
+	}
+	if err := d.Set("level", webhook.Level); err != nil {
+		return nil, setAttributeError("level", err)
+	}
+	if err := d.Set("include", webhook.Include); err != nil {
+		return nil, setAttributeError("include", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
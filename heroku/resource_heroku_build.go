@@ -2,14 +2,12 @@ package heroku
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"net/http/httputil"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -17,13 +15,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
-	tarinator "github.com/verybluebot/tarinator-go"
 )
 
+// buildCreateOpts are the options of a new build, along with the Cloud Native
+// Buildpacks builder of Fir generation builds, which heroku.BuildCreateOpts lacks.
+type buildCreateOpts struct {
+	heroku.BuildCreateOpts
+	Builder *string `json:"builder,omitempty"`
+}
+
 func resourceHerokuBuild() *schema.Resource {
 	return &schema.Resource{
 		Create:        resourceHerokuBuildCreate,
 		Read:          resourceHerokuBuildRead,
+		Update:        resourceHerokuBuildUpdate,
 		Delete:        resourceHerokuBuildDelete,
 		CustomizeDiff: resourceHerokuBuildCustomizeDiff,
 
@@ -31,6 +36,9 @@ func resourceHerokuBuild() *schema.Resource {
 			State: resourceHerokuBuildImport,
 		},
 
+		SchemaVersion: 1,
+		MigrateState:  resourceHerokuBuildMigrate,
+
 		Schema: map[string]*schema.Schema{
 			"app": {
 				Type:     schema.TypeString,
@@ -48,16 +56,47 @@ func resourceHerokuBuild() *schema.Resource {
 				},
 			},
 
+			"builder": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"output_stream_url": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"build_log_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"release_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"release_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"release_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"release_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"release_created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"slug_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -84,6 +123,15 @@ func resourceHerokuBuild() *schema.Resource {
 							ForceNew:      true,
 						},
 
+						"exclude": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
 						"url": {
 							Type:         schema.TypeString,
 							Optional:     true,
@@ -102,7 +150,28 @@ func resourceHerokuBuild() *schema.Resource {
 
 			"stack": {
 				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"health_check": healthCheckSchema(),
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"images": {
+				Type:     schema.TypeMap,
 				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 
 			"status": {
@@ -137,6 +206,12 @@ func resourceHerokuBuild() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			// Builds are allowed to take a very long time,
+			// basically until the build dyno cycles (22-26 hours).
+			Create: schema.DefaultTimeout(26 * time.Hour),
+		},
 	}
 }
 
@@ -159,6 +234,10 @@ func resourceHerokuBuildImport(d *schema.ResourceData, meta interface{}) ([]*sch
 		return nil, setErr
 	}
 
+	if err := setBuildReleaseState(d, client, app, build); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -168,7 +247,12 @@ func resourceHerokuBuildCreate(d *schema.ResourceData, meta interface{}) error {
 	app := getAppName(d)
 
 	// Build up our creation options
-	opts := heroku.BuildCreateOpts{}
+	opts := buildCreateOpts{}
+
+	if v, ok := d.GetOk("builder"); ok {
+		builder := v.(string)
+		opts.Builder = &builder
+	}
 
 	if v, ok := d.GetOk("buildpacks"); ok {
 		var buildpacks []*struct {
@@ -206,41 +290,25 @@ func resourceHerokuBuildCreate(d *schema.ResourceData, meta interface{}) error {
 				opts.SourceBlob.Version = &s
 			}
 
+			excludes := sourceExcludes(sourceArg["exclude"])
+
 			if v, ok := sourceArg["path"]; ok && v != "" {
-				path := v.(string)
-				var tarballPath string
-				fileInfo, err := os.Stat(path)
+				getURL, checksum, err := uploadSourcePath(client, v.(string), excludes)
 				if err != nil {
-					return fmt.Errorf("Error stating build source path %s: %s", path, err)
-				}
-
-				if fileInfo.IsDir() {
-					// Generate tarball from the directory
-					tarballPath, err = generateSourceTarball(path)
-					if err != nil {
-						return fmt.Errorf("Error generating build source tarball %s: %s", path, err)
-					}
-					defer cleanupSourceFile(tarballPath)
-				} else {
-					// or simply use the path to the file
-					tarballPath = path
+					return err
 				}
+				opts.SourceBlob.URL = &getURL
+				opts.SourceBlob.Checksum = &checksum
 
-				// Checksum, create, & upload source archive
-				checksum, err := checksumSource(tarballPath)
+				localChecksum, err := hashSourcePath(v.(string), excludes)
 				if err != nil {
-					return fmt.Errorf("Error calculating checksum for build source %s: %s", tarballPath, err)
+					return err
 				}
-				newSource, err := client.SourceCreate(context.TODO())
-				if err != nil {
-					return fmt.Errorf("Error creating source for build: %s", err)
+				if err := d.Set("local_checksum", localChecksum); err != nil {
+					return setAttributeError("local_checksum", err)
 				}
-				err = uploadSource(tarballPath, "PUT", newSource.SourceBlob.PutURL)
-				if err != nil {
-					return fmt.Errorf("Error uploading source for build to %s: %s", newSource.SourceBlob.PutURL, err)
-				}
-				opts.SourceBlob.URL = &newSource.SourceBlob.GetURL
-				opts.SourceBlob.Checksum = &checksum
+			} else if len(excludes) > 0 {
+				return fmt.Errorf("source.exclude can only be set along with source.path")
 			} else if v, ok = sourceArg["url"]; ok && v != "" {
 				s := v.(string)
 				opts.SourceBlob.URL = &s
@@ -250,37 +318,75 @@ func resourceHerokuBuildCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	build, err := client.BuildCreate(context.TODO(), app, opts)
+	build := &heroku.Build{}
+	err := client.Post(context.TODO(), build, fmt.Sprintf("/apps/%s/builds", app), opts)
 	if err != nil {
 		return fmt.Errorf("Error creating build: %s opts %+v", err, opts)
 	}
 
+	// Track the Build in state before waiting, so that a Build that fails or
+	// times out is tainted and replaced on the next apply.
+	d.SetId(build.ID)
+
 	// Wait for the Build to be complete
 	log.Printf("[DEBUG] Waiting for Build (%s:%s) to complete", app, build.ID)
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"pending"},
 		Target:  []string{"succeeded"},
 		Refresh: BuildStateRefreshFunc(client, app, build.ID),
-		// Builds are allowed to take a very long time,
-		// basically until the build dyno cycles (22-26 hours).
-		Timeout: 26 * time.Hour,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return err
+		if buildErr, ok := err.(*buildFailedError); ok {
+			return taintedCreateError(fmt.Sprintf("Build %s", build.ID), describeBuildFailure(buildErr, d.Get("build_log_path").(string)))
+		}
+		return taintedCreateError(fmt.Sprintf("Build %s", build.ID), stateWaitError(fmt.Sprintf("build (%s) to complete", build.ID), schema.TimeoutCreate, err))
 	}
 
-	d.SetId(build.ID)
-
 	build, err = client.BuildInfo(context.TODO(), app, build.ID)
 	if err != nil {
 		return fmt.Errorf("Error refreshing the completed build: %s", err)
 	}
+
+	// A successful build may still fail in the release phase, so wait for its release.
+	if build.Release != nil {
+		log.Printf("[DEBUG] Waiting for Release (%s:%s) of Build %s to complete", app, build.Release.ID, build.ID)
+		releaseStateConf := &resource.StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"succeeded"},
+			Refresh: releaseStateRefreshFunc(client, app, build.Release.ID),
+			Timeout: d.Timeout(schema.TimeoutCreate),
+		}
+
+		if _, err := releaseStateConf.WaitForState(); err != nil {
+			return taintedCreateError(fmt.Sprintf("Build %s", build.ID), stateWaitError(fmt.Sprintf("release (%s) of build (%s) to succeed", build.Release.ID, build.ID), schema.TimeoutCreate, err))
+		}
+
+		if err := waitForHealthCheck(context.TODO(), client, app, d.Get("health_check")); err != nil {
+			return err
+		}
+	}
 	setErr := setBuildState(d, build, app)
 	if setErr != nil {
 		return setErr
 	}
 
+	if err := setBuildReleaseState(d, client, app, build); err != nil {
+		return err
+	}
+
+	// Container builds release one image per process type declared in heroku.yml.
+	if build.Stack == "container" {
+		images, err := readContainerImages(meta.(*Config), app)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("images", images); err != nil {
+			return setAttributeError("images", err)
+		}
+	}
+
 	log.Printf("[INFO] Created build ID: %s", d.Id())
 
 	return nil
@@ -292,6 +398,9 @@ func resourceHerokuBuildRead(d *schema.ResourceData, meta interface{}) error {
 	app := getAppName(d)
 	build, err := client.BuildInfo(context.TODO(), app, d.Id())
 	if err != nil {
+		if removeNotFound(d, "Build", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving build: %s", err)
 	}
 
@@ -300,9 +409,19 @@ func resourceHerokuBuildRead(d *schema.ResourceData, meta interface{}) error {
 		return setErr
 	}
 
+	if err := setBuildReleaseState(d, client, app, build); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// A no-op method as builds cannot be updated. Only attributes that do not
+// affect the build itself, such as build_log_path, can change in place.
+func resourceHerokuBuildUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceHerokuBuildRead(d, meta)
+}
+
 // A no-op method as there is no DELETE build in Heroku Platform API.
 func resourceHerokuBuildDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] There is no DELETE for build resource so this is a no-op. Build will be removed from state.")
@@ -310,112 +429,153 @@ func resourceHerokuBuildDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceHerokuBuildCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	// A new build is planned when the resource is created or replaced.
+	rebuild := diff.Id() == "" || diff.HasChange("source") || diff.HasChange("buildpacks") || diff.HasChange("builder") ||
+		diff.HasChange("stack") || diff.HasChange("triggers")
+
 	// Detect changes to the content of local source archive.
 	if v, ok := diff.GetOk("source"); ok {
 		vL := v.([]interface{})
 		source := vL[0].(map[string]interface{})
 		if vv, okok := source["path"]; okok && vv != "" {
 			path := vv.(string)
-			var tarballPath string
-			fileInfo, err := os.Stat(path)
+
+			// Hash the contents of the source path for the current "local_checksum", same function call as in resourceHerokuBuildCreate
+			realChecksum, err := hashSourcePath(path, sourceExcludes(source["exclude"]))
 			if err != nil {
-				return fmt.Errorf("Error stating build source path %s: %s", path, err)
+				return err
 			}
 
-			if fileInfo.IsDir() {
-				// To diff this generates a tarball of the source directory for calculating the current "local_checksum", same function call as in resourceHerokuBuildCreate
-				tarballPath, err = generateSourceTarball(path)
-				if err != nil {
-					return fmt.Errorf("Error generating build source tarball %s: %s", path, err)
+			// Diff the "local_checksum" SHA256
+			oldChecksum, newChecksum := diff.GetChange("local_checksum")
+			log.Printf("[DEBUG] Diffing source: old '%s', new '%s', real '%s'", oldChecksum, newChecksum, realChecksum)
+			if newChecksum != realChecksum {
+				if err := diff.SetNew("local_checksum", realChecksum); err != nil {
+					return fmt.Errorf("Error updating source archive checksum: %s", err)
 				}
-				defer cleanupSourceFile(tarballPath)
-			} else {
-				// or simply use the path to the file
-				tarballPath = path
-			}
-
-			// Calculate & diff the "local_checksum" SHA256
-			realChecksum, err := checksumSource(tarballPath)
-			if err == nil {
-				oldChecksum, newChecksum := diff.GetChange("local_checksum")
-				log.Printf("[DEBUG] Diffing source: old '%s', new '%s', real '%s'", oldChecksum, newChecksum, realChecksum)
-				if newChecksum != realChecksum {
-					if err := diff.SetNew("local_checksum", realChecksum); err != nil {
-						return fmt.Errorf("Error updating source archive checksum: %s", err)
-					}
-					if err := diff.ForceNew("local_checksum"); err != nil {
-						return fmt.Errorf("Error forcing new source resource: %s", err)
-					}
+				if err := diff.ForceNew("local_checksum"); err != nil {
+					return fmt.Errorf("Error forcing new source resource: %s", err)
 				}
+				rebuild = true
 			}
 		}
 	}
 
+	if rebuild {
+		return validateBuildStack(ctx, diff, v.(*Config).Api)
+	}
+
 	return nil
 }
 
-func uploadSource(filePath, httpMethod, httpUrl string) error {
-	method := strings.ToUpper(httpMethod)
-	log.Printf("[DEBUG] Uploading source '%s' to %s %s", filePath, method, httpUrl)
+// validateBuildStack checks that a planned build matches the stack and generation
+// of its app: the configured stack must be the app's stack, container builds must be
+// driven by a heroku.yml manifest rather than buildpacks, and only Fir builds, which
+// use Cloud Native Buildpacks, can set a builder.
+func validateBuildStack(ctx context.Context, diff *schema.ResourceDiff, client *heroku.Service) error {
+	appName := diff.Get("app").(string)
+	if appName == "" || !diff.NewValueKnown("app") {
+		return nil
+	}
 
-	file, err := os.Open(filePath)
+	app, err := retrieveAppWithGeneration(appName, client)
 	if err != nil {
-		return fmt.Errorf("Error opening source.path: %s", err)
+		// The app may not exist yet. Any real problem will surface at apply.
+		log.Printf("[DEBUG] Skipping build stack validation for app %s: %s", appName, err)
+		return nil
 	}
-	stat, err := file.Stat()
-	if err != nil {
-		return fmt.Errorf("Error stating source.path: %s", err)
+
+	if app.GenerationName() == "fir" {
+		if v, ok := diff.GetOk("stack"); ok && diff.NewValueKnown("stack") && v.(string) != "" {
+			return fmt.Errorf("build stack cannot be set for app %s, which is a Fir generation app. "+
+				"Set the Cloud Native Buildpacks builder with builder instead", appName)
+		}
+		return nil
 	}
-	defer file.Close()
 
-	httpClient := &http.Client{}
-	req, err := http.NewRequest(method, httpUrl, file)
-	if err != nil {
-		return fmt.Errorf("Error creating source upload request: %s", err)
+	if v, ok := diff.GetOk("builder"); ok && v.(string) != "" {
+		return fmt.Errorf("builder cannot be set for app %s, which is a %s generation app. "+
+			"Cloud Native Buildpacks builders are only used by Fir generation apps", appName, app.GenerationName())
 	}
-	req.ContentLength = stat.Size()
-	log.Printf("[DEBUG] Upload source request: %+v", req)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("Error uploading source: %s", err)
+
+	appStack := app.BuildStack.Name
+
+	if v, ok := diff.GetOk("stack"); ok && diff.NewValueKnown("stack") && v.(string) != appStack {
+		return fmt.Errorf("build stack %q does not match the stack %q of app %s", v.(string), appStack, appName)
 	}
 
-	b, err := httputil.DumpResponse(res, true)
-	if err == nil {
-		// generate debug output if it's available
-		log.Printf("[DEBUG] Source upload response: %s", b)
+	if appStack != "container" {
+		return nil
 	}
 
-	defer res.Body.Close()
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return fmt.Errorf("Unsuccessful HTTP response from source upload: %s", res.Status)
+	if v, ok := diff.GetOk("buildpacks"); ok && len(v.([]interface{})) > 0 {
+		return fmt.Errorf("buildpacks cannot be set for app %s, which uses the container stack. Declare images in heroku.yml instead", appName)
+	}
+
+	if v, ok := diff.GetOk("source"); ok {
+		source := v.([]interface{})[0].(map[string]interface{})
+		if path, ok := source["path"].(string); ok && path != "" {
+			if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+				if _, err := os.Stat(filepath.Join(path, "heroku.yml")); err != nil {
+					return fmt.Errorf("source.path %s must contain a heroku.yml manifest to build app %s, which uses the container stack", path, appName)
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
-func checksumSource(filePath string) (string, error) {
-	file, openErr := os.Open(filePath)
-	if openErr != nil {
-		return "", fmt.Errorf("Error opening source.path: %s", openErr)
+// readContainerImages returns the Docker image released to each process type of
+// a container stack app.
+func readContainerImages(config *Config, appName string) (map[string]string, error) {
+	var formations []containerFormation
+	err := config.variantAPI("docker-releases").Get(context.TODO(), &formations, fmt.Sprintf("/apps/%s/formation", appName), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving container images for app %s: %s", appName, err)
+	}
+
+	images := make(map[string]string)
+	for _, f := range formations {
+		if f.DockerImage != nil {
+			images[f.Type] = f.DockerImage.ID
+		}
 	}
 
-	hash := sha256.New()
-	if _, copyErr := io.Copy(hash, file); copyErr != nil {
-		return "", fmt.Errorf("Error reading source for checksum: %s", copyErr)
+	return images, nil
+}
+
+// setBuildReleaseState sets the metadata of the release created by the build.
+func setBuildReleaseState(d *schema.ResourceData, client *heroku.Service, app string, build *heroku.Build) error {
+	if build.Release == nil {
+		return nil
 	}
 
-	closeErr := file.Close()
-	if closeErr != nil {
-		return "", closeErr
+	release, err := client.ReleaseInfo(context.TODO(), app, build.Release.ID)
+	if err != nil {
+		return fmt.Errorf("Error retrieving release %s of build %s: %s", build.Release.ID, build.ID, err)
 	}
 
-	checksum := fmt.Sprintf("SHA256:%x", hash.Sum(nil))
-	return checksum, nil
+	if err := d.Set("release_version", release.Version); err != nil {
+		return setAttributeError("release_version", err)
+	}
+	if err := d.Set("release_description", release.Description); err != nil {
+		return setAttributeError("release_description", err)
+	}
+	if err := d.Set("release_status", release.Status); err != nil {
+		return setAttributeError("release_status", err)
+	}
+	if err := d.Set("release_created_at", release.CreatedAt.Format(time.RFC3339)); err != nil {
+		return setAttributeError("release_created_at", err)
+	}
+
+	return nil
 }
 
 func setBuildState(d *schema.ResourceData, build *heroku.Build, appName string) error {
-	d.Set("app", appName)
+	if err := d.Set("app", appName); err != nil {
+		return setAttributeError("app", err)
+	}
 
 	var buildpacks []interface{}
 	for _, buildpack := range build.Buildpacks {
@@ -423,17 +583,23 @@ func setBuildState(d *schema.ResourceData, build *heroku.Build, appName string)
 		buildpacks = append(buildpacks, url)
 	}
 	if err := d.Set("buildpacks", buildpacks); err != nil {
-		log.Printf("[WARN] Error setting buildpacks: %s", err)
+		return setAttributeError("buildpacks", err)
 	}
 
-	d.Set("output_stream_url", build.OutputStreamURL)
+	if err := d.Set("output_stream_url", build.OutputStreamURL); err != nil {
+		return setAttributeError("output_stream_url", err)
+	}
 
 	if build.Release != nil {
-		d.Set("release_id", build.Release.ID)
+		if err := d.Set("release_id", build.Release.ID); err != nil {
+			return setAttributeError("release_id", err)
+		}
 	}
 
 	if build.Slug != nil {
-		d.Set("slug_id", build.Slug.ID)
+		if err := d.Set("slug_id", build.Slug.ID); err != nil {
+			return setAttributeError("slug_id", err)
+		}
 	}
 
 	if v, ok := d.GetOk("source"); ok {
@@ -448,19 +614,21 @@ func setBuildState(d *schema.ResourceData, build *heroku.Build, appName string)
 			if v := build.SourceBlob.URL; v != "" {
 				source["url"] = v
 			}
-		} else {
-			d.Set("local_checksum", build.SourceBlob.Checksum)
 		}
 		if v := build.SourceBlob.Version; v != nil {
 			source["version"] = *v
 		}
 		if err := d.Set("source", []map[string]interface{}{source}); err != nil {
-			log.Printf("[WARN] Error setting source: %s", err)
+			return setAttributeError("source", err)
 		}
 	}
 
-	d.Set("stack", build.Stack)
-	d.Set("status", build.Status)
+	if err := d.Set("stack", build.Stack); err != nil {
+		return setAttributeError("stack", err)
+	}
+	if err := d.Set("status", build.Status); err != nil {
+		return setAttributeError("status", err)
+	}
 
 	user := []map[string]string{
 		{
@@ -469,23 +637,16 @@ func setBuildState(d *schema.ResourceData, build *heroku.Build, appName string)
 		},
 	}
 	if err := d.Set("user", user); err != nil {
-		log.Printf("[WARN] Error setting user: %s", err)
+		return setAttributeError("user", err)
 	}
 
-	d.Set("uuid", build.ID)
+	if err := d.Set("uuid", build.ID); err != nil {
+		return setAttributeError("uuid", err)
+	}
 
 	return nil
 }
 
-func cleanupSourceFile(filePath string) {
-	if filePath != "" {
-		err := os.Remove(filePath)
-		if err != nil {
-			log.Printf("[WARN] Error cleaning-up build source tarball: %s (%s)", err, filePath)
-		}
-	}
-}
-
 func validateSourceUrl(v interface{}, k string) (ws []string, errors []error) {
 	if v == nil {
 		return
@@ -503,19 +664,66 @@ func validateSourceUrl(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
-func generateSourceTarball(path string) (string, error) {
-	fi, err := ioutil.TempFile("", "terraform-heroku_build-source-*.tar.gz")
+// buildFailedError is returned by BuildStateRefreshFunc when a build fails.
+type buildFailedError struct {
+	App   string
+	Build *heroku.Build
+}
+
+func (e *buildFailedError) Error() string {
+	return fmt.Sprintf("Build failed (%s:%s) see logs: curl \"%s\"", e.App, e.Build.ID, e.Build.OutputStreamURL)
+}
+
+// buildLogTailLines is the number of lines of the build log included in build failure errors.
+const buildLogTailLines = 50
+
+// describeBuildFailure adds the tail of the build log to a failed build's error
+// and, when logPath is set, writes the full build log to that file.
+func describeBuildFailure(err *buildFailedError, logPath string) error {
+	output, fetchErr := fetchOutputStream(err.Build.OutputStreamURL)
+	if fetchErr != nil {
+		log.Printf("[WARN] Unable to fetch output of failed build %s: %s", err.Build.ID, fetchErr)
+		return err
+	}
+
+	if logPath != "" {
+		if writeErr := ioutil.WriteFile(logPath, []byte(output), 0644); writeErr != nil {
+			log.Printf("[WARN] Unable to write output of failed build %s to %s: %s", err.Build.ID, logPath, writeErr)
+		} else {
+			log.Printf("[INFO] Wrote output of failed build %s to %s", err.Build.ID, logPath)
+		}
+	}
+
+	return fmt.Errorf("%s\n\nLast lines of the build output:\n%s", err, tailLines(output, buildLogTailLines))
+}
+
+// fetchOutputStream returns the output of a completed build or release from its output stream URL.
+func fetchOutputStream(streamURL string) (string, error) {
+	res, err := http.Get(streamURL)
 	if err != nil {
 		return "", err
 	}
-	if err := fi.Close(); err != nil {
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", fmt.Errorf("Unsuccessful HTTP response from output stream: %s", res.Status)
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
 		return "", err
 	}
-	tf := fi.Name()
-	if err = tarinator.Tarinate([]string{path}, tf); err != nil {
-		err = fmt.Errorf("Error generating build source tarball %s of %s: %s", tf, path, err)
+
+	return string(b), nil
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
 	}
-	return tf, err
+	return strings.Join(lines, "\n")
 }
 
 // Returns a resource.StateRefreshFunc that is used to watch a Build.
@@ -533,7 +741,7 @@ func BuildStateRefreshFunc(client *heroku.Service, app, id string) resource.Stat
 		}
 
 		if build.Status == "failed" {
-			return nil, "", fmt.Errorf("Build failed (%s:%s) see logs: curl \"%s\"", app, id, build.OutputStreamURL)
+			return nil, "", &buildFailedError{App: app, Build: build}
 		}
 
 		return &build, build.Status, nil
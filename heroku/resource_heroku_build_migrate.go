@@ -0,0 +1,62 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func resourceHerokuBuildMigrate(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	log.Printf("[DEBUG] Current version of state file is: v%v", v)
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Heroku Build state v0; migrating to v1")
+		return migrateBuildLocalChecksumStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("unexpected schema version: %d", v)
+	}
+}
+
+// Migrate the local_checksum of directory sources from the checksum of a generated tarball,
+// which changed along with file timestamps, to the hash of the directory's contents.
+// The old checksum cannot tell whether the contents changed since the last build, so the
+// current contents are taken as those of the last build rather than starting a new one.
+func migrateBuildLocalChecksumStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty Heroku Build State; nothing to migrate.")
+		return is, nil
+	}
+
+	path := is.Attributes["source.0.path"]
+	if path == "" {
+		return is, nil
+	}
+
+	var excludes []string
+	count, _ := strconv.Atoi(is.Attributes["source.0.exclude.#"])
+	for i := 0; i < count; i++ {
+		excludes = append(excludes, is.Attributes[fmt.Sprintf("source.0.exclude.%d", i)])
+	}
+
+	// Tarball sources keep their archive checksum, and missing paths are left to
+	// fail the plan that follows.
+	fileInfo, err := os.Stat(path)
+	if err != nil || !fileInfo.IsDir() {
+		return is, nil
+	}
+
+	localChecksum, err := hashSourcePath(path, excludes)
+	if err != nil {
+		log.Printf("[WARN] Unable to hash source directory %s, skipping migration: %s", path, err)
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] Setting build's local_checksum to %s", localChecksum)
+	is.Attributes["local_checksum"] = localChecksum
+
+	return is, nil
+}
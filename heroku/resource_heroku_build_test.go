@@ -2,9 +2,13 @@ package heroku
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -27,12 +31,166 @@ func TestAccHerokuBuild_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckHerokuBuildExists("heroku_build.foobar", &build),
 					resource.TestCheckResourceAttr("heroku_build.foobar", "status", "succeeded"),
+					resource.TestCheckResourceAttr("heroku_build.foobar", "release_status", "succeeded"),
+					resource.TestCheckResourceAttrSet("heroku_build.foobar", "release_version"),
+					resource.TestCheckResourceAttrSet("heroku_build.foobar", "release_created_at"),
 				),
 			},
 		},
 	})
 }
 
+func TestTailLines(t *testing.T) {
+	output := "one\ntwo\nthree\nfour\n"
+
+	if got := tailLines(output, 2); got != "three\nfour" {
+		t.Fatalf("got %q, want the last two lines", got)
+	}
+
+	if got := tailLines(output, 10); got != "one\ntwo\nthree\nfour" {
+		t.Fatalf("got %q, want all lines", got)
+	}
+}
+
+func TestResourceHerokuBuild_GenerationDiff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/fir-app":
+			w.Write([]byte(`{"name": "fir-app", "generation": {"name": "fir"}}`))
+		case "/apps/cedar-app":
+			w.Write([]byte(`{"name": "cedar-app", "generation": {"name": "cedar"}, "build_stack": {"name": "heroku-22"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	meta := NewConfig()
+	meta.URL = srv.URL
+	if err := meta.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		expectedErr string
+	}{
+		{"fir builder and buildpacks", map[string]interface{}{
+			"app":        "fir-app",
+			"builder":    "heroku/builder:24",
+			"buildpacks": []interface{}{"heroku/nodejs"},
+		}, ""},
+		{"fir stack", map[string]interface{}{"app": "fir-app", "stack": "heroku-24"}, "build stack cannot be set"},
+		{"cedar builder", map[string]interface{}{"app": "cedar-app", "builder": "heroku/builder:24"}, "builder cannot be set for app cedar-app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"source": []interface{}{
+					map[string]interface{}{"url": "https://example.com/source.tgz"},
+				},
+			}
+			for k, v := range tt.raw {
+				raw[k] = v
+			}
+
+			_, err := resourceHerokuBuild().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), meta)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("Expected an error containing %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildCreateOpts(t *testing.T) {
+	builder := "heroku/builder:24"
+	url := "https://example.com/source.tgz"
+	opts := buildCreateOpts{Builder: &builder}
+	opts.SourceBlob.URL = &url
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"source_blob":{"url":"https://example.com/source.tgz"},"builder":"heroku/builder:24"}`
+	if string(body) != expected {
+		t.Fatalf("Expected %s, got %s", expected, body)
+	}
+}
+
+func TestMigrateBuildLocalChecksumStateV0toV1(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "01234567-89ab-cdef-0123-456789abcdef",
+		Attributes: map[string]string{
+			"app":                "foobar",
+			"buildpacks.#":       "0",
+			"stack":              "heroku-22",
+			"local_checksum":     "SHA256:0000000000000000000000000000000000000000000000000000000000000000",
+			"source.#":           "1",
+			"source.0.path":      "test-fixtures/app",
+			"source.0.exclude.#": "1",
+			"source.0.exclude.0": "*.md",
+		},
+	}
+
+	is, err := resourceHerokuBuildMigrate(0, is, NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := hashSourcePath("test-fixtures/app", []string{"*.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := is.Attributes["local_checksum"]; got != expected {
+		t.Fatalf("Expected local_checksum %s, got %s", expected, got)
+	}
+
+	raw := map[string]interface{}{
+		"app": "foobar",
+		"source": []interface{}{
+			map[string]interface{}{"path": "test-fixtures/app", "exclude": []interface{}{"*.md"}},
+		},
+	}
+	diff, err := resourceHerokuBuild().Diff(context.Background(), is, terraform.NewResourceConfigRaw(raw), NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected no new build after migration, got %#v", diff)
+	}
+}
+
+func TestMigrateBuildLocalChecksumStateV0toV1_Tarball(t *testing.T) {
+	checksum := "SHA256:14671a3dcf1ba3f4976438bfd4654da5d2b18ccefa59d10187ecc1286f08ee29"
+	is := &terraform.InstanceState{
+		ID: "01234567-89ab-cdef-0123-456789abcdef",
+		Attributes: map[string]string{
+			"app":            "foobar",
+			"local_checksum": checksum,
+			"source.#":       "1",
+			"source.0.path":  "test-fixtures/app.tgz",
+		},
+	}
+
+	is, err := resourceHerokuBuildMigrate(0, is, NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := is.Attributes["local_checksum"]; got != checksum {
+		t.Fatalf("Expected tarball local_checksum %s to be kept, got %s", checksum, got)
+	}
+}
+
 func TestAccHerokuBuild_InsecureUrl(t *testing.T) {
 	randString := acctest.RandString(10)
 	appName := fmt.Sprintf("tftest-%s", randString)
@@ -65,6 +223,45 @@ func TestAccHerokuBuild_NoSource(t *testing.T) {
 	})
 }
 
+func TestAccHerokuBuild_StackMismatch(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The app must exist for the build to be validated against it.
+				Config: testAccCheckHerokuBuildConfig_stackApp(appName, "heroku-20"),
+			},
+			{
+				Config:      testAccCheckHerokuBuildConfig_stack(appName, "heroku-20", "container", "test-fixtures/app"),
+				ExpectError: regexp.MustCompile(`does not match the stack`),
+			},
+		},
+	})
+}
+
+func TestAccHerokuBuild_ContainerWithoutManifest(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuBuildConfig_stackApp(appName, "container"),
+			},
+			{
+				Config:      testAccCheckHerokuBuildConfig_stack(appName, "container", "container", "test-fixtures/app"),
+				ExpectError: regexp.MustCompile(`must contain a heroku.yml manifest`),
+			},
+		},
+	})
+}
+
 func TestAccHerokuBuild_AllOpts(t *testing.T) {
 	var build heroku.Build
 	randString := acctest.RandString(10)
@@ -184,6 +381,56 @@ func TestAccHerokuBuild_LocalSourceDirectory(t *testing.T) {
 	})
 }
 
+func TestAccHerokuBuild_Triggers(t *testing.T) {
+	var build, build2 heroku.Build
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuBuildConfig_triggers(appName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuBuildExists("heroku_build.foobar", &build),
+					resource.TestCheckResourceAttr("heroku_build.foobar", "triggers.buildpack_version", "v1"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuBuildConfig_triggers(appName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuBuildExists("heroku_build.foobar", &build2),
+					resource.TestCheckResourceAttr("heroku_build.foobar", "triggers.buildpack_version", "v2"),
+					func(*terraform.State) error {
+						if build.ID == build2.ID {
+							return fmt.Errorf("Expected changed triggers to create a new build, but build %s was kept", build.ID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// The fixture app has no Procfile, so it never runs a web process to respond to the health check.
+func TestAccHerokuBuild_HealthCheckFailure(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuBuildConfig_healthCheck(appName),
+				ExpectError: regexp.MustCompile(`did not respond with HTTP 200 at https://.*/health`),
+			},
+		},
+	})
+}
+
 // https://github.com/heroku/terraform-provider-heroku/issues/160
 func TestAccHerokuBuild_LocalSourceDirectorySelfContained(t *testing.T) {
 	var build heroku.Build
@@ -249,6 +496,30 @@ resource "heroku_build" "foobar" {
 }`, appName)
 }
 
+func testAccCheckHerokuBuildConfig_stackApp(appName, appStack string) string {
+	return fmt.Sprintf(`resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+    stack = "%s"
+}`, appName, appStack)
+}
+
+func testAccCheckHerokuBuildConfig_stack(appName, appStack, buildStack, path string) string {
+	return fmt.Sprintf(`resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+    stack = "%s"
+}
+
+resource "heroku_build" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    stack = "%s"
+    source {
+        path = "%s"
+    }
+}`, appName, appStack, buildStack, path)
+}
+
 func testAccCheckHerokuBuildConfig_insecureUrl(appName string) string {
 	return fmt.Sprintf(`resource "heroku_app" "foobar" {
     name = "%s"
@@ -314,6 +585,41 @@ resource "heroku_build" "foobar" {
 }`, appName)
 }
 
+func testAccCheckHerokuBuildConfig_triggers(appName, version string) string {
+	return fmt.Sprintf(`resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_build" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    source {
+      path = "test-fixtures/app/"
+    }
+    triggers = {
+      buildpack_version = "%s"
+    }
+}`, appName, version)
+}
+
+func testAccCheckHerokuBuildConfig_healthCheck(appName string) string {
+	return fmt.Sprintf(`resource "heroku_app" "foobar" {
+    name = "%s"
+    region = "us"
+}
+
+resource "heroku_build" "foobar" {
+    app = "${heroku_app.foobar.name}"
+    source {
+      path = "test-fixtures/app/"
+    }
+    health_check {
+      path    = "/health"
+      timeout = 15
+    }
+}`, appName)
+}
+
 func testAccCheckHerokuBuildConfig_localSourceDirectorySelfContained(appName string) string {
 	return fmt.Sprintf(`resource "heroku_app" "foobar" {
     name = "%s"
@@ -101,12 +101,21 @@ func resourceHerokuCertRead(d *schema.ResourceData, meta interface{}) error {
 	cert, err := resourceHerokuSSLCertRetrieve(
 		d.Get("app").(string), d.Id(), client)
 	if err != nil {
+		if removeNotFound(d, "SSL certificate", err) {
+			return nil
+		}
 		return err
 	}
 
-	d.Set("certificate_chain", cert.CertificateChain)
-	d.Set("name", cert.Name)
-	d.Set("cname", cert.CName)
+	if err := d.Set("certificate_chain", cert.CertificateChain); err != nil {
+		return setAttributeError("certificate_chain", err)
+	}
+	if err := d.Set("name", cert.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("cname", cert.CName); err != nil {
+		return setAttributeError("cname", err)
+	}
 
 	return nil
 }
@@ -152,7 +161,7 @@ func resourceHerokuSSLCertRetrieve(app string, id string, client *heroku.Service
 	addon, err := client.SSLEndpointInfo(context.TODO(), app, id)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error retrieving SSL Cert: %s", err)
+		return nil, fmt.Errorf("Error retrieving SSL Cert: %w", err)
 	}
 
 	return addon, nil
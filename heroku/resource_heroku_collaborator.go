@@ -90,11 +90,18 @@ func resourceHerokuCollaboratorRead(d *schema.ResourceData, meta interface{}) er
 	collaborator, err := resourceHerokuCollaboratorRetrieve(d.Id(), d.Get("app").(string), client)
 
 	if err != nil {
+		if removeNotFound(d, "Collaborator", err) {
+			return nil
+		}
 		return err
 	}
 
-	d.Set("app", collaborator.AppName)
-	d.Set("email", collaborator.Collaborator.Email)
+	if err := d.Set("app", collaborator.AppName); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("email", collaborator.Collaborator.Email); err != nil {
+		return setAttributeError("email", err)
+	}
 
 	return nil
 }
@@ -151,7 +158,7 @@ func resourceHerokuCollaboratorRetrieve(id string, appName string, client *herok
 	err := collaborator.Update()
 
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error retrieving collaborator: %s", err)
+		return nil, fmt.Errorf("[ERROR] Error retrieving collaborator: %w", err)
 	}
 
 	return &collaborator, nil
@@ -193,8 +200,12 @@ func resourceHerokuCollaboratorImport(d *schema.ResourceData, meta interface{})
 	}
 
 	d.SetId(collaboratorInfo.ID)
-	d.Set("app", collaboratorInfo.App.Name)
-	d.Set("email", collaboratorInfo.User.Email)
+	if err := d.Set("app", collaboratorInfo.App.Name); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("email", collaboratorInfo.User.Email); err != nil {
+		return nil, setAttributeError("email", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
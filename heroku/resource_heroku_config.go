@@ -81,12 +81,12 @@ func resourceHerokuConfigCreate(d *schema.ResourceData, m interface{}) error {
 	return resourceHerokuConfigRead(d, m)
 }
 
-func resourceHerokuConfigRead(d *schema.ResourceData, m interface{}) (err error) {
-	err = d.Set("vars", d.Get("vars").(map[string]interface{}))
-	err = d.Set("sensitive_vars", d.Get("sensitive_vars").(map[string]interface{}))
-
-	if err != nil {
-		return err
+func resourceHerokuConfigRead(d *schema.ResourceData, m interface{}) error {
+	if err := d.Set("vars", d.Get("vars").(map[string]interface{})); err != nil {
+		return setAttributeError("vars", err)
+	}
+	if err := d.Set("sensitive_vars", d.Get("sensitive_vars").(map[string]interface{})); err != nil {
+		return setAttributeError("sensitive_vars", err)
 	}
 
 	return nil
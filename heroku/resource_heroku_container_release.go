@@ -0,0 +1,217 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// containerFormationUpdateOpts releases Docker images to process types. The
+// docker_image update is only accepted by the "docker-releases" API variant,
+// so it is not part of heroku.FormationBatchUpdateOpts.
+type containerFormationUpdateOpts struct {
+	Updates []containerFormationUpdate `json:"updates"`
+}
+
+type containerFormationUpdate struct {
+	Type        string `json:"type"`
+	DockerImage string `json:"docker_image"`
+}
+
+// containerFormation is a process type as returned by the "docker-releases" API variant.
+type containerFormation struct {
+	Type        string `json:"type"`
+	DockerImage *struct {
+		ID string `json:"id"`
+	} `json:"docker_image"`
+}
+
+func resourceHerokuContainerRelease() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuContainerReleaseCreate,
+		ReadContext:   resourceHerokuContainerReleaseRead,
+		DeleteContext: resourceHerokuContainerReleaseDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"images": { // An existing Heroku release cannot be updated so ForceNew is required
+				Type:     schema.TypeMap,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^sha256:[0-9a-f]{64}$`),
+						"must be an image digest, e.g. sha256:0123…"),
+				},
+			},
+
+			"release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuContainerReleaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	appName := getAppName(d)
+
+	app, err := client.AppInfo(ctx, appName)
+	if err != nil {
+		return diag.Errorf("Error retrieving app %s: %s", appName, err)
+	}
+
+	if app.BuildStack.Name != "container" {
+		return diag.Errorf("app %s uses the %s stack; container releases require the app's stack to be \"container\"", appName, app.BuildStack.Name)
+	}
+
+	opts := containerFormationUpdateOpts{}
+	images := d.Get("images").(map[string]interface{})
+
+	// Sort so that the update is deterministic for the same set of images.
+	types := make([]string, 0, len(images))
+	for t := range images {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		opts.Updates = append(opts.Updates, containerFormationUpdate{
+			Type:        t,
+			DockerImage: images[t].(string),
+		})
+	}
+
+	// Releasing images that an earlier release deployed creates a new release, so
+	// only the releases after the latest one can be the new release.
+	latest, err := client.ReleaseList(ctx, appName, &heroku.ListRange{Descending: true, Field: "version", Max: 1})
+	if err != nil {
+		return diag.Errorf("Error retrieving releases of app %s: %s", appName, err)
+	}
+	latestVersion := 0
+	if len(latest) > 0 {
+		latestVersion = latest[0].Version
+	}
+
+	log.Printf("[DEBUG] Releasing images to app %s: %#v", appName, opts)
+	var formations heroku.FormationBatchUpdateResult
+	err = config.variantAPI("docker-releases").Patch(ctx, &formations, fmt.Sprintf("/apps/%s/formation", appName), opts)
+	if err != nil {
+		return diag.Errorf("Error releasing images to app %s: %s", appName, err)
+	}
+
+	// The formation update does not return the release it creates, so find it among
+	// the app's latest releases by the images it deployed.
+	releases, err := client.ReleaseList(ctx, appName, &heroku.ListRange{Descending: true, Field: "version", Max: 10})
+	if err != nil {
+		return diag.Errorf("Error retrieving releases of app %s: %s", appName, err)
+	}
+
+	release := findContainerRelease(releases, opts.Updates, latestVersion)
+	if release == nil {
+		return diag.Errorf("no release of the images found for app %s", appName)
+	}
+
+	log.Printf("[INFO] Begin Checking if new Release %s is successful", release.ID)
+
+	// Track the release in state before waiting, so that a release that fails or
+	// times out is tainted and replaced on the next apply.
+	d.SetId(release.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"succeeded"},
+		Refresh: releaseStateRefreshFunc(client, appName, release.ID),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("Release v%d", release.Version), stateWaitError(fmt.Sprintf("new release (%s) to succeed", release.ID), schema.TimeoutCreate, err)))
+	}
+
+	return resourceHerokuContainerReleaseRead(ctx, d, meta)
+}
+
+func resourceHerokuContainerReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	release, err := client.ReleaseInfo(ctx, getAppName(d), d.Id())
+	if err != nil {
+		if removeNotFound(d, "Container release", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving container release: %s", err)
+	}
+
+	if err := d.Set("app", release.App.Name); err != nil {
+		return setAttributeDiagnostics("app", err)
+	}
+	if err := d.Set("release_id", release.ID); err != nil {
+		return setAttributeDiagnostics("release_id", err)
+	}
+	if err := d.Set("version", release.Version); err != nil {
+		return setAttributeDiagnostics("version", err)
+	}
+
+	return nil
+}
+
+// findContainerRelease returns the latest of releases, in descending order, that deployed
+// all of the images of updates after the given version. Container releases are described
+// as "Deployed web (0123456789ab), worker (…)", by the short ID of each image.
+func findContainerRelease(releases []heroku.Release, updates []containerFormationUpdate, after int) *heroku.Release {
+	for i, release := range releases {
+		if release.Version <= after {
+			break
+		}
+		matched := true
+		for _, u := range updates {
+			shortID := strings.TrimPrefix(u.DockerImage, "sha256:")
+			if len(shortID) > 12 {
+				shortID = shortID[:12]
+			}
+			if !strings.Contains(release.Description, fmt.Sprintf("%s (%s)", u.Type, shortID)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &releases[i]
+		}
+	}
+	return nil
+}
+
+// resourceHerokuContainerReleaseDelete is a no-op method as there is no DELETE endpoint for the release resource
+// in the Heroku Platform APIs.
+func resourceHerokuContainerReleaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for release resource so this is a no-op. Resource will be removed from state.")
+	return nil
+}
@@ -0,0 +1,96 @@
+package heroku
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func TestFindContainerRelease(t *testing.T) {
+	web := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	worker := "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+	releases := []heroku.Release{
+		{ID: "v4", Version: 4, Description: "Set FOO config vars"},
+		{ID: "v3", Version: 3, Description: "Deployed web (0123456789ab), worker (fedcba987654)"},
+		{ID: "v2", Version: 2, Description: "Deployed web (0123456789ab)"},
+	}
+
+	tests := []struct {
+		name     string
+		updates  []containerFormationUpdate
+		after    int
+		expected string
+	}{
+		{"all images", []containerFormationUpdate{{Type: "web", DockerImage: web}, {Type: "worker", DockerImage: worker}}, 1, "v3"},
+		{"latest match", []containerFormationUpdate{{Type: "web", DockerImage: web}}, 1, "v3"},
+		{"other type", []containerFormationUpdate{{Type: "web", DockerImage: worker}}, 1, ""},
+		{"earlier release", []containerFormationUpdate{{Type: "web", DockerImage: web}}, 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := findContainerRelease(releases, tt.updates, tt.after)
+			if tt.expected == "" {
+				if release != nil {
+					t.Fatalf("Expected no release, got %s", release.ID)
+				}
+				return
+			}
+			if release == nil || release.ID != tt.expected {
+				t.Fatalf("Expected release %s, got %#v", tt.expected, release)
+			}
+		})
+	}
+}
+
+func TestAccHerokuContainerRelease_InvalidDigest(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuContainerReleaseConfig(appName, "container", "latest"),
+				ExpectError: regexp.MustCompile(`must be an image digest`),
+			},
+		},
+	})
+}
+
+func TestAccHerokuContainerRelease_NotContainerStack(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	digest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuContainerReleaseConfig(appName, "heroku-20", digest),
+				ExpectError: regexp.MustCompile(`require the app's stack to be "container"`),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuContainerReleaseConfig(appName, stack, digest string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+  stack  = "%s"
+}
+
+resource "heroku_container_release" "foobar" {
+  app = heroku_app.foobar.name
+
+  images = {
+    web = "%s"
+  }
+}`, appName, stack, digest)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
+	"golang.org/x/net/publicsuffix"
 )
 
 func resourceHerokuDomain() *schema.Resource {
@@ -23,9 +25,10 @@ func resourceHerokuDomain() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"hostname": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDomainHostname,
 			},
 
 			"app": {
@@ -43,6 +46,32 @@ func resourceHerokuDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+
+			"refresh_acm": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"dns_record_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_target": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"acm_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"acm_status_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -61,7 +90,9 @@ func resourceHerokuDomainImport(d *schema.ResourceData, meta interface{}) ([]*sc
 		return nil, err
 	}
 
-	populateResource(d, do)
+	if err := populateResource(d, do); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -83,32 +114,49 @@ func resourceHerokuDomainCreate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return err
 	}
-	populateResource(d, do)
+	if err := populateResource(d, do); err != nil {
+		return err
+	}
 
 	config := meta.(*Config)
 	time.Sleep(time.Duration(config.PostDomainCreateDelay) * time.Second)
 
+	if d.Get("refresh_acm").(bool) {
+		if err := refreshAcm(client, app); err != nil {
+			return err
+		}
+		return resourceHerokuDomainRead(d, meta)
+	}
+
 	return nil
 }
 
 func resourceHerokuDomainUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Config).Api
 	app := d.Get("app").(string)
-	opts := heroku.DomainUpdateOpts{}
 
 	if d.HasChange("sni_endpoint_id") {
 		v := d.Get("sni_endpoint_id").(string)
-		opts.SniEndpoint = &v
-	}
+		opts := heroku.DomainUpdateOpts{SniEndpoint: &v}
 
-	do, err := client.DomainUpdate(context.TODO(), app, d.Id(), opts)
-	if err != nil {
-		return err
+		do, err := client.DomainUpdate(context.TODO(), app, d.Id(), opts)
+		if err != nil {
+			return err
+		}
+
+		if err := populateResource(d, do); err != nil {
+			return err
+		}
 	}
 
-	populateResource(d, do)
+	// Enabling refresh_acm retriggers a stuck validation of an unchanged domain.
+	if d.Get("refresh_acm").(bool) {
+		if err := refreshAcm(client, app); err != nil {
+			return err
+		}
+	}
 
-	return nil
+	return resourceHerokuDomainRead(d, meta)
 }
 
 func resourceHerokuDomainDelete(d *schema.ResourceData, meta interface{}) error {
@@ -131,21 +179,71 @@ func resourceHerokuDomainRead(d *schema.ResourceData, meta interface{}) error {
 	app := d.Get("app").(string)
 	do, err := client.DomainInfo(context.TODO(), app, d.Id())
 	if err != nil {
+		if removeNotFound(d, "Domain", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving domain: %s", err)
 	}
 
 	log.Printf("[INFO] Reading Domain: %s", d.Id())
-	populateResource(d, do)
-
-	return nil
+	return populateResource(d, do)
 }
 
-func populateResource(d *schema.ResourceData, do *heroku.Domain) {
+func populateResource(d *schema.ResourceData, do *heroku.Domain) error {
 	d.SetId(do.ID)
-	d.Set("app", do.App.Name)
-	d.Set("hostname", do.Hostname)
-	d.Set("cname", do.CName)
+	if err := d.Set("app", do.App.Name); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("hostname", do.Hostname); err != nil {
+		return setAttributeError("hostname", err)
+	}
+	if err := d.Set("cname", do.CName); err != nil {
+		return setAttributeError("cname", err)
+	}
+	if err := d.Set("dns_record_type", domainDNSRecordType(do.Hostname)); err != nil {
+		return setAttributeError("dns_record_type", err)
+	}
+	if do.CName != nil {
+		if err := d.Set("dns_target", *do.CName); err != nil {
+			return setAttributeError("dns_target", err)
+		}
+	}
 	if v := do.SniEndpoint; v != nil {
-		d.Set("sni_endpoint_id", v.ID)
+		if err := d.Set("sni_endpoint_id", v.ID); err != nil {
+			return setAttributeError("sni_endpoint_id", err)
+		}
+	}
+	if v := do.AcmStatus; v != nil {
+		if err := d.Set("acm_status", *v); err != nil {
+			return setAttributeError("acm_status", err)
+		}
+	}
+	if v := do.AcmStatusReason; v != nil {
+		if err := d.Set("acm_status_reason", *v); err != nil {
+			return setAttributeError("acm_status_reason", err)
+		}
 	}
+
+	return nil
+}
+
+// domainDNSRecordType returns the type of DNS record to point a hostname at its
+// DNS target. Apex domains cannot have CNAME records, so their DNS provider must
+// support ALIAS or ANAME records instead.
+func domainDNSRecordType(hostname string) string {
+	hostname = strings.ToLower(hostname)
+	if apex, err := publicsuffix.EffectiveTLDPlusOne(hostname); err == nil && apex == hostname {
+		return "ALIAS"
+	}
+	return "CNAME"
+}
+
+// refreshAcm retriggers Automated Certificate Management of an app, so that
+// certificates are issued for its new or failed domains.
+func refreshAcm(client *heroku.Service, app string) error {
+	log.Printf("[INFO] Refreshing ACM of app: %s", app)
+	if _, err := client.AppRefreshACM(context.TODO(), app); err != nil {
+		return fmt.Errorf("Error refreshing ACM of app %s: %s", app, err)
+	}
+	return nil
 }
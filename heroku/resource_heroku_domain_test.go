@@ -338,3 +338,21 @@ resource "heroku_domain" "one" {
   hostname = "terraform-%s.example.com"
 }`, appName, appName)
 }
+
+func TestDomainDNSRecordType(t *testing.T) {
+	tests := map[string]string{
+		"example.com":       "ALIAS",
+		"Example.COM":       "ALIAS",
+		"example.co.uk":     "ALIAS",
+		"www.example.com":   "CNAME",
+		"www.example.co.uk": "CNAME",
+		"*.example.com":     "CNAME",
+		"a.b.c.example.com": "CNAME",
+	}
+
+	for hostname, expected := range tests {
+		if actual := domainDNSRecordType(hostname); actual != expected {
+			t.Errorf("domainDNSRecordType(%q) = %q, expected %q", hostname, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// resourceHerokuDomains manages a set of custom domains of one app, refreshing them
+// all with a single list call. It is meant for apps with many hostnames, such as
+// customer vanity domains, where a heroku_domain per hostname is too slow.
+func resourceHerokuDomains() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuDomainsCreate,
+		ReadContext:   resourceHerokuDomainsRead,
+		UpdateContext: resourceHerokuDomainsUpdate,
+		DeleteContext: resourceHerokuDomainsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuDomainsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"hostnames": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateDomainHostname,
+				},
+			},
+
+			"sni_endpoint_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"refresh_acm": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"cname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"sni_endpoint_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"dns_record_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"dns_target": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"acm_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"acm_status_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceHerokuDomainsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Config).Api
+
+	log.Printf("[INFO] Importing custom domains of app: %s", d.Id())
+	domains, err := listHerokuDomains(ctx, client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := make([]string, 0, len(domains))
+	for _, do := range domains {
+		if do.Kind == "custom" {
+			hostnames = append(hostnames, do.Hostname)
+		}
+	}
+
+	if err := d.Set("app", d.Id()); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("hostnames", hostnames); err != nil {
+		return nil, setAttributeError("hostnames", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuDomainsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	app := d.Get("app").(string)
+
+	d.SetId(app)
+
+	hostnames := d.Get("hostnames").(*schema.Set)
+	created, err := createHerokuDomains(ctx, client, app, hostnames.List(), d.Get("sni_endpoint_id").(string))
+	if err != nil {
+		// Only track the domains that were created, so the rest are retried.
+		if err := d.Set("hostnames", created); err != nil {
+			return setAttributeDiagnostics("hostnames", err)
+		}
+		return diag.FromErr(err)
+	}
+
+	config := meta.(*Config)
+	time.Sleep(time.Duration(config.PostDomainCreateDelay) * time.Second)
+
+	if d.Get("refresh_acm").(bool) {
+		if err := refreshAcm(client, app); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[INFO] Created %d domains on app %s", len(created), app)
+
+	return resourceHerokuDomainsRead(ctx, d, meta)
+}
+
+func resourceHerokuDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	app := d.Get("app").(string)
+
+	domains, err := listHerokuDomains(ctx, client, app)
+	if err != nil {
+		if removeNotFound(d, "Domains of app", err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Only report the managed hostnames, ignoring the app's default domain and
+	// those managed elsewhere, so that removed ones are recreated.
+	managed := d.Get("hostnames").(*schema.Set)
+	hostnames := make([]string, 0, managed.Len())
+	domainList := make([]map[string]interface{}, 0, managed.Len())
+	for _, do := range domains {
+		if !managed.Contains(do.Hostname) {
+			continue
+		}
+		hostnames = append(hostnames, do.Hostname)
+
+		domain := map[string]interface{}{
+			"id":              do.ID,
+			"hostname":        do.Hostname,
+			"dns_record_type": domainDNSRecordType(do.Hostname),
+		}
+		if do.CName != nil {
+			domain["cname"] = *do.CName
+			domain["dns_target"] = *do.CName
+		}
+		if do.SniEndpoint != nil {
+			domain["sni_endpoint_id"] = do.SniEndpoint.ID
+		}
+		if do.AcmStatus != nil {
+			domain["acm_status"] = *do.AcmStatus
+		}
+		if do.AcmStatusReason != nil {
+			domain["acm_status_reason"] = *do.AcmStatusReason
+		}
+		domainList = append(domainList, domain)
+	}
+
+	if err := d.Set("hostnames", hostnames); err != nil {
+		return setAttributeDiagnostics("hostnames", err)
+	}
+	if err := d.Set("domains", domainList); err != nil {
+		return setAttributeDiagnostics("domains", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuDomainsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	app := d.Get("app").(string)
+	sniEndpointID := d.Get("sni_endpoint_id").(string)
+
+	o, n := d.GetChange("hostnames")
+	oldHostnames := o.(*schema.Set)
+	newHostnames := n.(*schema.Set)
+	remove := oldHostnames.Difference(newHostnames).List()
+	add := newHostnames.Difference(oldHostnames).List()
+
+	// On failure, track what actually exists so the rest is retried.
+	current := oldHostnames.Union(oldHostnames)
+
+	removed, err := deleteHerokuDomains(ctx, client, app, remove)
+	for _, h := range removed {
+		current.Remove(h)
+	}
+	if err != nil {
+		if err := d.Set("hostnames", current.List()); err != nil {
+			return setAttributeDiagnostics("hostnames", err)
+		}
+		return diag.FromErr(err)
+	}
+
+	created, err := createHerokuDomains(ctx, client, app, add, sniEndpointID)
+	for _, h := range created {
+		current.Add(h)
+	}
+	if err != nil {
+		if err := d.Set("hostnames", current.List()); err != nil {
+			return setAttributeDiagnostics("hostnames", err)
+		}
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("sni_endpoint_id") {
+		opts := heroku.DomainUpdateOpts{SniEndpoint: &sniEndpointID}
+		for _, h := range oldHostnames.Intersection(newHostnames).List() {
+			log.Printf("[DEBUG] Updating SNI endpoint of domain %s on app %s", h, app)
+			if _, err := client.DomainUpdate(ctx, app, h.(string), opts); err != nil {
+				return diag.Errorf("Error updating domain %s: %s", h, err)
+			}
+		}
+	}
+
+	if len(created) > 0 {
+		config := meta.(*Config)
+		time.Sleep(time.Duration(config.PostDomainCreateDelay) * time.Second)
+	}
+
+	// Refresh when domains change, or when refresh_acm is enabled to retrigger
+	// a stuck validation.
+	if d.Get("refresh_acm").(bool) && (len(created) > 0 || d.HasChanges("sni_endpoint_id", "refresh_acm")) {
+		if err := refreshAcm(client, app); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceHerokuDomainsRead(ctx, d, meta)
+}
+
+func resourceHerokuDomainsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	app := d.Get("app").(string)
+
+	log.Printf("[INFO] Deleting domains of app: %s", app)
+	if _, err := deleteHerokuDomains(ctx, client, app, d.Get("hostnames").(*schema.Set).List()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// listHerokuDomains lists all domains of an app in a single request.
+func listHerokuDomains(ctx context.Context, client *heroku.Service, app string) (heroku.DomainListResult, error) {
+	domains, err := client.DomainList(ctx, app, &heroku.ListRange{Field: "hostname", Max: 1000})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving domains of app %s: %w", app, err)
+	}
+	return domains, nil
+}
+
+// createHerokuDomains adds each hostname to the app, returning those that were
+// created before any error.
+func createHerokuDomains(ctx context.Context, client *heroku.Service, app string, hostnames []interface{}, sniEndpointID string) ([]string, error) {
+	created := make([]string, 0, len(hostnames))
+	for _, h := range hostnames {
+		opts := heroku.DomainCreateOpts{Hostname: h.(string)}
+		if sniEndpointID != "" {
+			opts.SniEndpoint = &sniEndpointID
+		}
+
+		log.Printf("[DEBUG] Creating domain %s on app %s", opts.Hostname, app)
+		if _, err := client.DomainCreate(ctx, app, opts); err != nil {
+			return created, fmt.Errorf("Error creating domain %s: %s", opts.Hostname, err)
+		}
+		created = append(created, opts.Hostname)
+	}
+	return created, nil
+}
+
+// deleteHerokuDomains removes each hostname from the app, returning those that
+// were deleted before any error. Hostnames that no longer exist are skipped.
+func deleteHerokuDomains(ctx context.Context, client *heroku.Service, app string, hostnames []interface{}) ([]string, error) {
+	if len(hostnames) == 0 {
+		return nil, nil
+	}
+
+	domains, err := listHerokuDomains(ctx, client, app)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]string, len(domains))
+	for _, do := range domains {
+		existing[do.Hostname] = do.ID
+	}
+
+	deleted := make([]string, 0, len(hostnames))
+	for _, h := range hostnames {
+		hostname := h.(string)
+		if id, ok := existing[hostname]; ok {
+			log.Printf("[DEBUG] Deleting domain %s on app %s", hostname, app)
+			if _, err := client.DomainDelete(ctx, app, id); err != nil {
+				return deleted, fmt.Errorf("Error deleting domain %s: %s", hostname, err)
+			}
+		}
+		deleted = append(deleted, hostname)
+	}
+	return deleted, nil
+}
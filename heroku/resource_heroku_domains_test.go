@@ -0,0 +1,92 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/heroku/terraform-provider-heroku/v4/helper/test"
+)
+
+func TestAccHerokuDomains_Basic(t *testing.T) {
+	randString := acctest.RandString(10)
+	appName := fmt.Sprintf("tftest-%s", randString)
+	one := fmt.Sprintf("one-tftest-%s.example.com", randString)
+	two := fmt.Sprintf("two-tftest-%s.example.com", randString)
+	three := fmt.Sprintf("three-tftest-%s.example.com", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuDomainsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuDomainsConfig_basic(appName, one, two),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_domains.foobar", "hostnames.#", "2"),
+					test.TestCheckTypeSetElemAttr("heroku_domains.foobar", "hostnames.*", one),
+					test.TestCheckTypeSetElemAttr("heroku_domains.foobar", "hostnames.*", two),
+					resource.TestCheckResourceAttr("heroku_domains.foobar", "domains.#", "2"),
+					resource.TestCheckResourceAttrSet("heroku_domains.foobar", "domains.0.cname"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuDomainsConfig_basic(appName, two, three),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_domains.foobar", "hostnames.#", "2"),
+					test.TestCheckTypeSetElemAttr("heroku_domains.foobar", "hostnames.*", two),
+					test.TestCheckTypeSetElemAttr("heroku_domains.foobar", "hostnames.*", three),
+					resource.TestCheckResourceAttr("heroku_domains.foobar", "domains.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "heroku_domains.foobar",
+				ImportState:             true,
+				ImportStateId:           appName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"refresh_acm"},
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuDomainsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).Api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_domains" {
+			continue
+		}
+
+		domains, err := client.DomainList(context.TODO(), rs.Primary.ID, nil)
+		if err != nil {
+			// The app is destroyed along with its domains.
+			continue
+		}
+
+		for _, do := range domains {
+			if do.Kind == "custom" {
+				return fmt.Errorf("Domain %s still exists", do.Hostname)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHerokuDomainsConfig_basic(appName string, hostnames ...string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_domains" "foobar" {
+  app       = heroku_app.foobar.name
+  hostnames = ["%s", "%s"]
+}
+`, appName, hostnames[0], hostnames[1])
+}
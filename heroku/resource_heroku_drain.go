@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"strings"
 	"time"
 
@@ -22,11 +24,15 @@ func resourceHerokuDrain() *schema.Resource {
 			State: resourceHerokuDrainImport,
 		},
 
+		CustomizeDiff: resourceHerokuDrainCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"url": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDrainURL,
+				DiffSuppressFunc: suppressEquivalentDrainURL,
 			},
 
 			"app": {
@@ -40,9 +46,37 @@ func resourceHerokuDrain() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			// New apps are assigned a log channel shortly after they are created.
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
 	}
 }
 
+// resourceHerokuDrainCustomizeDiff rejects log drains of Fir generation apps, whose
+// logs are only sent to telemetry drains.
+func resourceHerokuDrainCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	appName := diff.Get("app").(string)
+	if diff.Id() != "" || appName == "" || !diff.NewValueKnown("app") {
+		return nil
+	}
+
+	app, err := retrieveAppWithGeneration(appName, v.(*Config).Api)
+	if err != nil {
+		// The app may not exist yet. Any real problem will surface at apply.
+		log.Printf("[DEBUG] Skipping drain generation validation for app %s: %s", appName, err)
+		return nil
+	}
+
+	if app.GenerationName() == "fir" {
+		return fmt.Errorf("log drains cannot be added to app %s, which is a Fir generation app. "+
+			"Fir apps send their logs to telemetry drains instead", appName)
+	}
+
+	return nil
+}
+
 const retryableError = `App hasn't yet been assigned a log channel. Please try again momentarily.`
 
 func resourceHerokuDrainImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -59,7 +93,9 @@ func resourceHerokuDrainImport(d *schema.ResourceData, meta interface{}) ([]*sch
 	}
 
 	d.SetId(dr.ID)
-	d.Set("app", app)
+	if err := d.Set("app", app); err != nil {
+		return nil, setAttributeError("app", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -73,7 +109,7 @@ func resourceHerokuDrainCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Drain create configuration: %#v, %#v", app, url)
 
 	var dr *heroku.LogDrain
-	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		d, err := client.LogDrainCreate(context.TODO(), app, heroku.LogDrainCreateOpts{URL: url})
 		if err != nil {
 			if strings.Contains(err.Error(), retryableError) {
@@ -89,8 +125,12 @@ func resourceHerokuDrainCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(dr.ID)
-	d.Set("url", dr.URL)
-	d.Set("token", dr.Token)
+	if err := d.Set("url", dr.URL); err != nil {
+		return setAttributeError("url", err)
+	}
+	if err := d.Set("token", dr.Token); err != nil {
+		return setAttributeError("token", err)
+	}
 
 	log.Printf("[INFO] Drain ID: %s", d.Id())
 	return nil
@@ -115,11 +155,85 @@ func resourceHerokuDrainRead(d *schema.ResourceData, meta interface{}) error {
 
 	dr, err := client.LogDrainInfo(context.TODO(), d.Get("app").(string), d.Id())
 	if err != nil {
+		if removeNotFound(d, "Drain", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving drain: %s", err)
 	}
 
-	d.Set("url", dr.URL)
-	d.Set("token", dr.Token)
+	if err := d.Set("url", dr.URL); err != nil {
+		return setAttributeError("url", err)
+	}
+	if err := d.Set("token", dr.Token); err != nil {
+		return setAttributeError("token", err)
+	}
 
 	return nil
 }
+
+// drainDefaultPorts are the default ports of the drain URL schemes.
+var drainDefaultPorts = map[string]string{
+	"http":       "80",
+	"https":      "443",
+	"syslog":     "514",
+	"syslog+tls": "6514",
+}
+
+// validateDrainURL matches type terraform.SchemaValidateFunc. Drains must use
+// https or syslog+tls, except for the plaintext http and syslog schemes, which are
+// still accepted by Heroku outside of Shield spaces and only cause a warning.
+func validateDrainURL(val interface{}, key string) ([]string, []error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q is an invalid drain URL: unable to assert %q to string", key, val)}
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q is an invalid drain URL %q: %s", key, s, err)}
+	}
+	if u.Host == "" {
+		return nil, []error{fmt.Errorf("%q is an invalid drain URL %q: it must include a host", key, s)}
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https", "syslog+tls":
+		return nil, nil
+	case "http", "syslog":
+		return []string{fmt.Sprintf("%q drain URL %q sends logs unencrypted, use https or syslog+tls instead", key, s)}, nil
+	}
+
+	return nil, []error{fmt.Errorf("%q is an invalid drain URL %q: the scheme must be https or syslog+tls", key, s)}
+}
+
+// suppressEquivalentDrainURL suppresses the diff of drain URLs that only differ
+// trivially, otherwise the drain is needlessly destroyed and recreated.
+func suppressEquivalentDrainURL(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDrainURL(old) == normalizeDrainURL(new)
+}
+
+// normalizeDrainURL lowercases the scheme and host of a drain URL, and removes
+// its default port and trailing slash.
+func normalizeDrainURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return s
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host, port := u.Hostname(), u.Port()
+	if port == drainDefaultPorts[u.Scheme] {
+		port = ""
+	}
+	host = strings.ToLower(host)
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String()
+}
@@ -35,6 +35,68 @@ func TestAccHerokuDrain_Basic(t *testing.T) {
 	})
 }
 
+func TestNormalizeDrainURL(t *testing.T) {
+	equivalent := [][]string{
+		{"https://logs.example.com/", "https://logs.example.com"},
+		{"https://logs.example.com:443/drain", "https://logs.example.com/drain"},
+		{"HTTPS://Logs.Example.com/drain/", "https://logs.example.com/drain"},
+		{"syslog+tls://logs.example.com:6514", "syslog+tls://logs.example.com"},
+		{"syslog://logs.example.com:514", "syslog://logs.example.com"},
+	}
+	for _, v := range equivalent {
+		if actual, expected := normalizeDrainURL(v[0]), normalizeDrainURL(v[1]); actual != expected {
+			t.Fatalf("%q and %q should be equivalent, got %q and %q", v[0], v[1], actual, expected)
+		}
+	}
+
+	different := [][]string{
+		{"https://logs.example.com:8443", "https://logs.example.com"},
+		{"syslog+tls://logs.example.com:514", "syslog+tls://logs.example.com"},
+		{"https://logs.example.com/a", "https://logs.example.com/b"},
+		{"https://logs.example.com?token=a", "https://logs.example.com?token=b"},
+	}
+	for _, v := range different {
+		if normalizeDrainURL(v[0]) == normalizeDrainURL(v[1]) {
+			t.Fatalf("%q and %q should not be equivalent", v[0], v[1])
+		}
+	}
+}
+
+func TestValidateDrainURL(t *testing.T) {
+	valid := []string{
+		"https://logs.example.com/drain",
+		"syslog+tls://logs.example.com:6514",
+	}
+	for _, v := range valid {
+		warnings, errors := validateDrainURL(v, "url")
+		if len(warnings) != 0 || len(errors) != 0 {
+			t.Fatalf("%q should be a valid drain URL: %q %q", v, warnings, errors)
+		}
+	}
+
+	insecure := []string{
+		"http://logs.example.com/drain",
+		"syslog://logs.example.com:514",
+	}
+	for _, v := range insecure {
+		warnings, errors := validateDrainURL(v, "url")
+		if len(warnings) == 0 || len(errors) != 0 {
+			t.Fatalf("%q should be a valid drain URL with a warning: %q %q", v, warnings, errors)
+		}
+	}
+
+	invalid := []string{
+		"logs.example.com",
+		"ftp://logs.example.com",
+		"https://",
+	}
+	for _, v := range invalid {
+		if _, errors := validateDrainURL(v, "url"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid drain URL", v)
+		}
+	}
+}
+
 func testAccCheckHerokuDrainDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Config).Api
 
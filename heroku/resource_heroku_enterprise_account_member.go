@@ -0,0 +1,245 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuEnterpriseAccountMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuEnterpriseAccountMemberCreate,
+		ReadContext:   resourceHerokuEnterpriseAccountMemberRead,
+		UpdateContext: resourceHerokuEnterpriseAccountMemberUpdate,
+		DeleteContext: resourceHerokuEnterpriseAccountMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuEnterpriseAccountMemberImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enterprise_account": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"view", "create", "manage", "billing"}, false),
+				},
+			},
+
+			"federated": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"require_two_factor_authentication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"two_factor_authentication": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuEnterpriseAccountMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	account, email, err := parseCompositeID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("enterprise_account", account); err != nil {
+		return nil, setAttributeError("enterprise_account", err)
+	}
+	if err := d.Set("email", email); err != nil {
+		return nil, setAttributeError("email", err)
+	}
+
+	if diags := resourceHerokuEnterpriseAccountMemberRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("Error importing enterprise account member %s: %s", d.Id(), diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("Could not find member %s of enterprise account %s", email, account)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuEnterpriseAccountMemberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account := d.Get("enterprise_account").(string)
+	email := d.Get("email").(string)
+	federated := d.Get("federated").(bool)
+
+	opts := heroku.EnterpriseAccountMemberCreateOpts{
+		User:        email,
+		Permissions: enterpriseAccountMemberPermissions(d),
+		Federated:   &federated,
+	}
+
+	log.Printf("[DEBUG] Adding member %s to enterprise account %s", email, account)
+	member, err := client.EnterpriseAccountMemberCreate(ctx, account, opts)
+	if err != nil {
+		return diag.Errorf("Error adding member %s to enterprise account %s: %s", email, account, err)
+	}
+
+	if d.Get("require_two_factor_authentication").(bool) && !member.TwoFactorAuthentication {
+		if _, err := client.EnterpriseAccountMemberDelete(ctx, account, email); err != nil {
+			log.Printf("[WARN] Error removing member %s without two-factor authentication from enterprise account %s: %s", email, account, err)
+		}
+		return diag.Errorf("Member %s of enterprise account %s does not have two-factor authentication enabled, "+
+			"so it was not added", email, account)
+	}
+
+	d.SetId(buildCompositeID(account, email))
+
+	return resourceHerokuEnterpriseAccountMemberRead(ctx, d, meta)
+}
+
+func resourceHerokuEnterpriseAccountMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account, email, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	member, err := findEnterpriseAccountMember(ctx, client, account, email)
+	if err != nil {
+		if removeNotFound(d, "Enterprise account member", err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if member == nil {
+		log.Printf("[WARN] Member %s of enterprise account %s not found, removing from state", email, account)
+		d.SetId("")
+		return nil
+	}
+
+	permissions := make([]string, 0, len(member.Permissions))
+	for _, p := range member.Permissions {
+		permissions = append(permissions, p.Name)
+	}
+
+	if err := d.Set("enterprise_account", account); err != nil {
+		return setAttributeDiagnostics("enterprise_account", err)
+	}
+	if err := d.Set("email", member.User.Email); err != nil {
+		return setAttributeDiagnostics("email", err)
+	}
+	if err := d.Set("user_id", member.User.ID); err != nil {
+		return setAttributeDiagnostics("user_id", err)
+	}
+	if err := d.Set("permissions", permissions); err != nil {
+		return setAttributeDiagnostics("permissions", err)
+	}
+	if err := d.Set("federated", member.IdentityProvider != nil); err != nil {
+		return setAttributeDiagnostics("federated", err)
+	}
+	if err := d.Set("two_factor_authentication", member.TwoFactorAuthentication); err != nil {
+		return setAttributeDiagnostics("two_factor_authentication", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuEnterpriseAccountMemberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account, email, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("require_two_factor_authentication").(bool) && !d.Get("two_factor_authentication").(bool) {
+		return diag.Errorf("Member %s of enterprise account %s does not have two-factor authentication enabled", email, account)
+	}
+
+	if d.HasChange("permissions") {
+		opts := heroku.EnterpriseAccountMemberUpdateOpts{
+			Permissions: enterpriseAccountMemberPermissions(d),
+		}
+
+		log.Printf("[DEBUG] Updating permissions of member %s of enterprise account %s", email, account)
+		if _, err := client.EnterpriseAccountMemberUpdate(ctx, account, email, opts); err != nil {
+			return diag.Errorf("Error updating member %s of enterprise account %s: %s", email, account, err)
+		}
+	}
+
+	return resourceHerokuEnterpriseAccountMemberRead(ctx, d, meta)
+}
+
+func resourceHerokuEnterpriseAccountMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	account, email, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Removing member %s from enterprise account %s", email, account)
+	if _, err := client.EnterpriseAccountMemberDelete(ctx, account, email); err != nil {
+		return diag.Errorf("Error removing member %s from enterprise account %s: %s", email, account, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// findEnterpriseAccountMember returns the member of an enterprise account with the
+// given email, or nil when the user is not a member.
+func findEnterpriseAccountMember(ctx context.Context, client *heroku.Service, account, email string) (*heroku.EnterpriseAccountMember, error) {
+	members, err := client.EnterpriseAccountMemberList(ctx, account, &heroku.ListRange{Field: "email", Max: 1000})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving members of enterprise account %s: %w", account, err)
+	}
+
+	for _, m := range members {
+		if m.User.Email == email {
+			member := m
+			return &member, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func enterpriseAccountMemberPermissions(d *schema.ResourceData) []string {
+	permissions := make([]string, 0)
+	for _, p := range d.Get("permissions").(*schema.Set).List() {
+		permissions = append(permissions, p.(string))
+	}
+	return permissions
+}
@@ -0,0 +1,82 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccHerokuEnterpriseAccountMember_Basic(t *testing.T) {
+	account := testAccConfig.GetEnterpriseAccountOrSkip(t)
+	testUser := testAccConfig.GetUserOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuEnterpriseAccountMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuEnterpriseAccountMemberConfig(account, testUser, `"view"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_enterprise_account_member.foobar", "permissions.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_enterprise_account_member.foobar", "user_id"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuEnterpriseAccountMemberConfig(account, testUser, `"view", "create"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_enterprise_account_member.foobar", "permissions.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "heroku_enterprise_account_member.foobar",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"require_two_factor_authentication"},
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuEnterpriseAccountMemberConfig(account, email, permissions string) string {
+	return fmt.Sprintf(`
+resource "heroku_enterprise_account_member" "foobar" {
+  enterprise_account = "%s"
+  email              = "%s"
+  permissions        = [%s]
+}
+`, account, email, permissions)
+}
+
+func testAccCheckHerokuEnterpriseAccountMemberDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Config).Api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "heroku_enterprise_account_member" {
+			continue
+		}
+
+		account, email, err := parseCompositeID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		member, err := findEnterpriseAccountMember(context.TODO(), client, account, email)
+		if err != nil {
+			return err
+		}
+		if member != nil {
+			return fmt.Errorf("Member %s of enterprise account %s still exists", email, account)
+		}
+	}
+
+	return nil
+}
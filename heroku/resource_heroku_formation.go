@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
@@ -37,6 +42,8 @@ func resourceHerokuFormation() *schema.Resource {
 			State: resourceHerokuFormationImport,
 		},
 
+		CustomizeDiff: resourceHerokuFormationCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"app": {
 				Type:     schema.TypeString,
@@ -50,8 +57,9 @@ func resourceHerokuFormation() *schema.Resource {
 			},
 
 			"quantity": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 
 			"size": {
@@ -59,6 +67,17 @@ func resourceHerokuFormation() *schema.Resource {
 				Required:  true,
 				StateFunc: formatSize,
 			},
+
+			"wait_for_dynos_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 		},
 	}
 }
@@ -70,15 +89,26 @@ func resourceHerokuFormationRead(d *schema.ResourceData, meta interface{}) (err
 
 	formation, err := resourceHerokuFormationRetrieve(d.Id(), appName, client)
 	if err != nil {
+		if removeNotFound(d, "Formation", err) {
+			return nil
+		}
 		return err
 	}
 
-	err = d.Set("app", formation.Formation.AppName)
-	err = d.Set("type", formation.Formation.Type)
-	err = d.Set("quantity", formation.Formation.Quantity)
-	err = d.Set("size", formation.Formation.Size)
+	if err := d.Set("app", formation.Formation.AppName); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("type", formation.Formation.Type); err != nil {
+		return setAttributeError("type", err)
+	}
+	if err := d.Set("quantity", formation.Formation.Quantity); err != nil {
+		return setAttributeError("quantity", err)
+	}
+	if err := d.Set("size", formation.Formation.Size); err != nil {
+		return setAttributeError("size", err)
+	}
 
-	return err
+	return nil
 }
 
 // resourceHerokuFormationCreate method will execute an UPDATE to the formation.
@@ -102,11 +132,10 @@ func resourceHerokuFormationCreate(d *schema.ResourceData, meta interface{}) err
 		opts.Size = &vs
 	}
 
-	if v, ok := d.GetOk("quantity"); ok {
-		vs := v.(int)
-		log.Printf("[DEBUG] Quantity: %v", vs)
-		opts.Quantity = &vs
-	}
+	// Quantity is always set, as GetOk would skip scaling to 0 dynos.
+	quantity := d.Get("quantity").(int)
+	log.Printf("[DEBUG] Quantity: %v", quantity)
+	opts.Quantity = &quantity
 
 	log.Printf(fmt.Sprintf("[DEBUG] Updating %s formation...", appName))
 	f, err := client.FormationUpdate(context.TODO(), appName, getFormationType(d), opts)
@@ -117,6 +146,12 @@ func resourceHerokuFormationCreate(d *schema.ResourceData, meta interface{}) err
 	d.SetId(f.ID)
 	log.Printf("[INFO] Formation ID: %s", d.Id())
 
+	if d.Get("wait_for_dynos_up").(bool) {
+		if err := waitForFormationDynosUp(client, f, d.Timeout(schema.TimeoutCreate), schema.TimeoutCreate); err != nil {
+			return taintedCreateError(fmt.Sprintf("Formation %s", f.Type), err)
+		}
+	}
+
 	return resourceHerokuFormationRead(d, meta)
 }
 
@@ -156,6 +191,12 @@ func resourceHerokuFormationUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 	d.SetId(updatedFormation.ID)
 
+	if d.Get("wait_for_dynos_up").(bool) {
+		if err := waitForFormationDynosUp(client, updatedFormation, d.Timeout(schema.TimeoutUpdate), schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceHerokuFormationRead(d, meta)
@@ -167,6 +208,56 @@ func resourceHerokuFormationDelete(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
+// waitForFormationDynosUp waits up to timeout for as many dynos of the formation's
+// process type and size as its quantity to be up, whose duration is set by the
+// timeoutKey operation.
+func waitForFormationDynosUp(client *heroku.Service, f *heroku.Formation, timeout time.Duration, timeoutKey string) error {
+	log.Printf("[DEBUG] Waiting for %d %s dynos of app %s to be up", f.Quantity, f.Type, f.App.Name)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"up"},
+		Refresh: FormationDynosStateRefreshFunc(client, f.App.ID, f.Type, f.Size, f.Quantity),
+		Timeout: timeout,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return stateWaitError(fmt.Sprintf("%d %s dynos of app %s to be up", f.Quantity, f.Type, f.App.Name), timeoutKey, err)
+	}
+
+	return nil
+}
+
+// FormationDynosStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch the dynos of a process type, which are up once quantity dynos of the
+// given size are.
+func FormationDynosStateRefreshFunc(client *heroku.Service, appID, processType, size string, quantity int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		dynos, err := client.DynoList(context.TODO(), appID, &heroku.ListRange{Field: "name", Max: 1000})
+		if err != nil {
+			return nil, "", err
+		}
+
+		up := 0
+		states := make(map[string]int)
+		for _, dyno := range dynos {
+			if dyno.Type != processType {
+				continue
+			}
+			states[dyno.State]++
+			if dyno.State == "up" && strings.EqualFold(dyno.Size, size) {
+				up++
+			}
+		}
+
+		if up < quantity {
+			log.Printf("[DEBUG] %d of %d %s dynos are up: %v", up, quantity, processType, states)
+			return dynos, "pending", nil
+		}
+
+		return dynos, "up", nil
+	}
+}
+
 func getFormationType(d *schema.ResourceData) string {
 	var formationType string
 	if v, ok := d.GetOk("type"); ok {
@@ -184,7 +275,7 @@ func resourceHerokuFormationRetrieve(id string, appName string, client *heroku.S
 	err := formation.GetInfo(appName)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving formation: %s", err)
+		return nil, fmt.Errorf("error retrieving formation: %w", err)
 	}
 
 	return &formation, nil
@@ -225,14 +316,155 @@ func resourceHerokuFormationImport(d *schema.ResourceData, meta interface{}) ([]
 	}
 
 	d.SetId(formation.ID)
-	d.Set("app", formation.App.Name)
-	d.Set("type", formation.Type)
-	d.Set("quantity", formation.Quantity)
-	d.Set("size", formation.Size)
+	if err := d.Set("app", formation.App.Name); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("type", formation.Type); err != nil {
+		return nil, setAttributeError("type", err)
+	}
+	if err := d.Set("quantity", formation.Quantity); err != nil {
+		return nil, setAttributeError("quantity", err)
+	}
+	if err := d.Set("size", formation.Size); err != nil {
+		return nil, setAttributeError("size", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
 
+// firDynoSizeRegexp matches the dyno sizes of Fir generation apps, e.g. "dyno-1c-0.5gb".
+var firDynoSizeRegexp = regexp.MustCompile(`(?i)^dyno-\d+c-[\d.]+gb$`)
+
+// resourceHerokuFormationCustomizeDiff checks that the planned dyno size belongs to
+// the generation of the app, as Cedar and Fir apps have distinct dyno sizes, and
+// that the formation respects the limits of its dyno tier.
+func resourceHerokuFormationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	appName := diff.Get("app").(string)
+	if appName == "" || !diff.NewValueKnown("app") || !diff.NewValueKnown("type") {
+		return nil
+	}
+	if !diff.HasChange("size") && !diff.HasChange("quantity") {
+		return nil
+	}
+	if !diff.NewValueKnown("size") || !diff.NewValueKnown("quantity") {
+		return nil
+	}
+
+	quantity := diff.Get("quantity").(int)
+	size := diff.Get("size").(string)
+	planned := formationUpdate{Type: diff.Get("type").(string), Quantity: &quantity, Size: &size}
+	if err := validateFormationQuantity(planned); err != nil {
+		return err
+	}
+
+	client := v.(*Config).Api
+	app, err := retrieveAppWithGeneration(appName, client)
+	if err != nil {
+		// The app may not exist yet. Any real problem will surface at apply.
+		log.Printf("[DEBUG] Skipping formation size validation for app %s: %s", appName, err)
+		return nil
+	}
+
+	if diff.HasChange("size") {
+		if err := validateFormationSize(app, appName, size); err != nil {
+			return err
+		}
+	}
+
+	formations, err := client.FormationList(ctx, app.ID, &heroku.ListRange{Field: "type", Max: 1000})
+	if err != nil {
+		log.Printf("[DEBUG] Skipping formation tier validation for app %s: %s", appName, err)
+		return nil
+	}
+
+	return validateFormationTiers(appName, []formationUpdate{planned}, formations)
+}
+
+// validateFormationSize checks that a dyno size belongs to the generation of the app.
+func validateFormationSize(app *appWithGeneration, appName, size string) error {
+	isFirSize := firDynoSizeRegexp.MatchString(size)
+	switch app.GenerationName() {
+	case "fir":
+		if !isFirSize {
+			return fmt.Errorf("size %q is not a Fir dyno size, which app %s requires, e.g. dyno-1c-0.5gb", size, appName)
+		}
+	default:
+		if isFirSize {
+			return fmt.Errorf("size %q is a Fir dyno size, which cannot be used by app %s of the %s generation", size, appName, app.GenerationName())
+		}
+	}
+
+	return nil
+}
+
+// dynoTier returns the tier of a dyno size: "eco", "basic" or "professional".
+// Free and hobby are the retired sizes of the eco and basic tiers.
+func dynoTier(size string) string {
+	switch strings.ToLower(strings.SplitN(size, "-", 2)[0]) {
+	case "eco", "free":
+		return "eco"
+	case "basic", "hobby":
+		return "basic"
+	default:
+		return "professional"
+	}
+}
+
+// validateFormationQuantity checks that an eco or basic process type does not run
+// more than one dyno, which Heroku rejects.
+func validateFormationQuantity(p formationUpdate) error {
+	if p.Quantity == nil || p.Size == nil || *p.Quantity <= 1 {
+		return nil
+	}
+
+	if tier := dynoTier(*p.Size); tier != "professional" {
+		return fmt.Errorf("process type %s cannot run %d %s dynos, as %s dynos are limited to 1 of each process type",
+			p.Type, *p.Quantity, *p.Size, tier)
+	}
+
+	return nil
+}
+
+// validateFormationTiers checks that applying the planned updates to the current
+// formations of an app would not run dynos of different tiers, which Heroku
+// rejects. Process types scaled to 0 dynos are not running, so are ignored.
+func validateFormationTiers(appName string, planned []formationUpdate, current []heroku.Formation) error {
+	sizes := make(map[string]string)
+	for _, f := range current {
+		if f.Quantity > 0 {
+			sizes[f.Type] = f.Size
+		}
+	}
+	for _, p := range planned {
+		if p.Quantity == nil {
+			continue
+		}
+		delete(sizes, p.Type)
+		if *p.Quantity > 0 && p.Size != nil {
+			sizes[p.Type] = *p.Size
+		}
+	}
+
+	tiers := make(map[string][]string)
+	for processType, size := range sizes {
+		tier := dynoTier(size)
+		tiers[tier] = append(tiers[tier], processType)
+	}
+	if len(tiers) <= 1 {
+		return nil
+	}
+
+	var found []string
+	for tier, types := range tiers {
+		sort.Strings(types)
+		found = append(found, fmt.Sprintf("%s (%s)", tier, strings.Join(types, ", ")))
+	}
+	sort.Strings(found)
+
+	return fmt.Errorf("app %s cannot run dynos of different tiers at once, found %s; "+
+		"scale the other process types to 0 first", appName, strings.Join(found, " and "))
+}
+
 // Guarantees a consistent format for the string that describes the
 // size of a dyno. A formation's size can be "free" or "standard-1x"
 // or "Private-M".
@@ -259,6 +491,11 @@ func formatSize(quant interface{}) string {
 		return ""
 	}
 
+	// Fir dyno sizes are lowercase
+	if firDynoSizeRegexp.MatchString(rawQuant) {
+		return strings.ToLower(rawQuant)
+	}
+
 	// Capitalise the first descriptor, uppercase the remaining descriptors
 	var formattedSlice []string
 	s := strings.Split(rawQuant, "-")
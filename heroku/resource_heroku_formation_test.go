@@ -3,6 +3,8 @@ package heroku
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -148,3 +150,120 @@ resource "heroku_formation" "foobar-web" {
 }
 `, appName, slugId, dynoSize, dynoQuant)
 }
+
+func TestFormatSize(t *testing.T) {
+	cases := map[string]string{
+		"standard-1x":   "Standard-1X",
+		"Private-M":     "Private-M",
+		"performance-l": "Performance-L",
+		"dyno-1c-0.5gb": "dyno-1c-0.5gb",
+		"Dyno-2C-4GB":   "dyno-2c-4gb",
+	}
+
+	for size, expected := range cases {
+		if actual := formatSize(size); actual != expected {
+			t.Errorf("formatSize(%q) = %q, expected %q", size, actual, expected)
+		}
+	}
+}
+
+func TestFormationDynosStateRefreshFunc(t *testing.T) {
+	dynos := `[
+		{"name": "web.1", "type": "web", "size": "Standard-2X", "state": "up"},
+		{"name": "web.2", "type": "web", "size": "Standard-2X", "state": "crashed"},
+		{"name": "web.3", "type": "web", "size": "Standard-1X", "state": "up"},
+		{"name": "worker.1", "type": "worker", "size": "Standard-2X", "state": "up"}
+	]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(dynos))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		size     string
+		quantity int
+		expected string
+	}{
+		{"standard-2x", 1, "up"},
+		{"standard-2x", 2, "pending"},
+		{"standard-1x", 1, "up"},
+		{"standard-1x", 0, "up"},
+	}
+
+	for _, tc := range cases {
+		_, state, err := FormationDynosStateRefreshFunc(config.Api, "some-app", "web", tc.size, tc.quantity)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state != tc.expected {
+			t.Errorf("Expected %d %s web dynos to be %s, got %s", tc.quantity, tc.size, tc.expected, state)
+		}
+	}
+}
+
+func TestValidateFormationQuantity(t *testing.T) {
+	cases := []struct {
+		size     string
+		quantity int
+		valid    bool
+	}{
+		{"eco", 1, true},
+		{"eco", 2, false},
+		{"Basic", 0, true},
+		{"basic", 3, false},
+		{"hobby", 2, false},
+		{"standard-1x", 5, true},
+		{"dyno-1c-0.5gb", 5, true},
+	}
+
+	for _, tc := range cases {
+		quantity, size := tc.quantity, tc.size
+		err := validateFormationQuantity(formationUpdate{Type: "web", Quantity: &quantity, Size: &size})
+		if (err == nil) != tc.valid {
+			t.Errorf("%d %s dynos: expected valid %t, got error %v", tc.quantity, tc.size, tc.valid, err)
+		}
+	}
+}
+
+func TestValidateFormationTiers(t *testing.T) {
+	current := []heroku.Formation{
+		{Type: "web", Quantity: 1, Size: "Basic"},
+		{Type: "worker", Quantity: 0, Size: "Performance-L"},
+	}
+	update := func(processType string, quantity int, size string) formationUpdate {
+		return formationUpdate{Type: processType, Quantity: &quantity, Size: &size}
+	}
+
+	cases := []struct {
+		name    string
+		planned []formationUpdate
+		valid   bool
+	}{
+		{"same tier", []formationUpdate{update("clock", 1, "basic")}, true},
+		{"scaled down other tier", []formationUpdate{update("worker", 0, "performance-l")}, true},
+		{"mixed tiers", []formationUpdate{update("worker", 1, "performance-l")}, false},
+		{"eco with basic", []formationUpdate{update("clock", 1, "eco")}, false},
+		{"replaced tier", []formationUpdate{update("web", 1, "standard-1x"), update("worker", 2, "performance-l")}, true},
+		{"scale to zero", []formationUpdate{{Type: "web", Quantity: new(int)}, update("worker", 1, "performance-l")}, true},
+	}
+
+	for _, tc := range cases {
+		err := validateFormationTiers("some-app", tc.planned, current)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: expected valid %t, got error %v", tc.name, tc.valid, err)
+		}
+	}
+
+	err := validateFormationTiers("some-app", []formationUpdate{update("worker", 1, "performance-l")}, current)
+	expected := "app some-app cannot run dynos of different tiers at once, found basic (web) and professional (worker); " +
+		"scale the other process types to 0 first"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
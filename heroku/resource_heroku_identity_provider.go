@@ -0,0 +1,231 @@
+package heroku
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuIdentityProviderCreate,
+		ReadContext:   resourceHerokuIdentityProviderRead,
+		UpdateContext: resourceHerokuIdentityProviderUpdate,
+		DeleteContext: resourceHerokuIdentityProviderDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The certificate is updated in place, so that it can be rotated without
+			// recreating the identity provider and breaking SSO of the team's members.
+			"certificate": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentCertificateChain,
+				ValidateFunc:     validateIdentityProviderCertificate,
+			},
+
+			"entity_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"sso_target_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+
+			"slo_target_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+
+			"certificate_expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuIdentityProviderCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Get("team").(string)
+
+	opts := heroku.IdentityProviderCreateByTeamOpts{
+		Certificate:  d.Get("certificate").(string),
+		EntityID:     d.Get("entity_id").(string),
+		SsoTargetURL: d.Get("sso_target_url").(string),
+	}
+
+	if v, ok := d.GetOk("slo_target_url"); ok {
+		vs := v.(string)
+		opts.SloTargetURL = &vs
+	}
+
+	log.Printf("[DEBUG] Creating identity provider for team %s", team)
+	idp, err := client.IdentityProviderCreateByTeam(ctx, team, opts)
+	if err != nil {
+		return diag.Errorf("Error creating identity provider for team %s: %s", team, err)
+	}
+
+	d.SetId(buildCompositeID(team, idp.ID))
+
+	return resourceHerokuIdentityProviderRead(ctx, d, meta)
+}
+
+func resourceHerokuIdentityProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team, id, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	idps, err := client.IdentityProviderListByTeam(ctx, team, nil)
+	if err != nil {
+		if removeNotFound(d, "Identity provider", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving identity providers of team %s: %s", team, err)
+	}
+
+	var idp *heroku.IdentityProvider
+	for i := range idps {
+		if idps[i].ID == id {
+			idp = &idps[i]
+			break
+		}
+	}
+
+	if idp == nil {
+		log.Printf("[WARN] Identity provider %s of team %s not found, removing from state", id, team)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("team", team); err != nil {
+		return setAttributeDiagnostics("team", err)
+	}
+	if err := d.Set("certificate", idp.Certificate); err != nil {
+		return setAttributeDiagnostics("certificate", err)
+	}
+	if err := d.Set("entity_id", idp.EntityID); err != nil {
+		return setAttributeDiagnostics("entity_id", err)
+	}
+	if err := d.Set("sso_target_url", idp.SsoTargetURL); err != nil {
+		return setAttributeDiagnostics("sso_target_url", err)
+	}
+	if err := d.Set("slo_target_url", idp.SloTargetURL); err != nil {
+		return setAttributeDiagnostics("slo_target_url", err)
+	}
+
+	if err := d.Set("certificate_expires_at", ""); err != nil {
+		return setAttributeDiagnostics("certificate_expires_at", err)
+	}
+	if err := d.Set("certificate_fingerprint", ""); err != nil {
+		return setAttributeDiagnostics("certificate_fingerprint", err)
+	}
+	if certs, err := parseCertificateChain(idp.Certificate); err == nil {
+		leaf := certificateChainLeaf(certs)
+		if err := d.Set("certificate_expires_at", leaf.NotAfter.UTC().Format(time.RFC3339)); err != nil {
+			return setAttributeDiagnostics("certificate_expires_at", err)
+		}
+		if err := d.Set("certificate_fingerprint", fmt.Sprintf("%x", sha256.Sum256(leaf.Raw))); err != nil {
+			return setAttributeDiagnostics("certificate_fingerprint", err)
+		}
+	} else {
+		log.Printf("[WARN] Error parsing certificate of identity provider %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func resourceHerokuIdentityProviderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team, id, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := heroku.IdentityProviderUpdateByTeamOpts{}
+
+	if d.HasChange("certificate") {
+		vs := d.Get("certificate").(string)
+		log.Printf("[INFO] Rotating certificate of identity provider %s of team %s", id, team)
+		opts.Certificate = &vs
+	}
+
+	if d.HasChange("entity_id") {
+		vs := d.Get("entity_id").(string)
+		opts.EntityID = &vs
+	}
+
+	if d.HasChange("sso_target_url") {
+		vs := d.Get("sso_target_url").(string)
+		opts.SsoTargetURL = &vs
+	}
+
+	if d.HasChange("slo_target_url") {
+		vs := d.Get("slo_target_url").(string)
+		opts.SloTargetURL = &vs
+	}
+
+	if _, err := client.IdentityProviderUpdateByTeam(ctx, team, id, opts); err != nil {
+		return diag.Errorf("Error updating identity provider %s of team %s: %s", id, team, err)
+	}
+
+	return resourceHerokuIdentityProviderRead(ctx, d, meta)
+}
+
+func resourceHerokuIdentityProviderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team, id, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting identity provider %s of team %s", id, team)
+	if _, err := client.IdentityProviderDeleteByTeam(ctx, team, id); err != nil {
+		return diag.Errorf("Error deleting identity provider %s of team %s: %s", id, team, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// validateIdentityProviderCertificate checks that the certificate is a PEM
+// encoded X.509 certificate, to fail at plan time rather than at apply.
+func validateIdentityProviderCertificate(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseCertificateChain(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a PEM encoded X.509 certificate: %s", k, err))
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuIdentityProvider_Basic(t *testing.T) {
+	// Identity providers are only available to Heroku Enterprise teams.
+	team := testAccConfig.GetSpaceOrganizationOrSkip(t)
+	certificate, _ := generateTestCertificate(t, "idp.example.com", false, "", nil)
+	rotatedCertificate, _ := generateTestCertificate(t, "idp.example.com", false, "", nil)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuIdentityProviderConfig(team, certificate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_identity_provider.foobar", "entity_id", "https://idp.example.com/saml"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_identity_provider.foobar", "certificate_expires_at"),
+					resource.TestCheckResourceAttrSet(
+						"heroku_identity_provider.foobar", "certificate_fingerprint"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuIdentityProviderConfig(team, rotatedCertificate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"heroku_identity_provider.foobar", "certificate_fingerprint"),
+				),
+			},
+			{
+				ResourceName:      "heroku_identity_provider.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuIdentityProviderConfig(team, certificate string) string {
+	return fmt.Sprintf(`
+resource "heroku_identity_provider" "foobar" {
+  team           = "%s"
+  certificate    = <<EOT
+%sEOT
+  entity_id      = "https://idp.example.com/saml"
+  sso_target_url = "https://idp.example.com/saml/sso"
+  slo_target_url = "https://idp.example.com/saml/slo"
+}
+`, team, certificate)
+}
@@ -0,0 +1,272 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// kafkaConnector is a Heroku Data Connector of the Postgres API, streaming the
+// changes of Postgres tables into Kafka topics.
+type kafkaConnector struct {
+	UUID            string   `json:"uuid"`
+	Name            string   `json:"name"`
+	Status          string   `json:"status"`
+	Tables          []string `json:"tables"`
+	ExcludedColumns []string `json:"excluded_columns"`
+	Topics          []string `json:"topics"`
+	PostgresAddon   struct {
+		ID string `json:"id"`
+	} `json:"postgres_addon"`
+	KafkaAddon struct {
+		ID string `json:"id"`
+	} `json:"kafka_addon"`
+}
+
+type kafkaConnectorCreateOpts struct {
+	Name            *string  `json:"name,omitempty"`
+	PlatformID      string   `json:"platform_id"`
+	Tables          []string `json:"tables"`
+	ExcludedColumns []string `json:"excluded_columns,omitempty"`
+}
+
+func resourceHerokuKafkaConnector() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuKafkaConnectorCreate,
+		ReadContext:   resourceHerokuKafkaConnectorRead,
+		UpdateContext: resourceHerokuKafkaConnectorUpdate,
+		DeleteContext: resourceHerokuKafkaConnectorDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"postgres_addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"kafka_addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"tables": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^.]+\.[^.]+$`), "must be a schema qualified table, e.g. public.users"),
+				},
+			},
+
+			"excluded_columns": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^.]+\.[^.]+\.[^.]+$`), "must be a schema qualified column, e.g. public.users.password"),
+				},
+			},
+
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"topics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	postgresAddonID := d.Get("postgres_addon_id").(string)
+	opts := kafkaConnectorCreateOpts{
+		PlatformID: d.Get("kafka_addon_id").(string),
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		vs := v.(string)
+		opts.Name = &vs
+	}
+
+	for _, t := range d.Get("tables").(*schema.Set).List() {
+		opts.Tables = append(opts.Tables, t.(string))
+	}
+
+	for _, c := range d.Get("excluded_columns").(*schema.Set).List() {
+		opts.ExcludedColumns = append(opts.ExcludedColumns, c.(string))
+	}
+
+	log.Printf("[DEBUG] Creating Kafka connector of Postgres database %s: %#v", postgresAddonID, opts)
+	var connector kafkaConnector
+	path := fmt.Sprintf("/data/cdc/v0/databases/%s/connectors", postgresAddonID)
+	if err := client.Post(ctx, &connector, path, opts); err != nil {
+		return diag.Errorf("Error creating Kafka connector of Postgres database %s: %s", postgresAddonID, err)
+	}
+
+	d.SetId(connector.UUID)
+
+	log.Printf("[DEBUG] Waiting for Kafka connector (%s) to be available", connector.UUID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    KafkaConnectorStateRefreshFunc(client, connector.UUID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("Kafka connector %s", connector.UUID), fmt.Errorf("Error waiting for Kafka connector (%s) to be available: %s", connector.UUID, err)))
+	}
+
+	if d.Get("paused").(bool) {
+		if err := setKafkaConnectorPaused(ctx, client, connector.UUID, true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[INFO] Created Kafka connector ID: %s", d.Id())
+
+	return resourceHerokuKafkaConnectorRead(ctx, d, meta)
+}
+
+func resourceHerokuKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	var connector kafkaConnector
+	if err := client.Get(ctx, &connector, kafkaConnectorPath(d.Id()), nil, nil); err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Kafka connector %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error retrieving Kafka connector %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("postgres_addon_id", connector.PostgresAddon.ID); err != nil {
+		return setAttributeDiagnostics("postgres_addon_id", err)
+	}
+	if err := d.Set("kafka_addon_id", connector.KafkaAddon.ID); err != nil {
+		return setAttributeDiagnostics("kafka_addon_id", err)
+	}
+	if err := d.Set("name", connector.Name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("tables", connector.Tables); err != nil {
+		return setAttributeDiagnostics("tables", err)
+	}
+	if err := d.Set("excluded_columns", connector.ExcludedColumns); err != nil {
+		return setAttributeDiagnostics("excluded_columns", err)
+	}
+	if err := d.Set("status", connector.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("paused", connector.Status == "paused"); err != nil {
+		return setAttributeDiagnostics("paused", err)
+	}
+	if err := d.Set("topics", connector.Topics); err != nil {
+		return setAttributeDiagnostics("topics", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuKafkaConnectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	if d.HasChange("paused") {
+		if err := setKafkaConnectorPaused(ctx, client, d.Id(), d.Get("paused").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceHerokuKafkaConnectorRead(ctx, d, meta)
+}
+
+func resourceHerokuKafkaConnectorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	log.Printf("[INFO] Deleting Kafka connector: %s", d.Id())
+	if err := client.Delete(ctx, nil, kafkaConnectorPath(d.Id())); err != nil {
+		return diag.Errorf("Error deleting Kafka connector: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// setKafkaConnectorPaused pauses or resumes the streaming of a Kafka connector.
+func setKafkaConnectorPaused(ctx context.Context, client *heroku.Service, id string, paused bool) error {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+
+	log.Printf("[DEBUG] Running %s of Kafka connector %s", action, id)
+	if err := client.Post(ctx, nil, fmt.Sprintf("%s/%s", kafkaConnectorPath(id), action), nil); err != nil {
+		return fmt.Errorf("Error running %s of Kafka connector %s: %s", action, id, err)
+	}
+
+	return nil
+}
+
+// KafkaConnectorStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a Kafka connector.
+func KafkaConnectorStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var connector kafkaConnector
+		if err := client.Get(context.TODO(), &connector, kafkaConnectorPath(id), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get Kafka connector status: %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		return connector, connector.Status, nil
+	}
+}
+
+func kafkaConnectorPath(id string) string {
+	return fmt.Sprintf("/data/cdc/v0/connectors/%s", id)
+}
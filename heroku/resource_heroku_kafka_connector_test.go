@@ -0,0 +1,78 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuKafkaConnector_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	spaceName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuKafkaConnectorConfig(spaceName, appName, org, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_kafka_connector.foobar", "status", "available"),
+					resource.TestCheckResourceAttr("heroku_kafka_connector.foobar", "tables.#", "1"),
+					resource.TestCheckResourceAttrSet("heroku_kafka_connector.foobar", "name"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuKafkaConnectorConfig(spaceName, appName, org, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_kafka_connector.foobar", "status", "paused"),
+					resource.TestCheckResourceAttr("heroku_kafka_connector.foobar", "paused", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuKafkaConnectorConfig(spaceName, appName, org string, paused bool) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  space  = heroku_space.foobar.name
+  region = "virginia"
+
+  organization {
+    name = "%s"
+  }
+}
+
+resource "heroku_addon" "database" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:private-7"
+}
+
+resource "heroku_addon" "kafka" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-kafka:private-extended-2"
+}
+
+resource "heroku_kafka_connector" "foobar" {
+  postgres_addon_id = heroku_addon.database.id
+  kafka_addon_id    = heroku_addon.kafka.id
+  tables            = ["public.users"]
+  excluded_columns  = ["public.users.password"]
+  paused            = %t
+}
+`, spaceName, org, appName, org, paused)
+}
@@ -66,7 +66,9 @@ func resourceHerokuPipelineImport(d *schema.ResourceData, meta interface{}) ([]*
 	}
 
 	d.SetId(p.ID)
-	setPipelineAttributes(d, p)
+	if err := setPipelineAttributes(d, p); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -83,7 +85,7 @@ func resourceHerokuPipelineCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// If the owner is set, use it. Otherwise, pipeline ownership will default
-	// to the authenticated user for this provider.
+	// to the provider's default team, or the authenticated user for this provider.
 	opts.Owner = (*struct {
 		ID   string `json:"id" url:"id,key"`
 		Type string `json:"type" url:"type,key"`
@@ -100,6 +102,14 @@ func resourceHerokuPipelineCreate(d *schema.ResourceData, meta interface{}) erro
 
 		opts.Owner.ID = ownerID
 		opts.Owner.Type = ownerType
+	} else if team := meta.(*Config).DefaultTeam; team != "" {
+		t, err := client.TeamInfo(context.TODO(), team)
+		if err != nil {
+			return fmt.Errorf("Error retrieving default team %s: %s", team, err)
+		}
+
+		opts.Owner.ID = t.ID
+		opts.Owner.Type = "team"
 	} else {
 		authUser, authGetUserErr := client.AccountInfo(context.TODO())
 		if authGetUserErr != nil {
@@ -165,19 +175,26 @@ func resourceHerokuPipelineRead(d *schema.ResourceData, meta interface{}) error
 
 	p, err := client.PipelineInfo(context.TODO(), d.Id())
 	if err != nil {
+		if removeNotFound(d, "Pipeline", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving pipeline: %s", err)
 	}
 
-	setPipelineAttributes(d, p)
-
-	return nil
+	return setPipelineAttributes(d, p)
 }
 
-func setPipelineAttributes(d *schema.ResourceData, p *heroku.Pipeline) {
-	d.Set("name", p.Name)
+func setPipelineAttributes(d *schema.ResourceData, p *heroku.Pipeline) error {
+	if err := d.Set("name", p.Name); err != nil {
+		return setAttributeError("name", err)
+	}
 
 	ownerInfo := make(map[string]string)
 	ownerInfo["id"] = p.Owner.ID
 	ownerInfo["type"] = p.Owner.Type
-	d.Set("owner", []interface{}{ownerInfo})
+	if err := d.Set("owner", []interface{}{ownerInfo}); err != nil {
+		return setAttributeError("owner", err)
+	}
+
+	return nil
 }
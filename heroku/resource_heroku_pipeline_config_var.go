@@ -140,6 +140,9 @@ func resourceHerokuPipelineConfigVarRead(d *schema.ResourceData, meta interface{
 
 	remotePipelineVars, getErr := client.PipelineConfigVarInfoForApp(context.TODO(), pipelineID, pipelineStage)
 	if getErr != nil {
+		if removeNotFound(d, "Pipeline config vars", getErr) {
+			return nil
+		}
 		return getErr
 	}
 
@@ -154,14 +157,23 @@ func resourceHerokuPipelineConfigVarRead(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] pipeline config vars to be set in state: *%#v", vettedConfigVars)
 	log.Printf("[DEBUG] pipeline sensitive config vars to be set in state: *%#v", vettedSensitiveConfigVars)
 
-	var setErr error
-	setErr = d.Set("pipeline_id", pipelineID)
-	setErr = d.Set("pipeline_stage", pipelineStage)
-	setErr = d.Set("vars", vettedConfigVars)
-	setErr = d.Set("sensitive_vars", vettedSensitiveConfigVars)
-	setErr = d.Set("all_vars", rpvFormatted)
+	if err := d.Set("pipeline_id", pipelineID); err != nil {
+		return setAttributeError("pipeline_id", err)
+	}
+	if err := d.Set("pipeline_stage", pipelineStage); err != nil {
+		return setAttributeError("pipeline_stage", err)
+	}
+	if err := d.Set("vars", vettedConfigVars); err != nil {
+		return setAttributeError("vars", err)
+	}
+	if err := d.Set("sensitive_vars", vettedSensitiveConfigVars); err != nil {
+		return setAttributeError("sensitive_vars", err)
+	}
+	if err := d.Set("all_vars", rpvFormatted); err != nil {
+		return setAttributeError("all_vars", err)
+	}
 
-	return setErr
+	return nil
 }
 
 func resourceHerokuPipelineConfigVarDelete(d *schema.ResourceData, meta interface{}) error {
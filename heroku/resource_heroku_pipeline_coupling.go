@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -69,6 +70,9 @@ func resourceHerokuPipelineCouplingCreate(d *schema.ResourceData, meta interface
 
 	log.Printf("[INFO] PipelineCoupling ID: %s", d.Id())
 
+	config := meta.(*Config)
+	time.Sleep(time.Duration(config.PostPipelineCouplingCreateDelay) * time.Second)
+
 	return resourceHerokuPipelineCouplingRead(d, meta)
 }
 
@@ -90,6 +94,9 @@ func resourceHerokuPipelineCouplingRead(d *schema.ResourceData, meta interface{}
 
 	p, err := client.PipelineCouplingInfo(context.TODO(), d.Id())
 	if err != nil {
+		if removeNotFound(d, "Pipeline coupling", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving pipeline: %s", err)
 	}
 
@@ -98,12 +105,20 @@ func resourceHerokuPipelineCouplingRead(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		log.Printf("[WARN] Error looking up addional App info for pipeline coupling (%s): %s", d.Id(), err)
 	} else {
-		d.Set("app", app.Name)
+		if err := d.Set("app", app.Name); err != nil {
+			return setAttributeError("app", err)
+		}
 	}
 
-	d.Set("app_id", p.App.ID)
-	d.Set("stage", p.Stage)
-	d.Set("pipeline", p.Pipeline.ID)
+	if err := d.Set("app_id", p.App.ID); err != nil {
+		return setAttributeError("app_id", err)
+	}
+	if err := d.Set("stage", p.Stage); err != nil {
+		return setAttributeError("stage", err)
+	}
+	if err := d.Set("pipeline", p.Pipeline.ID); err != nil {
+		return setAttributeError("pipeline", err)
+	}
 
 	return nil
 }
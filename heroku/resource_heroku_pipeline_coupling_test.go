@@ -3,14 +3,56 @@ package heroku
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
+func TestResourceHerokuPipelineCouplingCreate_PostPipelineCouplingCreateDelay(t *testing.T) {
+	coupling := `{"id": "coupling-id", "stage": "staging", "app": {"id": "app-id"}, "pipeline": {"id": "pipeline-id"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/pipeline-couplings",
+			r.Method == http.MethodGet && r.URL.Path == "/pipeline-couplings/coupling-id":
+			w.Write([]byte(coupling))
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/app-id":
+			w.Write([]byte(`{"id": "app-id", "name": "some-app"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PostPipelineCouplingCreateDelay = 1
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuPipelineCoupling().Schema, map[string]interface{}{
+		"app":      "some-app",
+		"pipeline": "pipeline-id",
+		"stage":    "staging",
+	})
+
+	start := time.Now()
+	if err := resourceHerokuPipelineCouplingCreate(d, config); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the pipeline coupling to be created after a 1s delay, took %s", elapsed)
+	}
+}
+
 func TestAccHerokuPipelineCoupling_Basic(t *testing.T) {
 	var coupling heroku.PipelineCoupling
 
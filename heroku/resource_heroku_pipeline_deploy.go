@@ -0,0 +1,442 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// resourceHerokuPipelineDeploy is a staged deploy: the source is built on a staging
+// app, the resulting release is promoted to a target app in the same pipeline, the
+// target's formation is scaled and its health is verified. When the target fails
+// after the promotion, it is rolled back to the release it ran before the deploy.
+func resourceHerokuPipelineDeploy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuPipelineDeployCreate,
+		ReadContext:   resourceHerokuPipelineDeployRead,
+		UpdateContext: resourceHerokuPipelineDeployUpdate,
+		DeleteContext: resourceHerokuPipelineDeployDelete,
+		CustomizeDiff: resourceHerokuPipelineDeployCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"staging_app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"target_app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source_url", "source_path"},
+				ValidateFunc: validateSourceUrl,
+			},
+
+			"source_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"formation": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"quantity": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"size": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"health_check": healthCheckSchema(),
+
+			"rollback_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"local_checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"build_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"staging_release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"promotion_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"previous_release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuPipelineDeployCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	path, ok := diff.GetOk("source_path")
+	if !ok {
+		return nil
+	}
+
+	// Deploy again when the content of the local source changes.
+	checksum, err := hashSourcePath(path.(string), nil)
+	if err != nil {
+		return err
+	}
+
+	if old := diff.Get("local_checksum").(string); old != checksum {
+		log.Printf("[DEBUG] Diffing deploy source: old '%s', real '%s'", old, checksum)
+		if err := diff.SetNew("local_checksum", checksum); err != nil {
+			return fmt.Errorf("Error updating source checksum: %s", err)
+		}
+		if diff.Id() != "" {
+			if err := diff.ForceNew("local_checksum"); err != nil {
+				return fmt.Errorf("Error forcing new deploy: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceHerokuPipelineDeployCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	stagingApp, err := client.AppInfo(ctx, d.Get("staging_app").(string))
+	if err != nil {
+		return diag.Errorf("Error retrieving staging app: %s", err)
+	}
+
+	targetApp, err := client.AppInfo(ctx, d.Get("target_app").(string))
+	if err != nil {
+		return diag.Errorf("Error retrieving target app: %s", err)
+	}
+
+	// Remember what the target runs, to roll back to it if the deploy fails.
+	previousReleaseID := ""
+	releases, err := client.ReleaseList(ctx, targetApp.ID, &heroku.ListRange{Descending: true, Field: "version", Max: 1})
+	if err != nil {
+		return diag.Errorf("Error retrieving current release of target app %s: %s", targetApp.Name, err)
+	}
+	if len(releases) > 0 {
+		previousReleaseID = releases[0].ID
+		if err := d.Set("previous_release_id", previousReleaseID); err != nil {
+			return setAttributeDiagnostics("previous_release_id", err)
+		}
+	}
+
+	// 1. Build on the staging app.
+	build, err := pipelineDeployBuild(ctx, d, client, stagingApp.ID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("build_id", build.ID); err != nil {
+		return setAttributeDiagnostics("build_id", err)
+	}
+	if err := d.Set("staging_release_id", build.Release.ID); err != nil {
+		return setAttributeDiagnostics("staging_release_id", err)
+	}
+
+	// 2. Promote the staging app's release to the target app.
+	releaseID, err := pipelineDeployPromote(ctx, d, client, stagingApp.ID, targetApp.ID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// 3. Scale the target & verify its health, rolling back on failure.
+	if err := pipelineDeployVerify(ctx, d, client, targetApp.ID, releaseID, timeout); err != nil {
+		if d.Get("rollback_on_failure").(bool) && previousReleaseID != "" {
+			return diag.FromErr(pipelineDeployRollback(ctx, client, targetApp, previousReleaseID, err))
+		}
+		// Track the failed release in state, so that the deploy is retried on the next apply.
+		d.SetId(releaseID)
+		return diag.FromErr(err)
+	}
+
+	d.SetId(releaseID)
+	log.Printf("[INFO] Deployed release %s to app %s", releaseID, targetApp.Name)
+
+	return resourceHerokuPipelineDeployRead(ctx, d, meta)
+}
+
+// pipelineDeployBuild builds the source on the staging app and waits for its release.
+func pipelineDeployBuild(ctx context.Context, d *schema.ResourceData, client *heroku.Service, appID string, timeout time.Duration) (*heroku.Build, error) {
+	opts := heroku.BuildCreateOpts{}
+
+	if v, ok := d.GetOk("source_path"); ok {
+		getURL, checksum, err := uploadSourcePath(client, v.(string), nil)
+		if err != nil {
+			return nil, err
+		}
+		opts.SourceBlob.URL = &getURL
+		opts.SourceBlob.Checksum = &checksum
+	} else {
+		vs := d.Get("source_url").(string)
+		opts.SourceBlob.URL = &vs
+	}
+
+	if v, ok := d.GetOk("source_version"); ok {
+		vs := v.(string)
+		opts.SourceBlob.Version = &vs
+	}
+
+	build, err := client.BuildCreate(ctx, appID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating build on staging app: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for Build (%s:%s) to complete", appID, build.ID)
+	buildStateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"succeeded"},
+		Refresh: BuildStateRefreshFunc(client, appID, build.ID),
+		Timeout: timeout,
+	}
+
+	if _, err := buildStateConf.WaitForState(); err != nil {
+		if buildErr, ok := err.(*buildFailedError); ok {
+			return nil, describeBuildFailure(buildErr, "")
+		}
+		return nil, err
+	}
+
+	build, err = client.BuildInfo(ctx, appID, build.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Error refreshing the completed build: %s", err)
+	}
+	if build.Release == nil {
+		return nil, fmt.Errorf("build %s did not create a release on the staging app", build.ID)
+	}
+
+	releaseStateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"succeeded"},
+		Refresh: releaseStateRefreshFunc(client, appID, build.Release.ID),
+		Timeout: timeout,
+	}
+
+	if _, err := releaseStateConf.WaitForState(); err != nil {
+		return nil, fmt.Errorf("Error waiting for staging release (%s) to succeed: %s", build.Release.ID, err)
+	}
+
+	return build, nil
+}
+
+// pipelineDeployPromote promotes the staging app's current release to the target app
+// and returns the ID of the target's new release.
+func pipelineDeployPromote(ctx context.Context, d *schema.ResourceData, client *heroku.Service, stagingAppID, targetAppID string, timeout time.Duration) (string, error) {
+	opts := heroku.PipelinePromotionCreateOpts{}
+	opts.Pipeline.ID = d.Get("pipeline").(string)
+	opts.Source.App = &struct {
+		ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+	}{ID: &stagingAppID}
+	opts.Targets = append(opts.Targets, struct {
+		App *struct {
+			ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+		} `json:"app,omitempty" url:"app,omitempty,key"`
+	}{App: &struct {
+		ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+	}{ID: &targetAppID}})
+
+	promotion, err := client.PipelinePromotionCreate(ctx, opts)
+	if err != nil {
+		return "", fmt.Errorf("Error promoting staging app: %s", err)
+	}
+	if err := d.Set("promotion_id", promotion.ID); err != nil {
+		return "", setAttributeError("promotion_id", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for pipeline promotion (%s) to complete", promotion.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"completed"},
+		Refresh: PipelinePromotionTargetsStateRefreshFunc(client, promotion.ID, len(opts.Targets)),
+		Timeout: timeout,
+	}
+
+	raw, err := stateConf.WaitForState()
+	if err != nil {
+		return "", err
+	}
+
+	targets := raw.(heroku.PipelinePromotionTargetListResult)
+	if err := pipelinePromotionFailure(targets); err != nil {
+		return "", err
+	}
+
+	for _, t := range targets {
+		if t.App.ID == targetAppID && t.Release != nil {
+			return t.Release.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("pipeline promotion %s did not create a release on the target app", promotion.ID)
+}
+
+// pipelineDeployVerify waits for the target's new release, scales its formation
+// and checks its health.
+func pipelineDeployVerify(ctx context.Context, d *schema.ResourceData, client *heroku.Service, appID, releaseID string, timeout time.Duration) error {
+	releaseStateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"succeeded"},
+		Refresh: releaseStateRefreshFunc(client, appID, releaseID),
+		Timeout: timeout,
+	}
+
+	if _, err := releaseStateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for target release (%s) to succeed: %s", releaseID, err)
+	}
+
+	if formations := d.Get("formation").([]interface{}); len(formations) > 0 {
+		opts := heroku.FormationBatchUpdateOpts{}
+		for _, f := range formations {
+			formation := f.(map[string]interface{})
+			quantity := formation["quantity"].(int)
+			update := struct {
+				Quantity *int    `json:"quantity,omitempty" url:"quantity,omitempty,key"`
+				Size     *string `json:"size,omitempty" url:"size,omitempty,key"`
+				Type     string  `json:"type" url:"type,key"`
+			}{Quantity: &quantity, Type: formation["type"].(string)}
+			if size := formation["size"].(string); size != "" {
+				update.Size = &size
+			}
+			opts.Updates = append(opts.Updates, update)
+		}
+
+		log.Printf("[DEBUG] Scaling target app %s: %#v", appID, opts)
+		if _, err := client.FormationBatchUpdate(ctx, appID, opts); err != nil {
+			return fmt.Errorf("Error scaling target app: %s", err)
+		}
+	}
+
+	return waitForHealthCheck(ctx, client, appID, d.Get("health_check"))
+}
+
+// pipelineDeployRollback rolls the target app back to its previous release after
+// the deploy failed with deployErr.
+func pipelineDeployRollback(ctx context.Context, client *heroku.Service, app *heroku.App, previousReleaseID string, deployErr error) error {
+	log.Printf("[WARN] Deploy to app %s failed, rolling back to release %s: %s", app.Name, previousReleaseID, deployErr)
+
+	rollback, err := client.ReleaseRollback(ctx, app.ID, heroku.ReleaseRollbackOpts{Release: previousReleaseID})
+	if err != nil {
+		return fmt.Errorf("%s\n\nError rolling back app %s to release %s: %s", deployErr, app.Name, previousReleaseID, err)
+	}
+
+	return fmt.Errorf("%s\n\nApp %s was rolled back to release %s as v%d", deployErr, app.Name, previousReleaseID, rollback.Version)
+}
+
+func resourceHerokuPipelineDeployRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	release, err := client.ReleaseInfo(ctx, d.Get("target_app").(string), d.Id())
+	if err != nil {
+		if removeNotFound(d, "Deployed release", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving deployed release: %s", err)
+	}
+
+	if err := d.Set("release_id", release.ID); err != nil {
+		return setAttributeDiagnostics("release_id", err)
+	}
+	if err := d.Set("version", release.Version); err != nil {
+		return setAttributeDiagnostics("version", err)
+	}
+
+	return nil
+}
+
+// resourceHerokuPipelineDeployUpdate only updates settings that affect how a new
+// deploy is verified, so the completed deploy is unchanged.
+func resourceHerokuPipelineDeployUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceHerokuPipelineDeployRead(ctx, d, meta)
+}
+
+// resourceHerokuPipelineDeployDelete only removes the deploy from state, as releases
+// cannot be deleted.
+func resourceHerokuPipelineDeployDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for pipeline deploy resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,103 @@
+package heroku
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuPipelineDeploy_Basic(t *testing.T) {
+	randString := acctest.RandString(10)
+	pipelineName := fmt.Sprintf("tftest-%s", randString)
+	stagingName := fmt.Sprintf("tftest-staging-%s", randString)
+	productionName := fmt.Sprintf("tftest-prod-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuPipelineDeployConfig_basic(pipelineName, stagingName, productionName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("heroku_pipeline_deploy.foobar", "build_id"),
+					resource.TestCheckResourceAttrSet("heroku_pipeline_deploy.foobar", "promotion_id"),
+					resource.TestCheckResourceAttrSet("heroku_pipeline_deploy.foobar", "previous_release_id"),
+					resource.TestCheckResourceAttrPair(
+						"heroku_pipeline_deploy.foobar", "release_id",
+						"heroku_pipeline_deploy.foobar", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+// The fixture app has no Procfile, so the health check fails and the target is rolled back.
+func TestAccHerokuPipelineDeploy_Rollback(t *testing.T) {
+	randString := acctest.RandString(10)
+	pipelineName := fmt.Sprintf("tftest-%s", randString)
+	stagingName := fmt.Sprintf("tftest-staging-%s", randString)
+	productionName := fmt.Sprintf("tftest-prod-%s", randString)
+	healthCheck := `
+  health_check {
+    path    = "/health"
+    timeout = 15
+  }
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuPipelineDeployConfig_basic(pipelineName, stagingName, productionName, healthCheck),
+				ExpectError: regexp.MustCompile(`was rolled back to release`),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPipelineDeployConfig_basic(pipelineName, stagingName, productionName, extra string) string {
+	return fmt.Sprintf(`
+resource "heroku_pipeline" "foobar" {
+  name = "%s"
+}
+
+resource "heroku_app" "staging" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_app" "production" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_pipeline_coupling" "staging" {
+  app      = heroku_app.staging.id
+  pipeline = heroku_pipeline.foobar.id
+  stage    = "staging"
+}
+
+resource "heroku_pipeline_coupling" "production" {
+  app      = heroku_app.production.id
+  pipeline = heroku_pipeline.foobar.id
+  stage    = "production"
+}
+
+resource "heroku_pipeline_deploy" "foobar" {
+  pipeline    = heroku_pipeline.foobar.id
+  staging_app = heroku_app.staging.id
+  target_app  = heroku_app.production.id
+  source_path = "test-fixtures/app"
+%s
+  depends_on = [
+    heroku_pipeline_coupling.staging,
+    heroku_pipeline_coupling.production,
+  ]
+}
+`, pipelineName, stagingName, productionName, extra)
+}
@@ -0,0 +1,288 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuPipelinePromotion() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuPipelinePromotionCreate,
+		ReadContext:   resourceHerokuPipelinePromotionRead,
+		UpdateContext: resourceHerokuPipelinePromotionUpdate,
+		DeleteContext: resourceHerokuPipelinePromotionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"targets": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"fail_on_target_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"release_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"target_release_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"promotion_targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"app_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"release_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"error_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuPipelinePromotionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	opts := heroku.PipelinePromotionCreateOpts{}
+	opts.Pipeline.ID = d.Get("pipeline").(string)
+
+	source := d.Get("source").(string)
+	opts.Source.App = &struct {
+		ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+	}{ID: &source}
+
+	for _, t := range d.Get("targets").(*schema.Set).List() {
+		target := t.(string)
+		opts.Targets = append(opts.Targets, struct {
+			App *struct {
+				ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+			} `json:"app,omitempty" url:"app,omitempty,key"`
+		}{App: &struct {
+			ID *string `json:"id,omitempty" url:"id,omitempty,key"`
+		}{ID: &target}})
+	}
+
+	log.Printf("[DEBUG] Creating pipeline promotion from app %s on pipeline %s", source, opts.Pipeline.ID)
+	promotion, err := client.PipelinePromotionCreate(ctx, opts)
+	if err != nil {
+		return diag.Errorf("Error creating pipeline promotion: %s", err)
+	}
+
+	d.SetId(promotion.ID)
+
+	log.Printf("[DEBUG] Waiting for pipeline promotion (%s) targets to complete", promotion.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"completed"},
+		Refresh: PipelinePromotionTargetsStateRefreshFunc(client, promotion.ID, len(opts.Targets)),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	raw, err := stateConf.WaitForState()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := resourceHerokuPipelinePromotionRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	if d.Get("fail_on_target_failure").(bool) {
+		if err := pipelinePromotionFailure(raw.(heroku.PipelinePromotionTargetListResult)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceHerokuPipelinePromotionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	promotion, err := client.PipelinePromotionInfo(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "Pipeline promotion", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving pipeline promotion: %s", err)
+	}
+
+	targets, err := client.PipelinePromotionTargetList(ctx, d.Id(), nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving pipeline promotion targets: %s", err)
+	}
+
+	if err := d.Set("pipeline", promotion.Pipeline.ID); err != nil {
+		return setAttributeDiagnostics("pipeline", err)
+	}
+	if err := d.Set("source", promotion.Source.App.ID); err != nil {
+		return setAttributeDiagnostics("source", err)
+	}
+	if err := d.Set("release_id", promotion.Source.Release.ID); err != nil {
+		return setAttributeDiagnostics("release_id", err)
+	}
+	if err := d.Set("status", promotion.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+
+	targetIDs := make([]string, 0, len(targets))
+	releaseIDs := make(map[string]string)
+	promotionTargets := make([]map[string]interface{}, 0, len(targets))
+	for _, t := range targets {
+		targetIDs = append(targetIDs, t.App.ID)
+
+		target := map[string]interface{}{
+			"app_id": t.App.ID,
+			"status": t.Status,
+		}
+		if t.Release != nil {
+			target["release_id"] = t.Release.ID
+			releaseIDs[t.App.ID] = t.Release.ID
+		}
+		if t.ErrorMessage != nil {
+			target["error_message"] = *t.ErrorMessage
+		}
+		promotionTargets = append(promotionTargets, target)
+	}
+
+	if err := d.Set("targets", targetIDs); err != nil {
+		return setAttributeDiagnostics("targets", err)
+	}
+	if err := d.Set("target_release_ids", releaseIDs); err != nil {
+		return setAttributeDiagnostics("target_release_ids", err)
+	}
+	if err := d.Set("promotion_targets", promotionTargets); err != nil {
+		return setAttributeDiagnostics("promotion_targets", err)
+	}
+
+	return nil
+}
+
+// resourceHerokuPipelinePromotionUpdate only updates fail_on_target_failure,
+// which does not affect the completed promotion.
+func resourceHerokuPipelinePromotionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceHerokuPipelinePromotionRead(ctx, d, meta)
+}
+
+// resourceHerokuPipelinePromotionDelete only removes the promotion from state, as
+// promotions cannot be deleted or reverted.
+func resourceHerokuPipelinePromotionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for pipeline promotion resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+// PipelinePromotionTargetsStateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch the targets of a pipeline promotion. The state is "completed"
+// once all of the expected number of targets exist and each has either succeeded or failed.
+func PipelinePromotionTargetsStateRefreshFunc(client *heroku.Service, id string, expected int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		targets, err := client.PipelinePromotionTargetList(context.TODO(), id, nil)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get pipeline promotion targets: %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		// Targets are created along with the promotion, but may not be listed right away.
+		if len(targets) < expected {
+			return targets, "pending", nil
+		}
+
+		for _, t := range targets {
+			if t.Status == "pending" {
+				return targets, "pending", nil
+			}
+		}
+
+		return targets, "completed", nil
+	}
+}
+
+// pipelinePromotionFailure describes the failed targets of a completed promotion,
+// or returns nil when every target succeeded.
+func pipelinePromotionFailure(targets heroku.PipelinePromotionTargetListResult) error {
+	var failures []string
+	for _, t := range targets {
+		if t.Status == "succeeded" {
+			continue
+		}
+
+		failure := fmt.Sprintf("app %s: %s", t.App.ID, t.Status)
+		if t.ErrorMessage != nil && *t.ErrorMessage != "" {
+			failure = fmt.Sprintf("%s: %s", failure, *t.ErrorMessage)
+		}
+		failures = append(failures, failure)
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("Pipeline promotion failed for %d of %d targets:\n  %s", len(failures), len(targets), strings.Join(failures, "\n  "))
+}
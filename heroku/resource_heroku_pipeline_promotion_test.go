@@ -0,0 +1,134 @@
+package heroku
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestPipelinePromotionTargetsStateRefreshFunc(t *testing.T) {
+	var targets string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pipeline-promotions/promotion-id/promotion-targets" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(targets))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		targets  string
+		expected string
+	}{
+		{"no targets", `[]`, "pending"},
+		{"some targets", `[{"id": "t1", "status": "succeeded"}]`, "pending"},
+		{"pending target", `[{"id": "t1", "status": "succeeded"}, {"id": "t2", "status": "pending"}]`, "pending"},
+		{"all targets", `[{"id": "t1", "status": "succeeded"}, {"id": "t2", "status": "failed"}]`, "completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets = tt.targets
+			_, state, err := PipelinePromotionTargetsStateRefreshFunc(config.Api, "promotion-id", 2)()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state != tt.expected {
+				t.Fatalf("Expected state %s, got %s", tt.expected, state)
+			}
+		})
+	}
+}
+
+func TestAccHerokuPipelinePromotion_Basic(t *testing.T) {
+	randString := acctest.RandString(10)
+	pipelineName := fmt.Sprintf("tftest-%s", randString)
+	stagingName := fmt.Sprintf("tftest-staging-%s", randString)
+	productionName := fmt.Sprintf("tftest-prod-%s", randString)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuPipelinePromotionConfig_basic(pipelineName, stagingName, productionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_pipeline_promotion.foobar", "status", "completed"),
+					resource.TestCheckResourceAttr("heroku_pipeline_promotion.foobar", "promotion_targets.#", "1"),
+					resource.TestCheckResourceAttr("heroku_pipeline_promotion.foobar", "promotion_targets.0.status", "succeeded"),
+					resource.TestCheckResourceAttrPair(
+						"heroku_pipeline_promotion.foobar", "promotion_targets.0.app_id",
+						"heroku_app.production", "uuid",
+					),
+					resource.TestCheckResourceAttrSet("heroku_pipeline_promotion.foobar", "promotion_targets.0.release_id"),
+					resource.TestCheckResourceAttrPair(
+						"heroku_pipeline_promotion.foobar", "release_id",
+						"heroku_build.staging", "release_id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPipelinePromotionConfig_basic(pipelineName, stagingName, productionName string) string {
+	return fmt.Sprintf(`
+resource "heroku_pipeline" "foobar" {
+  name = "%s"
+}
+
+resource "heroku_app" "staging" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_app" "production" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_pipeline_coupling" "staging" {
+  app      = heroku_app.staging.id
+  pipeline = heroku_pipeline.foobar.id
+  stage    = "staging"
+}
+
+resource "heroku_pipeline_coupling" "production" {
+  app      = heroku_app.production.id
+  pipeline = heroku_pipeline.foobar.id
+  stage    = "production"
+}
+
+resource "heroku_build" "staging" {
+  app = heroku_app.staging.id
+  source {
+    path = "test-fixtures/app"
+  }
+}
+
+resource "heroku_pipeline_promotion" "foobar" {
+  pipeline = heroku_pipeline.foobar.id
+  source   = heroku_app.staging.uuid
+  targets  = [heroku_app.production.uuid]
+
+  depends_on = [
+    heroku_build.staging,
+    heroku_pipeline_coupling.staging,
+    heroku_pipeline_coupling.production,
+  ]
+}
+`, pipelineName, stagingName, productionName)
+}
@@ -0,0 +1,321 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// postgresDefaultCredential is the credential every database is provisioned with.
+// It cannot be created or destroyed, only rotated.
+const postgresDefaultCredential = "default"
+
+// postgresCredential is a database credential of the Postgres API. During a
+// rotation, it holds both the new active password and the old revoking one.
+type postgresCredential struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Database    string `json:"database"`
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	Credentials []struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+		State    string `json:"state"`
+	} `json:"credentials"`
+}
+
+// activePassword returns the user and password of the active credential, if any.
+func (c *postgresCredential) activePassword() (string, string) {
+	for _, cr := range c.Credentials {
+		if cr.State == "active" {
+			return cr.User, cr.Password
+		}
+	}
+	return "", ""
+}
+
+func resourceHerokuPostgresCredential() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuPostgresCredentialCreate,
+		ReadContext:   resourceHerokuPostgresCredentialRead,
+		UpdateContext: resourceHerokuPostgresCredentialUpdate,
+		DeleteContext: resourceHerokuPostgresCredentialDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuPostgresCredentialImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rotation_keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"database": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"uri": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuPostgresCredentialImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	addonID, name, err := parseCompositeID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("addon_id", addonID); err != nil {
+		return nil, setAttributeError("addon_id", err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return nil, setAttributeError("name", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuPostgresCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	addonID := d.Get("addon_id").(string)
+	name := d.Get("name").(string)
+
+	// The default credential already exists, and is only managed to be rotated.
+	if name != postgresDefaultCredential {
+		log.Printf("[DEBUG] Creating credential %s of Postgres database %s", name, addonID)
+		err := client.Post(ctx, nil, postgresCredentialsPath(addonID), map[string]string{"name": name})
+		if err != nil {
+			return diag.Errorf("Error creating credential %s of Postgres database %s: %s", name, addonID, err)
+		}
+	}
+
+	d.SetId(buildCompositeID(addonID, name))
+
+	log.Printf("[DEBUG] Waiting for credential %s of Postgres database %s to be active", name, addonID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"active"},
+		Refresh: PostgresCredentialStateRefreshFunc(client, addonID, name, ""),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("Credential %s", name), fmt.Errorf("Error waiting for credential %s of Postgres database %s to be active: %s", name, addonID, err)))
+	}
+
+	return resourceHerokuPostgresCredentialRead(ctx, d, meta)
+}
+
+func resourceHerokuPostgresCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	addonID, name, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var credential postgresCredential
+	if err := client.Get(ctx, &credential, postgresCredentialPath(addonID, name), nil, nil); err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Credential %s of Postgres database %s not found, removing from state", name, addonID)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error retrieving credential %s of Postgres database %s: %s", name, addonID, err)
+	}
+
+	user, password := credential.activePassword()
+
+	if err := d.Set("addon_id", addonID); err != nil {
+		return setAttributeDiagnostics("addon_id", err)
+	}
+	if err := d.Set("name", credential.Name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
+	if err := d.Set("state", credential.State); err != nil {
+		return setAttributeDiagnostics("state", err)
+	}
+	if err := d.Set("database", credential.Database); err != nil {
+		return setAttributeDiagnostics("database", err)
+	}
+	if err := d.Set("host", credential.Host); err != nil {
+		return setAttributeDiagnostics("host", err)
+	}
+	if err := d.Set("port", credential.Port); err != nil {
+		return setAttributeDiagnostics("port", err)
+	}
+	if err := d.Set("username", user); err != nil {
+		return setAttributeDiagnostics("username", err)
+	}
+	if err := d.Set("password", password); err != nil {
+		return setAttributeDiagnostics("password", err)
+	}
+	if err := d.Set("uri", postgresURI(user, password, credential.Host, credential.Port, credential.Database)); err != nil {
+		return setAttributeDiagnostics("uri", err)
+	}
+
+	return nil
+}
+
+// resourceHerokuPostgresCredentialUpdate rotates the credential when its rotation
+// keepers change, waiting for the new password to be active.
+func resourceHerokuPostgresCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	if !d.HasChange("rotation_keepers") {
+		return resourceHerokuPostgresCredentialRead(ctx, d, meta)
+	}
+
+	addonID := d.Get("addon_id").(string)
+	name := d.Get("name").(string)
+	oldPassword := d.Get("password").(string)
+
+	log.Printf("[INFO] Rotating credential %s of Postgres database %s", name, addonID)
+	if err := client.Post(ctx, nil, fmt.Sprintf("%s/credentials_rotation", postgresCredentialPath(addonID, name)), nil); err != nil {
+		return diag.Errorf("Error rotating credential %s of Postgres database %s: %s", name, addonID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"active"},
+		Refresh: PostgresCredentialStateRefreshFunc(client, addonID, name, oldPassword),
+		Timeout: d.Timeout(schema.TimeoutUpdate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.Errorf("Error waiting for rotated credential %s of Postgres database %s to be active: %s", name, addonID, err)
+	}
+
+	return resourceHerokuPostgresCredentialRead(ctx, d, meta)
+}
+
+func resourceHerokuPostgresCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	addonID, name, err := parseCompositeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if name == postgresDefaultCredential {
+		log.Printf("[INFO] The default credential of Postgres database %s cannot be destroyed. Resource will be removed from state.", addonID)
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting credential %s of Postgres database %s", name, addonID)
+	if err := client.Delete(ctx, nil, postgresCredentialPath(addonID, name)); err != nil {
+		return diag.Errorf("Error deleting credential %s of Postgres database %s: %s", name, addonID, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// PostgresCredentialStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch a Postgres credential. The state is "active" once the credential
+// is active with a password other than the given old password, if any.
+func PostgresCredentialStateRefreshFunc(client *heroku.Service, addonID, name, oldPassword string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var credential postgresCredential
+		if err := client.Get(context.TODO(), &credential, postgresCredentialPath(addonID, name), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get credential %s of Postgres database %s: %s", name, addonID, err)
+			return nil, "", err
+		}
+
+		if credential.State == "revoked" {
+			return nil, "", fmt.Errorf("credential %s of Postgres database %s was revoked", name, addonID)
+		}
+
+		_, password := credential.activePassword()
+		if credential.State != "active" || password == "" || password == oldPassword {
+			return credential, "pending", nil
+		}
+
+		return credential, "active", nil
+	}
+}
+
+func postgresCredentialsPath(addonID string) string {
+	return fmt.Sprintf("/postgres/v0/databases/%s/credentials", addonID)
+}
+
+func postgresCredentialPath(addonID, name string) string {
+	return fmt.Sprintf("%s/%s", postgresCredentialsPath(addonID), url.PathEscape(name))
+}
+
+// postgresURI returns the connection URI of a Postgres credential.
+func postgresURI(user, password, host string, port int, database string) string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(user, password),
+		Host:   fmt.Sprintf("%s:%d", host, port),
+		Path:   "/" + database,
+	}
+	return u.String()
+}
@@ -0,0 +1,181 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// maintenanceWindowRegexp matches the maintenance windows of the data APIs, such
+// as "Sundays 14:30 to 18:30 UTC".
+var maintenanceWindowRegexp = regexp.MustCompile(`^([A-Z][a-z]+?)s? (\d{2}:\d{2}) to \d{2}:\d{2} UTC$`)
+
+var maintenanceWindowDays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// dataMaintenance is the maintenance status of a Postgres or Redis database.
+type dataMaintenance struct {
+	Window       *string    `json:"window"`
+	ScheduledFor *time.Time `json:"scheduled_for"`
+}
+
+// maintenanceWindowSchema is the schema of the maintenance window resources of
+// the data add-ons.
+func maintenanceWindowSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"addon_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"day": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(maintenanceWindowDays, false),
+		},
+
+		"time": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01]\d|2[0-3]):(00|30)$`), "must be a UTC time on the hour or half hour, e.g. 14:30"),
+		},
+
+		"window": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"scheduled_for": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func resourceHerokuPostgresMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuPostgresMaintenanceWindowSet,
+		ReadContext:   resourceHerokuPostgresMaintenanceWindowRead,
+		UpdateContext: resourceHerokuPostgresMaintenanceWindowSet,
+		DeleteContext: resourceHerokuPostgresMaintenanceWindowDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuMaintenanceWindowImport,
+		},
+
+		Schema: maintenanceWindowSchema(),
+	}
+}
+
+func resourceHerokuMaintenanceWindowImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("addon_id", d.Id()); err != nil {
+		return nil, setAttributeError("addon_id", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuPostgresMaintenanceWindowSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	addonID := d.Get("addon_id").(string)
+	if err := setMaintenanceWindow(ctx, client, postgresDatabasePath(addonID), d); err != nil {
+		return diag.Errorf("Error setting maintenance window of Postgres database %s: %s", addonID, err)
+	}
+
+	d.SetId(addonID)
+
+	return resourceHerokuPostgresMaintenanceWindowRead(ctx, d, meta)
+}
+
+func resourceHerokuPostgresMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	if err := readMaintenanceWindow(ctx, client, postgresDatabasePath(d.Id()), d); err != nil {
+		if removeNotFound(d, "Maintenance window of Postgres database", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving maintenance window of Postgres database %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceHerokuPostgresMaintenanceWindowDelete only removes the maintenance window
+// from state, as databases always have one.
+func resourceHerokuPostgresMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] The maintenance window of Postgres database %s cannot be removed. Resource will be removed from state.", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// setMaintenanceWindow sets the maintenance window of the database at the path.
+func setMaintenanceWindow(ctx context.Context, client *heroku.Service, path string, d *schema.ResourceData) error {
+	description := fmt.Sprintf("%s %s", d.Get("day").(string), d.Get("time").(string))
+
+	log.Printf("[DEBUG] Setting maintenance window of %s to %s", path, description)
+	return client.Put(ctx, nil, fmt.Sprintf("%s/maintenance_window", path), map[string]string{"description": description})
+}
+
+// readMaintenanceWindow reads the maintenance window of the database at the path.
+func readMaintenanceWindow(ctx context.Context, client *heroku.Service, path string, d *schema.ResourceData) error {
+	var maintenance dataMaintenance
+	if err := client.Get(ctx, &maintenance, fmt.Sprintf("%s/maintenance", path), nil, nil); err != nil {
+		return err
+	}
+
+	if err := d.Set("addon_id", d.Id()); err != nil {
+		return setAttributeError("addon_id", err)
+	}
+
+	if maintenance.Window != nil {
+		if err := d.Set("window", *maintenance.Window); err != nil {
+			return setAttributeError("window", err)
+		}
+		if day, start, ok := parseMaintenanceWindow(*maintenance.Window); ok {
+			if err := d.Set("day", day); err != nil {
+				return setAttributeError("day", err)
+			}
+			if err := d.Set("time", start); err != nil {
+				return setAttributeError("time", err)
+			}
+		}
+	}
+
+	if maintenance.ScheduledFor != nil {
+		if err := d.Set("scheduled_for", maintenance.ScheduledFor.Format(time.RFC3339)); err != nil {
+			return setAttributeError("scheduled_for", err)
+		}
+	} else {
+		if err := d.Set("scheduled_for", ""); err != nil {
+			return setAttributeError("scheduled_for", err)
+		}
+	}
+
+	return nil
+}
+
+// parseMaintenanceWindow returns the day and start time of a maintenance window
+// such as "Sundays 14:30 to 18:30 UTC".
+func parseMaintenanceWindow(window string) (string, string, bool) {
+	m := maintenanceWindowRegexp.FindStringSubmatch(window)
+	if m == nil {
+		return "", "", false
+	}
+
+	for _, day := range maintenanceWindowDays {
+		if strings.EqualFold(day, m[1]) {
+			return day, m[2], true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,74 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuPostgresMaintenanceWindow_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuPostgresMaintenanceWindowConfig(appName, "Sunday", "14:30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_postgres_maintenance_window.foobar", "day", "Sunday"),
+					resource.TestCheckResourceAttr("heroku_postgres_maintenance_window.foobar", "time", "14:30"),
+					resource.TestCheckResourceAttr("heroku_postgres_maintenance_window.foobar", "window", "Sundays 14:30 to 18:30 UTC"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuPostgresMaintenanceWindowConfig(appName, "Wednesday", "02:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_postgres_maintenance_window.foobar", "day", "Wednesday"),
+					resource.TestCheckResourceAttr("heroku_postgres_maintenance_window.foobar", "time", "02:00"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPostgresMaintenanceWindowConfig(appName, day, time string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "database" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+}
+
+resource "heroku_postgres_maintenance_window" "foobar" {
+  addon_id = heroku_addon.database.id
+  day      = "%s"
+  time     = "%s"
+}
+`, appName, day, time)
+}
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	day, start, ok := parseMaintenanceWindow("Sundays 14:30 to 18:30 UTC")
+	if !ok || day != "Sunday" || start != "14:30" {
+		t.Fatalf("expected Sunday 14:30, got %q %q (%t)", day, start, ok)
+	}
+
+	day, start, ok = parseMaintenanceWindow("Wednesdays 02:00 to 06:00 UTC")
+	if !ok || day != "Wednesday" || start != "02:00" {
+		t.Fatalf("expected Wednesday 02:00, got %q %q (%t)", day, start, ok)
+	}
+
+	if _, _, ok := parseMaintenanceWindow("sometime"); ok {
+		t.Fatal("expected an unparseable window")
+	}
+}
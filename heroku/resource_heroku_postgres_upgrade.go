@@ -0,0 +1,215 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+type postgresUpgradeOpts struct {
+	Version *string `json:"version,omitempty"`
+}
+
+func resourceHerokuPostgresUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuPostgresUpgradeCreate,
+		ReadContext:   resourceHerokuPostgresUpgradeRead,
+		DeleteContext: resourceHerokuPostgresUpgradeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"follower_addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"maintenance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"promote": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"postgres_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"completed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+// resourceHerokuPostgresUpgradeCreate upgrades a follower to a new major version
+// in the stages of the follower upgrade procedure: the app is put in maintenance
+// mode to stop writes, the follower catches up with its leader, it is upgraded
+// (which unfollows it), promoted and finally the app leaves maintenance mode.
+// When a stage fails, the app is left in maintenance mode so that the failure
+// can be inspected without writes being lost.
+func resourceHerokuPostgresUpgradeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	addonID := d.Get("follower_addon_id").(string)
+	app := d.Get("app").(string)
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	var database postgresDatabase
+	if err := config.PostgresAPI.Get(ctx, &database, postgresDatabasePath(addonID), nil, nil); err != nil {
+		return diag.Errorf("Error retrieving Postgres database %s: %s", addonID, err)
+	}
+	if database.Following == nil || *database.Following == "" {
+		return diag.Errorf("Postgres database %s is not a follower, only followers can be upgraded", database.Name)
+	}
+
+	maintenance := d.Get("maintenance").(bool)
+	if maintenance {
+		log.Printf("[INFO] Stage 1: enabling maintenance mode of app %s", app)
+		if err := setAppMaintenance(ctx, client, app, true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[INFO] Stage 2: waiting for follower %s to catch up with its leader", database.Name)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"behind"},
+		Target:     []string{"caught-up"},
+		Refresh:    PostgresFollowerLagStateRefreshFunc(config.PostgresAPI, addonID),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.Errorf("Error waiting for follower %s to catch up: %s", database.Name, err)
+	}
+
+	log.Printf("[INFO] Stage 3: upgrading follower %s", database.Name)
+	opts := postgresUpgradeOpts{}
+	if v, ok := d.GetOk("version"); ok {
+		vs := v.(string)
+		opts.Version = &vs
+	}
+	if err := config.PostgresAPI.Post(ctx, nil, fmt.Sprintf("%s/upgrade", postgresDatabasePath(addonID)), opts); err != nil {
+		return diag.Errorf("Error upgrading Postgres database %s: %s", database.Name, err)
+	}
+	if err := waitForPostgresDatabase(config.PostgresAPI, addonID, timeout); err != nil {
+		return diag.Errorf("Error waiting for upgrade of Postgres database %s: %s", database.Name, err)
+	}
+
+	if d.Get("promote").(bool) {
+		log.Printf("[INFO] Stage 4: promoting %s on app %s", database.Name, app)
+		if err := promotePostgresDatabase(ctx, config, addonID, app, timeout); err != nil {
+			return diag.Errorf("Error promoting Postgres database %s: %s", database.Name, err)
+		}
+	}
+
+	if maintenance {
+		log.Printf("[INFO] Stage 5: disabling maintenance mode of app %s", app)
+		if err := setAppMaintenance(ctx, client, app, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(addonID)
+	if err := d.Set("completed_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("completed_at", err)
+	}
+
+	log.Printf("[INFO] Upgraded Postgres database %s", database.Name)
+
+	return resourceHerokuPostgresUpgradeRead(ctx, d, meta)
+}
+
+func resourceHerokuPostgresUpgradeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).PostgresAPI
+
+	var database postgresDatabase
+	if err := client.Get(ctx, &database, postgresDatabasePath(d.Id()), nil, nil); err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Postgres database %s not found, removing upgrade from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error retrieving Postgres database %s: %s", d.Id(), err)
+	}
+
+	if v := database.infoValues("PG Version"); len(v) > 0 {
+		if err := d.Set("postgres_version", v[0]); err != nil {
+			return setAttributeDiagnostics("postgres_version", err)
+		}
+	}
+
+	return nil
+}
+
+// resourceHerokuPostgresUpgradeDelete only removes the upgrade from state, as
+// major version upgrades cannot be reverted.
+func resourceHerokuPostgresUpgradeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for Postgres upgrade resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+func setAppMaintenance(ctx context.Context, client *heroku.Service, app string, maintenance bool) error {
+	if _, err := client.AppUpdate(ctx, app, heroku.AppUpdateOpts{Maintenance: &maintenance}); err != nil {
+		return fmt.Errorf("Error setting maintenance mode of app %s to %t: %s", app, maintenance, err)
+	}
+	return nil
+}
+
+// PostgresFollowerLagStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch the replication lag of a follower.
+func PostgresFollowerLagStateRefreshFunc(client *heroku.Service, addonID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var database postgresDatabase
+		if err := client.Get(context.TODO(), &database, postgresDatabasePath(addonID), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get Postgres database %s: %s", addonID, err)
+			return nil, "", err
+		}
+
+		behindBy, err := parsePostgresBehindBy(database.infoValues("Behind By"))
+		if err != nil {
+			return nil, "", err
+		}
+
+		if behindBy > 0 {
+			log.Printf("[DEBUG] Follower %s is behind by %d commits", addonID, behindBy)
+			return database, "behind", nil
+		}
+
+		return database, "caught-up", nil
+	}
+}
@@ -0,0 +1,62 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuPostgresUpgrade_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuPostgresUpgradeConfig_basic(appName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_postgres_upgrade.foobar", "postgres_version", "16"),
+					resource.TestCheckResourceAttrSet("heroku_postgres_upgrade.foobar", "completed_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuPostgresUpgradeConfig_basic(appName string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "leader" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+
+  config = {
+    version = "15"
+  }
+}
+
+resource "heroku_addon" "follower" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-postgresql:standard-0"
+
+  config = {
+    follow = heroku_addon.leader.name
+  }
+}
+
+resource "heroku_postgres_upgrade" "foobar" {
+  follower_addon_id = heroku_addon.follower.id
+  app               = heroku_app.foobar.name
+  version           = "16"
+}
+`, appName)
+}
@@ -0,0 +1,148 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// redisDatabase is a Heroku Data for Redis database of the Redis API.
+type redisDatabase struct {
+	Name        string `json:"name"`
+	ResourceURL string `json:"resource_url"`
+}
+
+func resourceHerokuRedisCredential() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuRedisCredentialCreate,
+		ReadContext:   resourceHerokuRedisCredentialRead,
+		UpdateContext: resourceHerokuRedisCredentialUpdate,
+		DeleteContext: resourceHerokuRedisCredentialDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"addon_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"rotation_keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+// resourceHerokuRedisCredentialCreate tracks the existing credential of the
+// database, which is only managed to be rotated.
+func resourceHerokuRedisCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("addon_id").(string))
+
+	return resourceHerokuRedisCredentialRead(ctx, d, meta)
+}
+
+func resourceHerokuRedisCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).RedisAPI
+
+	var database redisDatabase
+	if err := client.Get(ctx, &database, redisDatabasePath(d.Id()), nil, nil); err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Redis database %s not found, removing credential from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error retrieving Redis database %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("addon_id", d.Id()); err != nil {
+		return setAttributeDiagnostics("addon_id", err)
+	}
+	if err := d.Set("url", database.ResourceURL); err != nil {
+		return setAttributeDiagnostics("url", err)
+	}
+
+	return nil
+}
+
+// resourceHerokuRedisCredentialUpdate rotates the credential when its rotation
+// keepers change, waiting for the new URL to be available.
+func resourceHerokuRedisCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).RedisAPI
+
+	if !d.HasChange("rotation_keepers") {
+		return resourceHerokuRedisCredentialRead(ctx, d, meta)
+	}
+
+	oldURL := d.Get("url").(string)
+
+	log.Printf("[INFO] Rotating credential of Redis database %s", d.Id())
+	if err := client.Post(ctx, nil, fmt.Sprintf("%s/credentials_rotation", redisDatabasePath(d.Id())), nil); err != nil {
+		return diag.Errorf("Error rotating credential of Redis database %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"rotating"},
+		Target:  []string{"rotated"},
+		Refresh: RedisCredentialStateRefreshFunc(client, d.Id(), oldURL),
+		Timeout: d.Timeout(schema.TimeoutUpdate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.Errorf("Error waiting for rotated credential of Redis database %s: %s", d.Id(), err)
+	}
+
+	return resourceHerokuRedisCredentialRead(ctx, d, meta)
+}
+
+// resourceHerokuRedisCredentialDelete only removes the credential from state, as
+// the credential of a database cannot be destroyed.
+func resourceHerokuRedisCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for Redis credential resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+// RedisCredentialStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch the rotation of a Redis credential. The state is "rotated" once the URL
+// of the database differs from its old URL.
+func RedisCredentialStateRefreshFunc(client *heroku.Service, addonID, oldURL string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var database redisDatabase
+		if err := client.Get(context.TODO(), &database, redisDatabasePath(addonID), nil, nil); err != nil {
+			log.Printf("[DEBUG] Failed to get Redis database %s: %s", addonID, err)
+			return nil, "", err
+		}
+
+		if database.ResourceURL == "" || database.ResourceURL == oldURL {
+			return database, "rotating", nil
+		}
+
+		return database, "rotated", nil
+	}
+}
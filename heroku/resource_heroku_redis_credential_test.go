@@ -0,0 +1,81 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccHerokuRedisCredential_Rotation(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	var redisURL string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuRedisCredentialConfig(appName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuRedisCredentialURL("heroku_redis_credential.foobar", &redisURL, false),
+				),
+			},
+			{
+				Config: testAccCheckHerokuRedisCredentialConfig(appName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuRedisCredentialURL("heroku_redis_credential.foobar", &redisURL, true),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckHerokuRedisCredentialURL saves the URL of the credential, checking
+// that it changed from the saved one when rotated.
+func testAccCheckHerokuRedisCredentialURL(n string, redisURL *string, rotated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		current := rs.Primary.Attributes["url"]
+		if current == "" {
+			return fmt.Errorf("No URL is set")
+		}
+		if rotated && current == *redisURL {
+			return fmt.Errorf("Credential was not rotated")
+		}
+		*redisURL = current
+
+		return nil
+	}
+}
+
+func testAccCheckHerokuRedisCredentialConfig(appName, rotation string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "redis" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-redis:premium-0"
+}
+
+resource "heroku_redis_credential" "foobar" {
+  addon_id = heroku_addon.redis.id
+
+  rotation_keepers = {
+    rotation = "%s"
+  }
+}
+`, appName, rotation)
+}
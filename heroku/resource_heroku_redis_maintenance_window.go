@@ -0,0 +1,63 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceHerokuRedisMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuRedisMaintenanceWindowSet,
+		ReadContext:   resourceHerokuRedisMaintenanceWindowRead,
+		UpdateContext: resourceHerokuRedisMaintenanceWindowSet,
+		DeleteContext: resourceHerokuRedisMaintenanceWindowDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuMaintenanceWindowImport,
+		},
+
+		Schema: maintenanceWindowSchema(),
+	}
+}
+
+func resourceHerokuRedisMaintenanceWindowSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).RedisAPI
+
+	addonID := d.Get("addon_id").(string)
+	if err := setMaintenanceWindow(ctx, client, redisDatabasePath(addonID), d); err != nil {
+		return diag.Errorf("Error setting maintenance window of Redis database %s: %s", addonID, err)
+	}
+
+	d.SetId(addonID)
+
+	return resourceHerokuRedisMaintenanceWindowRead(ctx, d, meta)
+}
+
+func resourceHerokuRedisMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).RedisAPI
+
+	if err := readMaintenanceWindow(ctx, client, redisDatabasePath(d.Id()), d); err != nil {
+		if removeNotFound(d, "Maintenance window of Redis database", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving maintenance window of Redis database %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceHerokuRedisMaintenanceWindowDelete only removes the maintenance window
+// from state, as databases always have one.
+func resourceHerokuRedisMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] The maintenance window of Redis database %s cannot be removed. Resource will be removed from state.", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func redisDatabasePath(addonID string) string {
+	return fmt.Sprintf("/redis/v0/databases/%s", addonID)
+}
@@ -0,0 +1,51 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuRedisMaintenanceWindow_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAddonDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuRedisMaintenanceWindowConfig(appName, "Monday", "10:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_redis_maintenance_window.foobar", "day", "Monday"),
+					resource.TestCheckResourceAttr("heroku_redis_maintenance_window.foobar", "time", "10:00"),
+					resource.TestCheckResourceAttr("heroku_redis_maintenance_window.foobar", "window", "Mondays 10:00 to 14:00 UTC"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuRedisMaintenanceWindowConfig(appName, day, time string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_addon" "redis" {
+  app  = heroku_app.foobar.name
+  plan = "heroku-redis:premium-0"
+}
+
+resource "heroku_redis_maintenance_window" "foobar" {
+  addon_id = heroku_addon.redis.id
+  day      = "%s"
+  time     = "%s"
+}
+`, appName, day, time)
+}
@@ -0,0 +1,291 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuReviewApp() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuReviewAppCreate,
+		ReadContext:   resourceHerokuReviewAppRead,
+		DeleteContext: resourceHerokuReviewAppDelete,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"pr_number": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"fork_repo_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source_url", "source_path"},
+				ValidateFunc: validateSourceUrl,
+			},
+
+			"source_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"environment": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"app_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"app_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"web_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"wait_for_ci": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuReviewAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	opts := heroku.ReviewAppCreateOpts{
+		Pipeline: d.Get("pipeline").(string),
+		Branch:   d.Get("branch").(string),
+	}
+
+	if v, ok := d.GetOk("pr_number"); ok {
+		vi := v.(int)
+		opts.PrNumber = &vi
+	}
+
+	if v, ok := d.GetOk("fork_repo_id"); ok {
+		vi := v.(int)
+		opts.ForkRepoID = &vi
+	}
+
+	if v, ok := d.GetOk("source_path"); ok {
+		getURL, _, err := uploadSourcePath(client, v.(string), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		opts.SourceBlob.URL = &getURL
+	} else {
+		vs := d.Get("source_url").(string)
+		opts.SourceBlob.URL = &vs
+	}
+
+	if v, ok := d.GetOk("source_version"); ok {
+		vs := v.(string)
+		opts.SourceBlob.Version = &vs
+	}
+
+	if env := d.Get("environment").(map[string]interface{}); len(env) > 0 {
+		opts.Environment = make(map[string]*string)
+		for k, v := range env {
+			vs := v.(string)
+			opts.Environment[k] = &vs
+		}
+	}
+
+	log.Printf("[DEBUG] Creating review app for branch %s on pipeline %s", opts.Branch, opts.Pipeline)
+	reviewApp, err := client.ReviewAppCreate(ctx, opts)
+	if err != nil {
+		return diag.Errorf("Error creating review app: %s", err)
+	}
+
+	// Track the review app in state even if its deployment fails below,
+	// so that its app is cleaned up on destroy.
+	d.SetId(reviewApp.ID)
+
+	log.Printf("[DEBUG] Waiting for review app (%s) to be created", reviewApp.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "creating"},
+		Target:  []string{"created"},
+		Refresh: ReviewAppStateRefreshFunc(client, reviewApp.ID),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("Review app %s", reviewApp.ID), err))
+	}
+
+	log.Printf("[INFO] Created review app ID: %s", d.Id())
+
+	return resourceHerokuReviewAppRead(ctx, d, meta)
+}
+
+func resourceHerokuReviewAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	reviewApp, err := client.ReviewAppGetReviewApp(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "Review app", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving review app: %s", err)
+	}
+
+	// Review apps are eventually destroyed by Heroku, for example when their
+	// pull request is closed, so remove them from state to be recreated.
+	if reviewApp.Status == "deleting" || reviewApp.Status == "deleted" {
+		log.Printf("[WARN] Review app %s is %s, removing from state", d.Id(), reviewApp.Status)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("pipeline", reviewApp.Pipeline.ID); err != nil {
+		return setAttributeDiagnostics("pipeline", err)
+	}
+	if err := d.Set("branch", reviewApp.Branch); err != nil {
+		return setAttributeDiagnostics("branch", err)
+	}
+	if err := d.Set("status", reviewApp.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("wait_for_ci", reviewApp.WaitForCi); err != nil {
+		return setAttributeDiagnostics("wait_for_ci", err)
+	}
+
+	if reviewApp.PrNumber != nil {
+		if err := d.Set("pr_number", *reviewApp.PrNumber); err != nil {
+			return setAttributeDiagnostics("pr_number", err)
+		}
+	}
+
+	if reviewApp.Message != nil {
+		if err := d.Set("message", *reviewApp.Message); err != nil {
+			return setAttributeDiagnostics("message", err)
+		}
+	}
+
+	if reviewApp.App != nil {
+		if err := d.Set("app_id", reviewApp.App.ID); err != nil {
+			return setAttributeDiagnostics("app_id", err)
+		}
+
+		app, err := client.AppInfo(ctx, reviewApp.App.ID)
+		if err != nil {
+			return diag.Errorf("Error retrieving review app's app %s: %s", reviewApp.App.ID, err)
+		}
+		if err := d.Set("app_name", app.Name); err != nil {
+			return setAttributeDiagnostics("app_name", err)
+		}
+		if err := d.Set("web_url", app.WebURL); err != nil {
+			return setAttributeDiagnostics("web_url", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceHerokuReviewAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	client := config.Api
+
+	// Destroying a review app deletes its app.
+	if config.PreventAppDestroy {
+		return diag.Errorf("Review app %s cannot be destroyed, as the prevent_app_destroy feature of the provider is enabled", d.Id())
+	}
+
+	log.Printf("[INFO] Deleting review app: %s", d.Id())
+	if _, err := client.ReviewAppDelete(ctx, d.Id()); err != nil {
+		return diag.Errorf("Error deleting review app: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// ReviewAppStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch a review app.
+func ReviewAppStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		reviewApp, err := client.ReviewAppGetReviewApp(context.TODO(), id)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get review app status: %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		if reviewApp.Status == "errored" {
+			msg := fmt.Sprintf("Review app (%s) of branch %s errored", reviewApp.ID, reviewApp.Branch)
+			if reviewApp.ErrorStatus != nil && *reviewApp.ErrorStatus != "" {
+				msg = fmt.Sprintf("%s: %s", msg, *reviewApp.ErrorStatus)
+			}
+			if reviewApp.Message != nil && *reviewApp.Message != "" {
+				msg = fmt.Sprintf("%s (%s)", msg, *reviewApp.Message)
+			}
+			return nil, "", fmt.Errorf("%s", msg)
+		}
+
+		return reviewApp, reviewApp.Status, nil
+	}
+}
@@ -120,7 +120,9 @@ func resourceHerokuReviewAppConfigImport(ctx context.Context, d *schema.Resource
 	}
 
 	d.SetId(pipelineID)
-	d.Set("org_repo", orgRepo)
+	if err := d.Set("org_repo", orgRepo); err != nil {
+		return nil, setAttributeError("org_repo", err)
+	}
 
 	readErr := resourceHerokuReviewAppConfigRead(ctx, d, meta)
 	if readErr.HasError() {
@@ -160,12 +162,6 @@ func resourceHerokuReviewAppConfigCreate(ctx context.Context, d *schema.Resource
 		opts.BaseName = &vs
 	}
 
-	if v, ok := d.GetOk("base_name"); ok {
-		vs := v.(string)
-		log.Printf("[DEBUG] review app enable - base_name: %s", vs)
-		opts.BaseName = &vs
-	}
-
 	if v, ok := d.GetOk("deploy_target"); ok {
 		vL := v.([]interface{})
 		deployTargetData := struct {
@@ -273,7 +269,9 @@ func resourceHerokuReviewAppConfigUpdate(ctx context.Context, d *schema.Resource
 		log.Printf("[DEBUG] review app update - destroy_stale_apps: %v", destroyStaleApps)
 	}
 
-	if changed := d.HasChange("stale_days"); changed {
+	// Stale days are required when enabling the destruction of stale apps,
+	// so send them along even if they are unchanged.
+	if changed := d.HasChange("stale_days") || (d.HasChange("destroy_stale_apps") && d.Get("destroy_stale_apps").(bool)); changed && d.Get("stale_days").(int) > 0 {
 		staleDays := d.Get("stale_days").(int)
 		opts.StaleDays = &staleDays
 		log.Printf("[DEBUG] review app update - stale_days: %v", staleDays)
@@ -308,6 +306,9 @@ func resourceHerokuReviewAppConfigRead(ctx context.Context, d *schema.ResourceDa
 
 	reviewAppConfig, readErr := client.ReviewAppConfigInfo(ctx, d.Id())
 	if readErr != nil {
+		if removeNotFound(d, "Review app config of pipeline", readErr) {
+			return nil
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("Unable to retrieve review apps config for pipeline %s", d.Id()),
@@ -316,34 +317,60 @@ func resourceHerokuReviewAppConfigRead(ctx context.Context, d *schema.ResourceDa
 		return diags
 	}
 
-	d.Set("pipeline_id", reviewAppConfig.Pipeline.ID)
-	d.Set("automatic_review_apps", reviewAppConfig.AutomaticReviewApps)
-	d.Set("base_name", reviewAppConfig.BaseName)
-	d.Set("destroy_stale_apps", reviewAppConfig.DestroyStaleApps)
-	d.Set("stale_days", reviewAppConfig.StaleDays)
-	d.Set("wait_for_ci", reviewAppConfig.WaitForCi)
-	d.Set("repo_id", reviewAppConfig.Repo.ID)
+	if err := d.Set("pipeline_id", reviewAppConfig.Pipeline.ID); err != nil {
+		return setAttributeDiagnostics("pipeline_id", err)
+	}
+	if err := d.Set("automatic_review_apps", reviewAppConfig.AutomaticReviewApps); err != nil {
+		return setAttributeDiagnostics("automatic_review_apps", err)
+	}
+	baseName := ""
+	if reviewAppConfig.BaseName != nil {
+		baseName = *reviewAppConfig.BaseName
+	}
+	if err := d.Set("base_name", baseName); err != nil {
+		return setAttributeDiagnostics("base_name", err)
+	}
+	if err := d.Set("destroy_stale_apps", reviewAppConfig.DestroyStaleApps); err != nil {
+		return setAttributeDiagnostics("destroy_stale_apps", err)
+	}
+	if err := d.Set("stale_days", reviewAppConfig.StaleDays); err != nil {
+		return setAttributeDiagnostics("stale_days", err)
+	}
+	if err := d.Set("wait_for_ci", reviewAppConfig.WaitForCi); err != nil {
+		return setAttributeDiagnostics("wait_for_ci", err)
+	}
+	if err := d.Set("repo_id", reviewAppConfig.Repo.ID); err != nil {
+		return setAttributeDiagnostics("repo_id", err)
+	}
 
 	deployTarget := make([]map[string]interface{}, 0)
 	if reviewAppConfig.DeployTarget != nil {
+		deployTargetID := reviewAppConfig.DeployTarget.ID
+
 		// Lookup region info as the /review-app-config endpoint returns the region UUID
-		// for the deploy target ID instead of the name (ex. 'us').
-		region, regionGetErr := client.RegionInfo(ctx, reviewAppConfig.DeployTarget.ID)
-		if regionGetErr != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Unable to retrieve region %s", reviewAppConfig.DeployTarget.ID),
-				Detail:   regionGetErr.Error(),
-			})
-			return diags
+		// for the deploy target ID instead of the name (ex. 'us'). Space deploy targets
+		// are configured by their UUID, so they are set as returned.
+		if reviewAppConfig.DeployTarget.Type == "region" {
+			region, regionGetErr := client.RegionInfo(ctx, reviewAppConfig.DeployTarget.ID)
+			if regionGetErr != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Unable to retrieve region %s", reviewAppConfig.DeployTarget.ID),
+					Detail:   regionGetErr.Error(),
+				})
+				return diags
+			}
+			deployTargetID = region.Name
 		}
 
 		deployTarget = append(deployTarget, map[string]interface{}{
-			"id":   region.Name,
+			"id":   deployTargetID,
 			"type": reviewAppConfig.DeployTarget.Type,
 		})
 	}
-	d.Set("deploy_target", deployTarget)
+	if err := d.Set("deploy_target", deployTarget); err != nil {
+		return setAttributeDiagnostics("deploy_target", err)
+	}
 
 	return diags
 }
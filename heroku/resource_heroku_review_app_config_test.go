@@ -1,8 +1,11 @@
 package heroku
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	heroku "github.com/heroku/heroku-go/v5"
 	"testing"
 )
 
@@ -72,6 +75,59 @@ func TestAccHerokuReviewAppConfig_Basic(t *testing.T) {
 	})
 }
 
+// Settings changed outside of Terraform, such as in the Dashboard, are detected and reverted.
+func TestAccHerokuReviewAppConfig_Drift(t *testing.T) {
+	pipelineID := testAccConfig.GetPipelineIDorSkip(t)
+	var pipelineUUID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuReviewAppConfig_basic(pipelineID, "true",
+					"true", "true"),
+				Check: func(s *terraform.State) error {
+					rs, ok := s.RootModule().Resources["heroku_review_app_config.foobar"]
+					if !ok {
+						return fmt.Errorf("Not found: heroku_review_app_config.foobar")
+					}
+					pipelineUUID = rs.Primary.ID
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Config).Api
+					automaticReviewApps, waitForCI, staleDays := false, false, 10
+					baseName := "changed-outside-terraform"
+					_, err := client.ReviewAppConfigUpdate(context.TODO(), pipelineUUID, heroku.ReviewAppConfigUpdateOpts{
+						AutomaticReviewApps: &automaticReviewApps,
+						BaseName:            &baseName,
+						StaleDays:           &staleDays,
+						WaitForCi:           &waitForCI,
+					})
+					if err != nil {
+						t.Fatalf("Error updating review app config outside of Terraform: %s", err)
+					}
+				},
+				Config: testAccCheckHerokuReviewAppConfig_basic(pipelineID, "true",
+					"true", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_review_app_config.foobar", "automatic_review_apps", "true"),
+					resource.TestCheckResourceAttr(
+						"heroku_review_app_config.foobar", "base_name", "ruby-st"),
+					resource.TestCheckResourceAttr(
+						"heroku_review_app_config.foobar", "stale_days", "5"),
+					resource.TestCheckResourceAttr(
+						"heroku_review_app_config.foobar", "wait_for_ci", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckHerokuReviewAppConfig_basic(pipelineID, automaticReviewApps, destroyStaleApps, waitForCI string) string {
 	return fmt.Sprintf(`
 data "heroku_pipeline" "foobar" {
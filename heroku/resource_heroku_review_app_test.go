@@ -0,0 +1,89 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccHerokuReviewApp_LocalSource(t *testing.T) {
+	pipelineID := testAccConfig.GetPipelineIDorSkip(t)
+	branch := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuReviewAppConfig_localSource(pipelineID, branch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_review_app.foobar", "status", "created"),
+					resource.TestCheckResourceAttr("heroku_review_app.foobar", "branch", branch),
+					resource.TestCheckResourceAttrSet("heroku_review_app.foobar", "app_id"),
+					resource.TestMatchResourceAttr("heroku_review_app.foobar", "web_url", regexp.MustCompile(`^https://`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuReviewAppConfig_localSource(pipelineID, branch string) string {
+	return fmt.Sprintf(`
+data "heroku_pipeline" "foobar" {
+  name = "%s"
+}
+
+resource "heroku_review_app_config" "foobar" {
+  pipeline_id = data.heroku_pipeline.foobar.id
+  org_repo = "heroku/ruby-getting-started"
+  base_name = "ruby-st"
+
+  deploy_target {
+    id = "us"
+    type = "region"
+  }
+}
+
+resource "heroku_review_app" "foobar" {
+  pipeline    = data.heroku_pipeline.foobar.id
+  branch      = "%s"
+  source_path = "test-fixtures/app"
+
+  depends_on = [heroku_review_app_config.foobar]
+}
+`, pipelineID, branch)
+}
+
+func TestResourceHerokuReviewAppDelete_PreventAppDestroy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.PreventAppDestroy = true
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHerokuReviewApp().Schema, nil)
+	d.SetId("01234567-89ab-cdef-0123-456789abcdef")
+
+	diags := resourceHerokuReviewAppDelete(context.Background(), d, config)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "prevent_app_destroy") {
+		t.Fatalf("got %#v, want an error about prevent_app_destroy", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the review app to remain in state")
+	}
+}
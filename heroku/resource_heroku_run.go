@@ -0,0 +1,262 @@
+package heroku
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+// runLogLineRegexp matches a line of a log session, such as
+// "2021-03-01T00:00:00.000000+00:00 app[run.1234]: Migrating", capturing its
+// source, e.g. app or heroku, and its message.
+var runLogLineRegexp = regexp.MustCompile(`^\S+ (\w+)\[[^\]]+\]: ?(.*)$`)
+
+// runExitStatusRegexp matches the message that Heroku logs when a dyno exits.
+var runExitStatusRegexp = regexp.MustCompile(`^Process exited with status (\d+)$`)
+
+func resourceHerokuRun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuRunCreate,
+		ReadContext:   resourceHerokuRunRead,
+		DeleteContext: resourceHerokuRunDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"command": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"size": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				StateFunc: formatSize,
+			},
+
+			"env": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"time_to_live": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 86400),
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"dyno_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"exit_code": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"completed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	app := d.Get("app").(string)
+	command := d.Get("command").(string)
+
+	runType := "run"
+	attach := false
+	opts := heroku.DynoCreateOpts{
+		Command: command,
+		Attach:  &attach,
+		Type:    &runType,
+	}
+
+	if v, ok := d.GetOk("size"); ok {
+		vs := v.(string)
+		opts.Size = &vs
+	}
+
+	if v, ok := d.GetOk("time_to_live"); ok {
+		vi := v.(int)
+		opts.TimeToLive = &vi
+	}
+
+	if env := d.Get("env").(map[string]interface{}); len(env) > 0 {
+		opts.Env = make(map[string]string)
+		for k, v := range env {
+			opts.Env[k] = v.(string)
+		}
+	}
+
+	log.Printf("[INFO] Running `%s` on app %s", command, app)
+	dyno, err := client.DynoCreate(ctx, app, opts)
+	if err != nil {
+		return diag.Errorf("Error running `%s` on app %s: %s", command, app, err)
+	}
+
+	// The log session is created once the dyno is, so its earlier lines are
+	// replayed before the session tails the dyno's logs.
+	lines := 1500
+	tail := true
+	session, err := client.LogSessionCreate(ctx, app, heroku.LogSessionCreateOpts{
+		Dyno:  &dyno.Name,
+		Lines: &lines,
+		Tail:  &tail,
+	})
+	if err != nil {
+		return diag.Errorf("Error streaming the output of dyno %s of app %s: %s", dyno.Name, app, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for dyno %s of app %s to exit", dyno.Name, app)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, exitCode, err := streamRunOutput(waitCtx, session.LogplexURL)
+	if err != nil {
+		if waitCtx.Err() == context.DeadlineExceeded {
+			if _, stopErr := client.DynoStop(ctx, app, dyno.ID); stopErr != nil {
+				log.Printf("[WARN] Unable to stop dyno %s of app %s: %s", dyno.Name, app, stopErr)
+			}
+			return diag.Errorf("Error waiting for dyno %s of app %s to exit: timeout after %s, so it was stopped; "+
+				"the wait can be extended with timeouts.%s", dyno.Name, app, timeout, schema.TimeoutCreate)
+		}
+		return diag.Errorf("Error streaming the output of dyno %s of app %s: %s", dyno.Name, app, err)
+	}
+
+	if exitCode != 0 {
+		return diag.Errorf("`%s` exited with status %d on dyno %s of app %s\n\nLast lines of the output:\n%s",
+			command, exitCode, dyno.Name, app, tailLines(output, buildLogTailLines))
+	}
+
+	d.SetId(dyno.ID)
+	if err := d.Set("dyno_name", dyno.Name); err != nil {
+		return setAttributeDiagnostics("dyno_name", err)
+	}
+	if err := d.Set("exit_code", exitCode); err != nil {
+		return setAttributeDiagnostics("exit_code", err)
+	}
+	if err := d.Set("output", output); err != nil {
+		return setAttributeDiagnostics("output", err)
+	}
+	if err := d.Set("completed_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return setAttributeDiagnostics("completed_at", err)
+	}
+
+	log.Printf("[INFO] Completed `%s` on dyno %s of app %s", command, dyno.Name, app)
+
+	return nil
+}
+
+// resourceHerokuRunRead is a no-op, as one-off dynos are gone once they exit.
+func resourceHerokuRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceHerokuRunDelete only removes the run from state, as a completed command
+// cannot be undone.
+func resourceHerokuRunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for run resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+// streamRunOutput reads the log session of a dyno until Heroku logs its exit,
+// returning the dyno's output and exit status.
+func streamRunOutput(ctx context.Context, logplexURL string) (string, int, error) {
+	req, err := http.NewRequest(http.MethodGet, logplexURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", 0, fmt.Errorf("Unsuccessful HTTP response from log session: %s", res.Status)
+	}
+
+	return parseRunOutput(res.Body)
+}
+
+// parseRunOutput returns the output of the app lines of a dyno's log session, and
+// the exit status of the dyno.
+func parseRunOutput(r io.Reader) (string, int, error) {
+	var output strings.Builder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m := runLogLineRegexp.FindStringSubmatch(strings.TrimRight(scanner.Text(), "\r"))
+		if m == nil {
+			continue
+		}
+
+		source, message := m[1], m[2]
+		switch source {
+		case "app":
+			output.WriteString(message)
+			output.WriteString("\n")
+		case "heroku":
+			if s := runExitStatusRegexp.FindStringSubmatch(message); s != nil {
+				exitCode, err := strconv.Atoi(s[1])
+				return output.String(), exitCode, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return output.String(), 0, err
+	}
+
+	return output.String(), 0, fmt.Errorf("the log session ended before the dyno exited")
+}
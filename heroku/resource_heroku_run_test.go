@@ -0,0 +1,144 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestParseRunOutput(t *testing.T) {
+	logs := strings.Join([]string{
+		"2021-03-01T00:00:00.000000+00:00 heroku[run.1234]: Starting process with command `rails db:migrate`",
+		"2021-03-01T00:00:01.000000+00:00 heroku[run.1234]: State changed from starting to up",
+		"2021-03-01T00:00:02.000000+00:00 app[run.1234]: == 20210301000000 CreateUsers: migrating",
+		"2021-03-01T00:00:03.000000+00:00 app[run.1234]: == 20210301000000 CreateUsers: migrated",
+		"2021-03-01T00:00:04.000000+00:00 heroku[run.1234]: Process exited with status 3",
+		"2021-03-01T00:00:05.000000+00:00 heroku[run.1234]: State changed from up to complete",
+	}, "\n")
+
+	output, exitCode, err := parseRunOutput(strings.NewReader(logs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exitCode != 3 {
+		t.Errorf("Expected exit code 3, got %d", exitCode)
+	}
+	expected := "== 20210301000000 CreateUsers: migrating\n== 20210301000000 CreateUsers: migrated\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+
+	if _, _, err := parseRunOutput(strings.NewReader(logs[:strings.Index(logs, "heroku[run.1234]: Process")])); err == nil {
+		t.Errorf("Expected an error when the log session ends before the dyno exits")
+	}
+}
+
+func TestResourceHerokuRunCreate(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/some-app/dynos":
+			w.Write([]byte(`{"id": "01234567-89ab-cdef-0123-456789abcdef", "name": "run.1234"}`))
+		case "/apps/some-app/log-sessions":
+			fmt.Fprintf(w, `{"logplex_url": "%s/stream"}`, srv.URL)
+		case "/stream":
+			fmt.Fprintln(w, "2021-03-01T00:00:00.000000+00:00 app[run.1234]: done")
+			fmt.Fprintln(w, "2021-03-01T00:00:01.000000+00:00 heroku[run.1234]: Process exited with status 0")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceHerokuRun()
+	d := r.TestResourceData()
+	d.Set("app", "some-app")
+	d.Set("command", "rails db:migrate")
+
+	if diags := resourceHerokuRunCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error running command: %#v", diags)
+	}
+	if d.Id() != "01234567-89ab-cdef-0123-456789abcdef" {
+		t.Errorf("Expected the ID of the dyno, got %s", d.Id())
+	}
+	if output := d.Get("output").(string); output != "done\n" {
+		t.Errorf("Expected the output of the command, got %q", output)
+	}
+}
+
+func TestAccHerokuRun_Basic(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuRunConfig(appName, "echo hello"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_run.foobar", "exit_code", "0"),
+					resource.TestMatchResourceAttr("heroku_run.foobar", "output", regexp.MustCompile(`hello`)),
+					resource.TestCheckResourceAttrSet("heroku_run.foobar", "completed_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuRun_Failure(t *testing.T) {
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuAppDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuRunConfig(appName, "exit 2"),
+				ExpectError: regexp.MustCompile("exited with status 2"),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuRunConfig(appName, command string) string {
+	return fmt.Sprintf(`
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  region = "us"
+}
+
+resource "heroku_build" "foobar" {
+  app = heroku_app.foobar.name
+
+  source {
+    path = "test-fixtures/app"
+  }
+}
+
+resource "heroku_run" "foobar" {
+  app     = heroku_app.foobar.name
+  command = "%s"
+
+  depends_on = [heroku_build.foobar]
+}
+`, appName, command)
+}
@@ -136,7 +136,9 @@ func resourceHerokuSlugImport(d *schema.ResourceData, meta interface{}) ([]*sche
 	}
 
 	d.SetId(slug.ID)
-	d.Set("app", app)
+	if err := d.Set("app", app); err != nil {
+		return nil, setAttributeError("app", err)
+	}
 
 	setErr := setSlugState(d, slug)
 	if setErr != nil {
@@ -252,6 +254,9 @@ func resourceHerokuSlugRead(d *schema.ResourceData, meta interface{}) error {
 	app := getAppName(d)
 	slug, err := client.SlugInfo(context.TODO(), app, d.Id())
 	if err != nil {
+		if removeNotFound(d, "Slug", err) {
+			return nil
+		}
 		return fmt.Errorf("Error retrieving slug: %s", err)
 	}
 
@@ -395,18 +400,32 @@ func setSlugState(d *schema.ResourceData, slug *heroku.Slug) error {
 		"url":    slug.Blob.URL,
 	}}
 	if err := d.Set("blob", blob); err != nil {
-		log.Printf("[WARN] Error setting blob: %s", err)
+		return setAttributeError("blob", err)
+	}
+	if err := d.Set("buildpack_provided_description", slug.BuildpackProvidedDescription); err != nil {
+		return setAttributeError("buildpack_provided_description", err)
+	}
+	if err := d.Set("checksum", slug.Checksum); err != nil {
+		return setAttributeError("checksum", err)
+	}
+	if err := d.Set("commit", slug.Commit); err != nil {
+		return setAttributeError("commit", err)
+	}
+	if err := d.Set("commit_description", slug.CommitDescription); err != nil {
+		return setAttributeError("commit_description", err)
 	}
-	d.Set("buildpack_provided_description", slug.BuildpackProvidedDescription)
-	d.Set("checksum", slug.Checksum)
-	d.Set("commit", slug.Commit)
-	d.Set("commit_description", slug.CommitDescription)
 	if err := d.Set("process_types", slug.ProcessTypes); err != nil {
-		log.Printf("[WARN] Error setting process_types: %s", err)
+		return setAttributeError("process_types", err)
+	}
+	if err := d.Set("size", slug.Size); err != nil {
+		return setAttributeError("size", err)
+	}
+	if err := d.Set("stack_id", slug.Stack.ID); err != nil {
+		return setAttributeError("stack_id", err)
+	}
+	if err := d.Set("stack", slug.Stack.Name); err != nil {
+		return setAttributeError("stack", err)
 	}
-	d.Set("size", slug.Size)
-	d.Set("stack_id", slug.Stack.ID)
-	d.Set("stack", slug.Stack.Name)
 	return nil
 }
 
@@ -0,0 +1,474 @@
+package heroku
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuSourceCreate,
+		ReadContext:   resourceHerokuSourceRead,
+		DeleteContext: resourceHerokuSourceDelete,
+		CustomizeDiff: resourceHerokuSourceCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"exclude": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"local_checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The presigned URLs expire about an hour after upload, and there is no
+			// endpoint to refresh them, so both are kept out of plan output.
+			"get_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"put_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	path := d.Get("path").(string)
+	excludes := sourceExcludes(d.Get("exclude"))
+
+	localChecksum, err := hashSourcePath(path, excludes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tarballPath, cleanup, err := sourceTarballPath(path, excludes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer cleanup()
+
+	checksum, err := checksumSource(tarballPath)
+	if err != nil {
+		return diag.Errorf("Error calculating checksum for source %s: %s", tarballPath, err)
+	}
+
+	newSource, err := client.SourceCreate(ctx)
+	if err != nil {
+		return diag.Errorf("Error creating source: %s", err)
+	}
+
+	if err := uploadSource(tarballPath, "PUT", newSource.SourceBlob.PutURL); err != nil {
+		return diag.Errorf("Error uploading source: %s", err)
+	}
+
+	if err := verifySource(newSource.SourceBlob.GetURL, checksum); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Sources have no identifier of their own, so the checksum of the uploaded
+	// archive identifies the resource.
+	d.SetId(checksum)
+	if err := d.Set("checksum", checksum); err != nil {
+		return setAttributeDiagnostics("checksum", err)
+	}
+	if err := d.Set("local_checksum", localChecksum); err != nil {
+		return setAttributeDiagnostics("local_checksum", err)
+	}
+	if err := d.Set("get_url", newSource.SourceBlob.GetURL); err != nil {
+		return setAttributeDiagnostics("get_url", err)
+	}
+	if err := d.Set("put_url", newSource.SourceBlob.PutURL); err != nil {
+		return setAttributeDiagnostics("put_url", err)
+	}
+
+	log.Printf("[INFO] Uploaded source %s to %s", path, newSource.SourceBlob.GetURL)
+
+	return nil
+}
+
+// resourceHerokuSourceRead is a no-op as there is no GET source endpoint in the Heroku Platform API.
+func resourceHerokuSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceHerokuSourceDelete is a no-op as there is no DELETE source endpoint in the Heroku Platform API.
+// Uploaded sources expire on their own.
+func resourceHerokuSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for source resource so this is a no-op. Source will be removed from state.")
+	return nil
+}
+
+func resourceHerokuSourceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	// Detect changes to the content of the local source, so it is uploaded again.
+	path := diff.Get("path").(string)
+	if path == "" {
+		return nil
+	}
+
+	checksum, err := hashSourcePath(path, sourceExcludes(diff.Get("exclude")))
+	if err != nil {
+		return err
+	}
+
+	if old := diff.Get("local_checksum").(string); old != checksum {
+		log.Printf("[DEBUG] Diffing source: old '%s', real '%s'", old, checksum)
+		if err := diff.SetNew("local_checksum", checksum); err != nil {
+			return fmt.Errorf("Error updating source checksum: %s", err)
+		}
+		if diff.Id() != "" {
+			if err := diff.ForceNew("local_checksum"); err != nil {
+				return fmt.Errorf("Error forcing new source resource: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sourceTarballPath returns the path of a tarball for the given source path,
+// generating one without the files matched by excludes when the path is a directory.
+// The returned func removes any generated tarball.
+func sourceTarballPath(path string, excludes []string) (string, func(), error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return "", func() {}, fmt.Errorf("Error stating source path %s: %s", path, err)
+	}
+
+	if !fileInfo.IsDir() {
+		// Simply use the path to the file
+		return path, func() {}, nil
+	}
+
+	// Generate tarball from the directory
+	tarballPath, err := generateSourceTarball(path, excludes)
+	if err != nil {
+		return "", func() {}, fmt.Errorf("Error generating source tarball %s: %s", path, err)
+	}
+
+	return tarballPath, func() { cleanupSourceFile(tarballPath) }, nil
+}
+
+// sourceExcludes returns the exclude patterns of a source's "exclude" list.
+func sourceExcludes(v interface{}) []string {
+	var excludes []string
+	patterns, _ := v.([]interface{})
+	for _, pattern := range patterns {
+		excludes = append(excludes, pattern.(string))
+	}
+	return excludes
+}
+
+// hashSourcePath returns a hash of the contents of the given source path. For a
+// directory, the hash covers the relative path, executable bit and content of each
+// file not matched by excludes, so it is stable across checkouts and file timestamps.
+// For a tarball, it is the checksum of the archive.
+func hashSourcePath(path string, excludes []string) (string, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("Error stating source path %s: %s", path, err)
+	}
+
+	if !fileInfo.IsDir() {
+		return checksumSource(path)
+	}
+
+	hash := sha256.New()
+	err = walkSourceDirectory(path, excludes, func(file, rel string, info os.FileInfo) error {
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(hash, "link %s %s\x00", rel, target)
+		case info.Mode().IsRegular():
+			fmt.Fprintf(hash, "file %s %t\x00", rel, info.Mode()&0111 != 0)
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if _, err := io.Copy(hash, f); err != nil {
+				return err
+			}
+			fmt.Fprint(hash, "\x00")
+		case info.IsDir():
+			fmt.Fprintf(hash, "dir %s\x00", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error hashing source directory %s: %s", path, err)
+	}
+
+	return fmt.Sprintf("SHA256:%x", hash.Sum(nil)), nil
+}
+
+// walkSourceDirectory calls fn, in lexical order, for every file and directory within
+// root that is not matched by excludes. fn receives the file's path, its slash-separated
+// path relative to root and its info. Exclude patterns use filepath.Match syntax and
+// match either a file's name or its relative path; excluded directories are skipped
+// entirely.
+func walkSourceDirectory(root string, excludes []string, fn func(file, rel string, info os.FileInfo) error) error {
+	return filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel != "." && sourceExcluded(rel, excludes) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		return fn(file, rel, info)
+	})
+}
+
+// sourceExcluded reports whether the slash-separated relative path matches any of
+// the exclude patterns, by its name or by its full relative path.
+func sourceExcluded(rel string, excludes []string) bool {
+	name := rel[strings.LastIndex(rel, "/")+1:]
+	for _, pattern := range excludes {
+		pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadSourcePath tarballs the path when it is a directory, leaving out files matched
+// by excludes, then creates a new source
+// and uploads the archive to it. It returns the source's GET URL and the archive checksum.
+func uploadSourcePath(client *heroku.Service, path string, excludes []string) (string, string, error) {
+	tarballPath, cleanup, err := sourceTarballPath(path, excludes)
+	if err != nil {
+		return "", "", err
+	}
+	defer cleanup()
+
+	// Checksum, create, & upload source archive
+	checksum, err := checksumSource(tarballPath)
+	if err != nil {
+		return "", "", fmt.Errorf("Error calculating checksum for source %s: %s", tarballPath, err)
+	}
+	newSource, err := client.SourceCreate(context.TODO())
+	if err != nil {
+		return "", "", fmt.Errorf("Error creating source: %s", err)
+	}
+	err = uploadSource(tarballPath, "PUT", newSource.SourceBlob.PutURL)
+	if err != nil {
+		return "", "", fmt.Errorf("Error uploading source to %s: %s", newSource.SourceBlob.PutURL, err)
+	}
+	if err := verifySource(newSource.SourceBlob.GetURL, checksum); err != nil {
+		return "", "", err
+	}
+
+	return newSource.SourceBlob.GetURL, checksum, nil
+}
+
+// verifySource downloads an uploaded source and compares it to the checksum of the local archive.
+func verifySource(httpUrl, checksum string) error {
+	res, err := http.Get(httpUrl)
+	if err != nil {
+		return fmt.Errorf("Error downloading source for verification: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("Unsuccessful HTTP response from source download: %s", res.Status)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, res.Body); err != nil {
+		return fmt.Errorf("Error reading source for verification: %s", err)
+	}
+
+	if uploaded := fmt.Sprintf("SHA256:%x", hash.Sum(nil)); uploaded != checksum {
+		return fmt.Errorf("Uploaded source checksum %s does not match local checksum %s", uploaded, checksum)
+	}
+
+	return nil
+}
+
+func uploadSource(filePath, httpMethod, httpUrl string) error {
+	method := strings.ToUpper(httpMethod)
+	log.Printf("[DEBUG] Uploading source '%s' to %s %s", filePath, method, httpUrl)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("Error opening source.path: %s", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("Error stating source.path: %s", err)
+	}
+	defer file.Close()
+
+	httpClient := &http.Client{}
+	req, err := http.NewRequest(method, httpUrl, file)
+	if err != nil {
+		return fmt.Errorf("Error creating source upload request: %s", err)
+	}
+	req.ContentLength = stat.Size()
+	log.Printf("[DEBUG] Upload source request: %+v", req)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error uploading source: %s", err)
+	}
+
+	b, err := httputil.DumpResponse(res, true)
+	if err == nil {
+		// generate debug output if it's available
+		log.Printf("[DEBUG] Source upload response: %s", b)
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("Unsuccessful HTTP response from source upload: %s", res.Status)
+	}
+
+	return nil
+}
+
+func checksumSource(filePath string) (string, error) {
+	file, openErr := os.Open(filePath)
+	if openErr != nil {
+		return "", fmt.Errorf("Error opening source.path: %s", openErr)
+	}
+
+	hash := sha256.New()
+	if _, copyErr := io.Copy(hash, file); copyErr != nil {
+		return "", fmt.Errorf("Error reading source for checksum: %s", copyErr)
+	}
+
+	closeErr := file.Close()
+	if closeErr != nil {
+		return "", closeErr
+	}
+
+	checksum := fmt.Sprintf("SHA256:%x", hash.Sum(nil))
+	return checksum, nil
+}
+
+func cleanupSourceFile(filePath string) {
+	if filePath != "" {
+		err := os.Remove(filePath)
+		if err != nil {
+			log.Printf("[WARN] Error cleaning-up build source tarball: %s (%s)", err, filePath)
+		}
+	}
+}
+
+func generateSourceTarball(path string, excludes []string) (string, error) {
+	fi, err := ioutil.TempFile("", "terraform-heroku_build-source-*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	tf := fi.Name()
+	if err = writeSourceTarball(fi, path, excludes); err != nil {
+		err = fmt.Errorf("Error generating build source tarball %s of %s: %s", tf, path, err)
+	}
+	if closeErr := fi.Close(); err == nil {
+		err = closeErr
+	}
+	return tf, err
+}
+
+// writeSourceTarball writes a gzipped tarball of the directory to w. Entries are
+// nested in a top-level directory named after the source directory.
+func writeSourceTarball(w io.Writer, path string, excludes []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	baseDir := filepath.Base(path)
+
+	err := walkSourceDirectory(path, excludes, func(file, rel string, info os.FileInfo) error {
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			link = target
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(baseDir, rel))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
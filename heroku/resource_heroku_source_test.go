@@ -0,0 +1,106 @@
+package heroku
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuSource_LocalDirectory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSourceConfig("test-fixtures/app"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("heroku_source.foobar", "checksum", regexp.MustCompile(`^SHA256:[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr("heroku_source.foobar", "get_url", regexp.MustCompile(`^https://`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuSource_LocalTarball(t *testing.T) {
+	// Manually generated using `shasum --algorithm 256 app.tgz`
+	// per Heroku docs https://devcenter.heroku.com/articles/slug-checksums
+	sourceChecksum := "SHA256:14671a3dcf1ba3f4976438bfd4654da5d2b18ccefa59d10187ecc1286f08ee29"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSourceConfig("test-fixtures/app.tgz"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_source.foobar", "checksum", sourceChecksum),
+					resource.TestCheckResourceAttr("heroku_source.foobar", "id", sourceChecksum),
+					resource.TestCheckResourceAttrSet("heroku_source.foobar", "get_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestHashSourcePath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terraform-heroku-source-hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, content string) {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash := func() string {
+		h, err := hashSourcePath(dir, []string{".git", "node_modules"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	writeFile("index.js", "console.log('hello')")
+	writeFile(".git/HEAD", "ref: refs/heads/main")
+	writeFile("node_modules/dep/index.js", "module.exports = 1")
+	original := hash()
+
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "index.js"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if got := hash(); got != original {
+		t.Fatalf("hash changed when only file timestamps changed: %s != %s", got, original)
+	}
+
+	writeFile(".git/HEAD", "ref: refs/heads/other")
+	writeFile("node_modules/dep/index.js", "module.exports = 2")
+	if got := hash(); got != original {
+		t.Fatalf("hash changed when only excluded files changed: %s != %s", got, original)
+	}
+
+	writeFile("index.js", "console.log('goodbye')")
+	if got := hash(); got == original {
+		t.Fatal("hash did not change when file contents changed")
+	}
+}
+
+func testAccCheckHerokuSourceConfig(path string) string {
+	return fmt.Sprintf(`
+resource "heroku_source" "foobar" {
+  path = "%s"
+}`, path)
+}
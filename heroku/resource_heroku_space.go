@@ -8,12 +8,30 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	heroku "github.com/heroku/heroku-go/v5"
 )
 
 type spaceWithNAT struct {
 	heroku.Space
-	NAT heroku.SpaceNAT
+	resourceGeneration
+	StateReason *string `json:"state_reason"`
+	NAT         heroku.SpaceNAT
+}
+
+// stateReason returns the detail of the Space's state reported by the API, if any.
+func (s *spaceWithNAT) stateReason() string {
+	if s.StateReason == nil {
+		return ""
+	}
+	return *s.StateReason
+}
+
+// spaceCreateOpts extends heroku.SpaceCreateOpts with the generation of the space,
+// which is not yet part of the heroku-go client.
+type spaceCreateOpts struct {
+	heroku.SpaceCreateOpts
+	Generation *string `json:"generation,omitempty"`
 }
 
 func resourceHerokuSpace() *schema.Resource {
@@ -35,8 +53,8 @@ func resourceHerokuSpace() *schema.Resource {
 
 			"organization": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Optional: true,
+				Computed: true,
 			},
 
 			"cidr": {
@@ -73,16 +91,48 @@ func resourceHerokuSpace() *schema.Resource {
 				Default:  false,
 				ForceNew: true,
 			},
+
+			"generation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cedar", "fir"}, false),
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: resourceHerokuSpaceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
 func resourceHerokuSpaceCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).Api
+	config := meta.(*Config)
+	client := config.Api
 
-	opts := heroku.SpaceCreateOpts{}
+	opts := spaceCreateOpts{}
 	opts.Name = d.Get("name").(string)
 	opts.Team = d.Get("organization").(string)
+	if opts.Team == "" {
+		if config.DefaultTeam == "" {
+			return fmt.Errorf("Error creating space %s: organization must be set, or the provider's default_team", opts.Name)
+		}
+		opts.Team = config.DefaultTeam
+	}
 
 	if v, ok := d.GetOk("region"); ok {
 		vs := v.(string)
@@ -107,28 +157,36 @@ func resourceHerokuSpaceCreate(d *schema.ResourceData, meta interface{}) error {
 		opts.DataCIDR = &vs
 	}
 
-	space, err := client.SpaceCreate(context.TODO(), opts)
-	if err != nil {
+	if v, ok := d.GetOk("generation"); ok {
+		vs := v.(string)
+		log.Printf("[DEBUG] Creating a %s generation space", vs)
+		opts.Generation = &vs
+	}
+
+	var space heroku.Space
+	if err := client.Post(context.TODO(), &space, "/spaces", opts); err != nil {
 		return err
 	}
 
+	// Track the Space in state before waiting, so that a Space that fails to be
+	// allocated is tainted and deleted on the next apply, rather than orphaned.
 	d.SetId(space.ID)
 	log.Printf("[INFO] Space ID: %s", d.Id())
 
 	// Wait for the Space to be allocated
 	log.Printf("[DEBUG] Waiting for Space (%s) to be allocated", d.Id())
 	stateConf := &resource.StateChangeConf{
-		Pending: []string{"allocating"},
-		Target:  []string{"allocated"},
-		Refresh: SpaceStateRefreshFunc(client, d.Id()),
-		Timeout: 20 * time.Minute,
+		Pending:      []string{"allocating"},
+		Target:       []string{"allocated"},
+		Refresh:      spaceAllocationStateRefreshFunc(client, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		PollInterval: 20 * time.Second,
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Space (%s) to become available: %s", d.Id(), err)
+		return taintedCreateError(fmt.Sprintf("Space %s", space.Name), stateWaitError(fmt.Sprintf("Space (%s) to become available", d.Id()), schema.TimeoutCreate, err))
 	}
 
-	config := meta.(*Config)
 	time.Sleep(time.Duration(config.PostSpaceCreateDelay) * time.Second)
 
 	return resourceHerokuSpaceRead(d, meta)
@@ -139,18 +197,44 @@ func resourceHerokuSpaceRead(d *schema.ResourceData, meta interface{}) error {
 
 	spaceRaw, _, err := SpaceStateRefreshFunc(client, d.Id())()
 	if err != nil {
+		if removeNotFound(d, "Space", err) {
+			return nil
+		}
 		return err
 	}
 
 	space := spaceRaw.(*spaceWithNAT)
 
-	d.Set("name", space.Name)
-	d.Set("organization", space.Organization.Name)
-	d.Set("region", space.Region.Name)
-	d.Set("outbound_ips", space.NAT.Sources)
-	d.Set("shield", space.Shield)
-	d.Set("cidr", space.CIDR)
-	d.Set("data_cidr", space.DataCIDR)
+	if err := d.Set("name", space.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("organization", space.Organization.Name); err != nil {
+		return setAttributeError("organization", err)
+	}
+	if err := d.Set("region", space.Region.Name); err != nil {
+		return setAttributeError("region", err)
+	}
+	if err := d.Set("outbound_ips", space.NAT.Sources); err != nil {
+		return setAttributeError("outbound_ips", err)
+	}
+	if err := d.Set("shield", space.Shield); err != nil {
+		return setAttributeError("shield", err)
+	}
+	if err := d.Set("cidr", space.CIDR); err != nil {
+		return setAttributeError("cidr", err)
+	}
+	if err := d.Set("data_cidr", space.DataCIDR); err != nil {
+		return setAttributeError("data_cidr", err)
+	}
+	if err := d.Set("generation", space.GenerationName()); err != nil {
+		return setAttributeError("generation", err)
+	}
+	if err := d.Set("state", space.State); err != nil {
+		return setAttributeError("state", err)
+	}
+	if err := d.Set("state_reason", space.stateReason()); err != nil {
+		return setAttributeError("state_reason", err)
+	}
 
 	log.Printf("[DEBUG] Set NAT source IPs to %s for %s", space.NAT.Sources, d.Id())
 
@@ -170,7 +254,20 @@ func resourceHerokuSpaceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return nil
+	// Transfer the Space to its new team in place, as replacing it would
+	// also replace its apps and peering connections.
+	if d.HasChange("organization") {
+		team := d.Get("organization").(string)
+		log.Printf("[INFO] Transferring space %s to team %s", d.Id(), team)
+
+		opts := heroku.SpaceTransferTransferOpts{NewOwner: team}
+		if _, err := client.SpaceTransferTransfer(context.TODO(), d.Id(), opts); err != nil {
+			return fmt.Errorf("Error transferring space (%s) to team %s, spaces can only be "+
+				"transferred between teams of the same enterprise account: %s", d.Id(), team, err)
+		}
+	}
+
+	return resourceHerokuSpaceRead(d, meta)
 }
 
 func resourceHerokuSpaceDelete(d *schema.ResourceData, meta interface{}) error {
@@ -182,26 +279,63 @@ func resourceHerokuSpaceDelete(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Wait for the Space to be deleted, so that its name can be reused right away
+	log.Printf("[DEBUG] Waiting for Space (%s) to be deleted", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"allocated", "allocating", "deleting"},
+		Target:       []string{"deleted"},
+		Refresh:      spaceDeletionStateRefreshFunc(client, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		PollInterval: 20 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return stateWaitError(fmt.Sprintf("Space (%s) to be deleted", d.Id()), schema.TimeoutDelete, err)
+	}
+
 	d.SetId("")
 	return nil
 }
 
+// resourceHerokuSpaceCustomizeDiff rejects options that are not available to
+// Fir generation spaces at plan time.
+func resourceHerokuSpaceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Get("generation").(string) != "fir" {
+		return nil
+	}
+
+	if diff.Get("shield").(bool) {
+		return fmt.Errorf("shield is not supported by Fir generation spaces")
+	}
+
+	return nil
+}
+
+// retrieveSpaceGeneration returns the generation of a space, e.g. "cedar" or "fir".
+func retrieveSpaceGeneration(id string, client *heroku.Service) (string, error) {
+	var space struct {
+		resourceGeneration
+	}
+	if err := client.Get(context.TODO(), &space, fmt.Sprintf("/spaces/%v", id), nil, nil); err != nil {
+		return "", err
+	}
+	return space.GenerationName(), nil
+}
+
 // SpaceStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // a Space.
 func SpaceStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		space, err := client.SpaceInfo(context.TODO(), id)
+		var s spaceWithNAT
+		err := client.Get(context.TODO(), &s, fmt.Sprintf("/spaces/%v", id), nil, nil)
 		if err != nil {
 			log.Printf("[DEBUG] %s (%s)", err, id)
 			return nil, "", err
 		}
+		space := &s.Space
 
-		s := spaceWithNAT{
-			Space: *space,
-		}
-
-		if space.State == "allocating" {
-			log.Printf("[DEBUG] Still allocating: %s (%s)", space.State, id)
+		if space.State != "allocated" {
+			log.Printf("[DEBUG] Not allocated: %s (%s)", space.State, id)
 			return &s, space.State, nil
 		}
 
@@ -216,3 +350,52 @@ func SpaceStateRefreshFunc(client *heroku.Service, id string) resource.StateRefr
 		return &s, space.State, nil
 	}
 }
+
+// spaceAllocationStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch a Space being allocated. It fails as soon as the Space reaches a state other
+// than "allocating" or "allocated", instead of polling until the timeout.
+func spaceAllocationStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		raw, state, err := SpaceStateRefreshFunc(client, id)()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if state != "allocating" && state != "allocated" {
+			return nil, "", spaceStateError(raw.(*spaceWithNAT))
+		}
+
+		return raw, state, nil
+	}
+}
+
+// spaceDeletionStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch a Space being deleted. The state is "deleted" once the Space is not found.
+func spaceDeletionStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var space spaceWithNAT
+		err := client.Get(context.TODO(), &space, fmt.Sprintf("/spaces/%v", id), nil, nil)
+		if err != nil {
+			if isNotFoundError(err) {
+				return id, "deleted", nil
+			}
+			log.Printf("[DEBUG] %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		switch space.State {
+		case "allocated", "allocating", "deleting":
+			return &space, space.State, nil
+		}
+
+		return nil, "", spaceStateError(&space)
+	}
+}
+
+// spaceStateError describes a Space in a terminal failure state.
+func spaceStateError(space *spaceWithNAT) error {
+	if reason := space.stateReason(); reason != "" {
+		return fmt.Errorf("Space (%s) is %s: %s", space.ID, space.State, reason)
+	}
+	return fmt.Errorf("Space (%s) is %s", space.ID, space.State)
+}
@@ -42,14 +42,18 @@ func resourceHerokuSpaceAppAccess() *schema.Resource {
 	}
 }
 
-//callback for schema.ResourceImporter
+// callback for schema.ResourceImporter
 func resourceHerokuSpaceAppAccessImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	space, email, err := parseCompositeID(d.Id())
 	if err != nil {
 		return nil, err
 	}
-	d.Set("space", space)
-	d.Set("email", email)
+	if err := d.Set("space", space); err != nil {
+		return nil, setAttributeError("space", err)
+	}
+	if err := d.Set("email", email); err != nil {
+		return nil, setAttributeError("email", err)
+	}
 	readErr := resourceHerokuSpaceAppAccessRead(d, meta)
 	if readErr != nil {
 		return nil, readErr
@@ -57,7 +61,7 @@ func resourceHerokuSpaceAppAccessImport(d *schema.ResourceData, meta interface{}
 	return []*schema.ResourceData{d}, nil
 }
 
-//callback for schema Resource.Create and schema Resource.Update
+// callback for schema Resource.Create and schema Resource.Update
 func resourceHerokuSpaceAppAccessSet(d *schema.ResourceData, meta interface{}) error {
 	_, err := updateSpaceAppAccess(d.Get("permissions").(*schema.Set), d, meta)
 	if err != nil {
@@ -66,25 +70,34 @@ func resourceHerokuSpaceAppAccessSet(d *schema.ResourceData, meta interface{}) e
 	return resourceHerokuSpaceAppAccessRead(d, meta)
 }
 
-//callback for schema Resource.Read
+// callback for schema Resource.Read
 func resourceHerokuSpaceAppAccessRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Config).Api
 	space := d.Get("space").(string)
 	email := d.Get("email").(string)
 	spaceAppAccess, err := client.SpaceAppAccessInfo(context.TODO(), space, email)
 	if err != nil {
+		if removeNotFound(d, "Space app access", err) {
+			return nil
+		}
 		return err
 	}
 	d.SetId(spaceAppAccess.User.ID)
-	d.Set("space", spaceAppAccess.Space.Name)
-	d.Set("email", spaceAppAccess.User.Email)
-	d.Set("permissions", createPermissionsList(spaceAppAccess))
+	if err := d.Set("space", spaceAppAccess.Space.Name); err != nil {
+		return setAttributeError("space", err)
+	}
+	if err := d.Set("email", spaceAppAccess.User.Email); err != nil {
+		return setAttributeError("email", err)
+	}
+	if err := d.Set("permissions", createPermissionsList(spaceAppAccess)); err != nil {
+		return setAttributeError("permissions", err)
+	}
 	return nil
 }
 
-//callback for schema Resource.Delete
-//Members cannot be deleted from a space with this resource, they are removed
-//from the state file and their permissions are cleared out.
+// callback for schema Resource.Delete
+// Members cannot be deleted from a space with this resource, they are removed
+// from the state file and their permissions are cleared out.
 func resourceHerokuSpaceAppAccessDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := updateSpaceAppAccess(nil, d, meta)
 	if err != nil {
@@ -94,7 +107,7 @@ func resourceHerokuSpaceAppAccessDelete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-//utility method to call heroku.SpaceAppAccessUpdate
+// utility method to call heroku.SpaceAppAccessUpdate
 func updateSpaceAppAccess(permissions *schema.Set, d *schema.ResourceData, meta interface{}) (*heroku.SpaceAppAccess, error) {
 	email := d.Get("email").(string)
 	space := d.Get("space").(string)
@@ -107,8 +120,8 @@ func updateSpaceAppAccess(permissions *schema.Set, d *schema.ResourceData, meta
 	return spaceAppAccess, nil
 }
 
-//utility method to convert SpaceAppAccess to a simple string array of
-//permission names.
+// utility method to convert SpaceAppAccess to a simple string array of
+// permission names.
 func createPermissionsList(spaceAppAccess *heroku.SpaceAppAccess) []string {
 	perms := make([]string, 0)
 	if spaceAppAccess != nil {
@@ -119,8 +132,8 @@ func createPermissionsList(spaceAppAccess *heroku.SpaceAppAccess) []string {
 	return perms
 }
 
-//utility method to convert a schema.Set of simple permission names to
-//SpaceAppAccessUpdateOpts suitable as input into the the heroku API.
+// utility method to convert a schema.Set of simple permission names to
+// SpaceAppAccessUpdateOpts suitable as input into the the heroku API.
 func createSpaceAppAccessUpdateOpts(permSet *schema.Set) heroku.SpaceAppAccessUpdateOpts {
 	//The choice of using anonymous structs in heroku-go should be revisited per
 	//https://github.com/interagent/schematic/issues/17
@@ -41,17 +41,25 @@ func resourceHerokuSpaceInboundRuleset() *schema.Resource {
 					},
 				},
 			},
+
+			"merge": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
+type inboundRule = struct {
+	Action string `json:"action" url:"action,key"`
+	Source string `json:"source" url:"source,key"`
+}
+
 func getRulesetFromSchema(d *schema.ResourceData) heroku.InboundRulesetCreateOpts {
 	rules := d.Get("rule").(*schema.Set)
 
-	var ruleset []*struct {
-		Action string `json:"action" url:"action,key"`
-		Source string `json:"source" url:"source,key"`
-	}
+	var ruleset []*inboundRule
 
 	for _, r := range rules.List() {
 		data := r.(map[string]interface{})
@@ -74,6 +82,19 @@ func resourceHerokuSpaceInboundRulesetSet(d *schema.ResourceData, meta interface
 	spaceIdentity := d.Get("space").(string)
 	ruleset := getRulesetFromSchema(d)
 
+	// When merging, keep the rules of the current ruleset whose sources are not
+	// managed by Terraform, neither before nor after this change.
+	if d.Get("merge").(bool) {
+		o, n := d.GetChange("rule")
+		managed := inboundRuleSources(o.(*schema.Set).Union(n.(*schema.Set)))
+
+		external, err := externalInboundRules(client, spaceIdentity, managed)
+		if err != nil {
+			return err
+		}
+		ruleset.Rules = append(external, ruleset.Rules...)
+	}
+
 	_, err := client.InboundRulesetCreate(context.TODO(), spaceIdentity, ruleset)
 	if err != nil {
 		return fmt.Errorf("Error creating inbound ruleset for space (%s): %s", spaceIdentity, err)
@@ -88,11 +109,24 @@ func resourceHerokuSpaceInboundRulesetRead(d *schema.ResourceData, meta interfac
 	spaceIdentity := d.Get("space").(string)
 	ruleset, err := client.InboundRulesetCurrent(context.TODO(), spaceIdentity)
 	if err != nil {
-		return fmt.Errorf("Error creating inbound ruleset for space (%s): %s", spaceIdentity, err)
+		if removeNotFound(d, "Inbound ruleset", err) {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving inbound ruleset for space (%s): %s", spaceIdentity, err)
+	}
+
+	// When merging, only the rules with sources managed by Terraform are tracked.
+	var managed map[string]bool
+	if d.Get("merge").(bool) {
+		managed = inboundRuleSources(d.Get("rule").(*schema.Set))
 	}
 
 	rulesList := []interface{}{}
 	for _, rule := range ruleset.Rules {
+		if managed != nil && !managed[rule.Source] {
+			continue
+		}
+
 		values := map[string]interface{}{}
 		values["source"] = rule.Source
 		values["action"] = rule.Action
@@ -100,8 +134,12 @@ func resourceHerokuSpaceInboundRulesetRead(d *schema.ResourceData, meta interfac
 	}
 
 	d.SetId(ruleset.ID)
-	d.Set("rule", rulesList)
-	d.Set("space", ruleset.Space.Name)
+	if err := d.Set("rule", rulesList); err != nil {
+		return setAttributeError("rule", err)
+	}
+	if err := d.Set("space", ruleset.Space.Name); err != nil {
+		return setAttributeError("space", err)
+	}
 
 	return nil
 }
@@ -115,18 +153,23 @@ func resourceHerokuSpaceInboundRulesetDelete(d *schema.ResourceData, meta interf
 	// an inbound ruleset. There's no delete API method for this. So when we "delete" the ruleset
 	// we reset things back to what Heroku sets when the HPS is created. Given that the default
 	// allows all traffic from all places, this is akin to deleting all filtering.
-	var rules []*struct {
-		Action string `json:"action" url:"action,key"`
-		Source string `json:"source" url:"source,key"`
+	// When merging, only the Terraform managed rules are removed, unless no other
+	// rules remain.
+	var rules []*inboundRule
+	if d.Get("merge").(bool) {
+		external, err := externalInboundRules(client, spaceIdentity, inboundRuleSources(d.Get("rule").(*schema.Set)))
+		if err != nil {
+			return err
+		}
+		rules = external
 	}
 
-	rules = append(rules, &struct {
-		Action string `json:"action" url:"action,key"`
-		Source string `json:"source" url:"source,key"`
-	}{
-		Action: "allow",
-		Source: "0.0.0.0/0",
-	})
+	if len(rules) == 0 {
+		rules = append(rules, &inboundRule{
+			Action: "allow",
+			Source: "0.0.0.0/0",
+		})
+	}
 
 	_, err := client.InboundRulesetCreate(context.TODO(), spaceIdentity, heroku.InboundRulesetCreateOpts{Rules: rules})
 	if err != nil {
@@ -136,3 +179,33 @@ func resourceHerokuSpaceInboundRulesetDelete(d *schema.ResourceData, meta interf
 	d.SetId("")
 	return nil
 }
+
+// inboundRuleSources returns the sources of a set of rule blocks.
+func inboundRuleSources(rules *schema.Set) map[string]bool {
+	sources := make(map[string]bool, rules.Len())
+	for _, r := range rules.List() {
+		sources[r.(map[string]interface{})["source"].(string)] = true
+	}
+	return sources
+}
+
+// externalInboundRules returns the rules of the current inbound ruleset of a space
+// whose sources are not managed by Terraform.
+func externalInboundRules(client *heroku.Service, spaceIdentity string, managed map[string]bool) ([]*inboundRule, error) {
+	current, err := client.InboundRulesetCurrent(context.TODO(), spaceIdentity)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving inbound ruleset for space (%s): %s", spaceIdentity, err)
+	}
+
+	var rules []*inboundRule
+	for _, rule := range current.Rules {
+		if managed[rule.Source] {
+			continue
+		}
+		rules = append(rules, &inboundRule{
+			Action: rule.Action,
+			Source: rule.Source,
+		})
+	}
+	return rules, nil
+}
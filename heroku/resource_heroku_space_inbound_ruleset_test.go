@@ -34,6 +34,41 @@ func TestAccHerokuSpaceInboundRuleset_Basic(t *testing.T) {
 	})
 }
 
+func TestAccHerokuSpaceInboundRuleset_Merge(t *testing.T) {
+	var space heroku.Space
+	spaceName := fmt.Sprintf("tftest1-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpaceInboundRulesetConfig_merge(spaceName, org, "8.8.8.8/32"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &space),
+					resource.TestCheckResourceAttr(
+						"heroku_space_inbound_ruleset.one", "rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"heroku_space_inbound_ruleset.two", "rule.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuSpaceInboundRulesetConfig_merge(spaceName, org, "8.8.4.4/32"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_space_inbound_ruleset.one", "rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"heroku_space_inbound_ruleset.two", "rule.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckHerokuSpaceInboundRulesetConfig_basic(spaceName, orgName string) string {
 	return fmt.Sprintf(`
 resource "heroku_space" "foobar" {
@@ -57,3 +92,35 @@ resource "heroku_space_inbound_ruleset" "foobar" {
 }
 `, spaceName, orgName)
 }
+
+func testAccCheckHerokuSpaceInboundRulesetConfig_merge(spaceName, orgName, source string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+resource "heroku_space_inbound_ruleset" "one" {
+  space = heroku_space.foobar.name
+  merge = true
+
+  rule {
+    action = "allow"
+    source = "%s"
+  }
+}
+
+resource "heroku_space_inbound_ruleset" "two" {
+  space = heroku_space.foobar.name
+  merge = true
+
+  rule {
+    action = "allow"
+    source = "8.8.8.0/24"
+  }
+
+  depends_on = [heroku_space_inbound_ruleset.one]
+}
+`, spaceName, orgName, source)
+}
@@ -0,0 +1,162 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuSpaceOutboundRuleset() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuSpaceOutboundRulesetSet,
+		Read:   resourceHerokuSpaceOutboundRulesetRead,
+		Update: resourceHerokuSpaceOutboundRulesetSet,
+		Delete: resourceHerokuSpaceOutboundRulesetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rule": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsCIDRNetwork(0, 32),
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp", "any"}, false),
+						},
+						"from_port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"to_port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type outboundRule = struct {
+	FromPort int    `json:"from_port" url:"from_port,key"`
+	Protocol string `json:"protocol" url:"protocol,key"`
+	Target   string `json:"target" url:"target,key"`
+	ToPort   int    `json:"to_port" url:"to_port,key"`
+}
+
+func getOutboundRulesetFromSchema(d *schema.ResourceData) (heroku.OutboundRulesetCreateOpts, error) {
+	rules := d.Get("rule").(*schema.Set)
+
+	var ruleset []*outboundRule
+	for _, r := range rules.List() {
+		data := r.(map[string]interface{})
+
+		rule := &outboundRule{
+			Target:   data["target"].(string),
+			Protocol: data["protocol"].(string),
+			FromPort: data["from_port"].(int),
+			ToPort:   data["to_port"].(int),
+		}
+		if rule.FromPort > rule.ToPort {
+			return heroku.OutboundRulesetCreateOpts{}, fmt.Errorf("from_port %d of outbound rule to %s must not be greater than to_port %d", rule.FromPort, rule.Target, rule.ToPort)
+		}
+
+		ruleset = append(ruleset, rule)
+	}
+
+	return heroku.OutboundRulesetCreateOpts{Rules: ruleset}, nil
+}
+
+func resourceHerokuSpaceOutboundRulesetSet(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).Api
+
+	spaceIdentity := d.Get("space").(string)
+	ruleset, err := getOutboundRulesetFromSchema(d)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.OutboundRulesetCreate(context.TODO(), spaceIdentity, ruleset); err != nil {
+		return fmt.Errorf("Error creating outbound ruleset for space (%s): %s", spaceIdentity, err)
+	}
+
+	return resourceHerokuSpaceOutboundRulesetRead(d, meta)
+}
+
+func resourceHerokuSpaceOutboundRulesetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).Api
+
+	spaceIdentity := d.Get("space").(string)
+	ruleset, err := client.OutboundRulesetCurrent(context.TODO(), spaceIdentity)
+	if err != nil {
+		if removeNotFound(d, "Outbound ruleset", err) {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving outbound ruleset for space (%s): %s", spaceIdentity, err)
+	}
+
+	rulesList := []interface{}{}
+	for _, rule := range ruleset.Rules {
+		values := map[string]interface{}{}
+		values["target"] = rule.Target
+		values["protocol"] = rule.Protocol
+		values["from_port"] = rule.FromPort
+		values["to_port"] = rule.ToPort
+		rulesList = append(rulesList, values)
+	}
+
+	d.SetId(ruleset.ID)
+	if err := d.Set("rule", rulesList); err != nil {
+		return setAttributeError("rule", err)
+	}
+	if err := d.Set("space", ruleset.Space.Name); err != nil {
+		return setAttributeError("space", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuSpaceOutboundRulesetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Config).Api
+
+	spaceIdentity := d.Get("space").(string)
+
+	// Like inbound rulesets, outbound rulesets cannot be deleted. Reset the ruleset
+	// to the default that Heroku sets when the space is created, which allows all
+	// traffic to all destinations.
+	rules := []*outboundRule{
+		{
+			Target:   "0.0.0.0/0",
+			Protocol: "any",
+			FromPort: 0,
+			ToPort:   65535,
+		},
+	}
+
+	_, err := client.OutboundRulesetCreate(context.TODO(), spaceIdentity, heroku.OutboundRulesetCreateOpts{Rules: rules})
+	if err != nil {
+		return fmt.Errorf("Error resetting outbound ruleset for space (%s): %s", spaceIdentity, err)
+	}
+
+	d.SetId("")
+	return nil
+}
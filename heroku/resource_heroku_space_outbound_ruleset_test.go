@@ -0,0 +1,63 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func TestAccHerokuSpaceOutboundRuleset_Basic(t *testing.T) {
+	var space heroku.Space
+	spaceName := fmt.Sprintf("tftest1-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "heroku_space_outbound_ruleset.foobar",
+				Config:       testAccCheckHerokuSpaceOutboundRulesetConfig_basic(spaceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &space),
+					resource.TestCheckResourceAttr(
+						"heroku_space_outbound_ruleset.foobar", "rule.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuSpaceOutboundRulesetConfig_basic(spaceName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+}
+
+resource "heroku_space_outbound_ruleset" "foobar" {
+  space = heroku_space.foobar.name
+
+  rule {
+    target    = "10.0.0.0/8"
+    protocol  = "tcp"
+    from_port = 5432
+    to_port   = 5432
+  }
+
+  rule {
+    target    = "8.8.8.8/32"
+    protocol  = "udp"
+    from_port = 53
+    to_port   = 53
+  }
+}
+`, spaceName, orgName)
+}
@@ -48,6 +48,10 @@ func resourceHerokuSpacePeeringConnectionAccepter() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -85,18 +89,22 @@ func resourceHerokuSpacePeeringConnectionAccepterCreate(d *schema.ResourceData,
 		Pending: []string{"initiating-request", "pending", "pending-acceptance", "provisioning"},
 		Target:  []string{"active"},
 		Refresh: SpacePeeringConnAccepterStateRefreshFunc(client, spaceIdentity, d.Id()),
-		Timeout: 20 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
 
 	finalPeerConn, err := stateConf.WaitForState()
 	if err != nil {
-		return fmt.Errorf("Error waiting for Space (%s) to become available: %s", d.Id(), err)
+		return taintedCreateError(fmt.Sprintf("Peering connection %s", d.Id()), stateWaitError(fmt.Sprintf("peering connection (%s) to become active", d.Id()), schema.TimeoutCreate, err))
 	}
 
 	p := finalPeerConn.(*spacePeerInfo)
 
-	d.Set("status", p.Status)
-	d.Set("type", p.Type)
+	if err := d.Set("status", p.Status); err != nil {
+		return setAttributeError("status", err)
+	}
+	if err := d.Set("type", p.Type); err != nil {
+		return setAttributeError("type", err)
+	}
 
 	return nil
 }
@@ -108,13 +116,22 @@ func resourceHerokuSpacePeeringConnectionAccepterRead(d *schema.ResourceData, me
 
 	peeringConn, err := client.PeeringInfo(context.TODO(), spaceIdentity, d.Id())
 	if err != nil {
+		if removeNotFound(d, "Peering connection", err) {
+			return nil
+		}
 		return err
 	}
 
 	d.SetId(peeringConn.PcxID)
-	d.Set("status", peeringConn.Status)
-	d.Set("type", peeringConn.Type)
-	d.Set("vpc_peering_connection_id", peeringConn.PcxID)
+	if err := d.Set("status", peeringConn.Status); err != nil {
+		return setAttributeError("status", err)
+	}
+	if err := d.Set("type", peeringConn.Type); err != nil {
+		return setAttributeError("type", err)
+	}
+	if err := d.Set("vpc_peering_connection_id", peeringConn.PcxID); err != nil {
+		return setAttributeError("vpc_peering_connection_id", err)
+	}
 
 	return nil
 }
@@ -3,6 +3,7 @@ package heroku
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -32,6 +33,8 @@ func TestAccHerokuSpace_Basic(t *testing.T) {
 					testAccCheckHerokuSpaceAttributes(&space, spaceName),
 					resource.TestCheckResourceAttrSet(
 						"heroku_space.foobar", "outbound_ips.#"),
+					resource.TestCheckResourceAttr(
+						"heroku_space.foobar", "state", "allocated"),
 				),
 			},
 			{
@@ -46,6 +49,48 @@ func TestAccHerokuSpace_Basic(t *testing.T) {
 	})
 }
 
+func TestAccHerokuSpace_Transfer(t *testing.T) {
+	var space heroku.Space
+	spaceName := fmt.Sprintf("tftest1-%s", acctest.RandString(10))
+	org := testAccConfig.GetSpaceOrganizationOrSkip(t)
+	newOrg := testAccConfig.GetSpaceTransferTeamOrSkip(t)
+	var spaceID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpaceConfig_basic(spaceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &space),
+					resource.TestCheckResourceAttr("heroku_space.foobar", "organization", org),
+					func(s *terraform.State) error {
+						spaceID = space.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCheckHerokuSpaceConfig_basic(spaceName, newOrg),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &space),
+					resource.TestCheckResourceAttr("heroku_space.foobar", "organization", newOrg),
+					func(s *terraform.State) error {
+						if space.ID != spaceID {
+							return fmt.Errorf("space was replaced instead of transferred: %s != %s", space.ID, spaceID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccHerokuSpace_Shield(t *testing.T) {
 	var space heroku.Space
 	spaceName := fmt.Sprintf("tfshieldtest-%s", acctest.RandString(10))
@@ -71,6 +116,52 @@ func TestAccHerokuSpace_Shield(t *testing.T) {
 	})
 }
 
+func TestAccHerokuSpace_Fir(t *testing.T) {
+	var space heroku.Space
+	spaceName := fmt.Sprintf("tffirtest-%s", acctest.RandString(10))
+	appName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHerokuSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuSpaceConfig_fir(spaceName, appName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHerokuSpaceExists("heroku_space.foobar", &space),
+					testAccCheckHerokuSpaceAttributes(&space, spaceName),
+					resource.TestCheckResourceAttr(
+						"heroku_space.foobar", "generation", "fir"),
+					resource.TestCheckResourceAttr(
+						"heroku_app.foobar", "generation", "fir"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHerokuSpace_FirShield(t *testing.T) {
+	spaceName := fmt.Sprintf("tffirtest-%s", acctest.RandString(10))
+	org := testAccConfig.GetAnyOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckHerokuSpaceConfig_firShield(spaceName, org),
+				ExpectError: regexp.MustCompile(`shield is not supported by Fir generation spaces`),
+			},
+		},
+	})
+}
+
 func TestAccHerokuSpace_CIDRs(t *testing.T) {
 	var space heroku.Space
 	spaceName := fmt.Sprintf("tfcidrtest-%s", acctest.RandString(10))
@@ -124,6 +215,39 @@ resource "heroku_space" "foobar" {
 `, spaceName, orgName)
 }
 
+func testAccCheckHerokuSpaceConfig_fir(spaceName, appName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+  generation   = "fir"
+}
+
+resource "heroku_app" "foobar" {
+  name   = "%s"
+  space  = heroku_space.foobar.name
+  region = "virginia"
+
+  organization {
+    name = "%s"
+  }
+}
+`, spaceName, orgName, appName, orgName)
+}
+
+func testAccCheckHerokuSpaceConfig_firShield(spaceName, orgName string) string {
+	return fmt.Sprintf(`
+resource "heroku_space" "foobar" {
+  name         = "%s"
+  organization = "%s"
+  region       = "virginia"
+  generation   = "fir"
+  shield       = true
+}
+`, spaceName, orgName)
+}
+
 func testAccCheckHerokuSpaceConfig_cidr(spaceName, orgName string, cidr string) string {
 	return fmt.Sprintf(`
 resource "heroku_space" "foobar" {
@@ -95,15 +95,30 @@ func resourceHerokuSpaceVPNConnectionRead(d *schema.ResourceData, meta interface
 
 	conn, err := client.VPNConnectionInfo(context.TODO(), space, id)
 	if err != nil {
+		if removeNotFound(d, "VPN connection", err) {
+			return nil
+		}
 		return fmt.Errorf("Error reading VPN information: %v", err)
 	}
 
-	d.Set("space", space)
-	d.Set("name", conn.Name)
-	d.Set("public_ip", conn.PublicIP)
-	d.Set("routable_cidrs", conn.RoutableCidrs)
-	d.Set("space_cidr_block", conn.SpaceCIDRBlock)
-	d.Set("ike_version", conn.IKEVersion)
+	if err := d.Set("space", space); err != nil {
+		return setAttributeError("space", err)
+	}
+	if err := d.Set("name", conn.Name); err != nil {
+		return setAttributeError("name", err)
+	}
+	if err := d.Set("public_ip", conn.PublicIP); err != nil {
+		return setAttributeError("public_ip", err)
+	}
+	if err := d.Set("routable_cidrs", conn.RoutableCidrs); err != nil {
+		return setAttributeError("routable_cidrs", err)
+	}
+	if err := d.Set("space_cidr_block", conn.SpaceCIDRBlock); err != nil {
+		return setAttributeError("space_cidr_block", err)
+	}
+	if err := d.Set("ike_version", conn.IKEVersion); err != nil {
+		return setAttributeError("ike_version", err)
+	}
 
 	tunnels := []map[string]interface{}{}
 	for _, t := range conn.Tunnels {
@@ -112,7 +127,9 @@ func resourceHerokuSpaceVPNConnectionRead(d *schema.ResourceData, meta interface
 			"pre_shared_key": t.PreSharedKey,
 		})
 	}
-	d.Set("tunnels", tunnels)
+	if err := d.Set("tunnels", tunnels); err != nil {
+		return setAttributeError("tunnels", err)
+	}
 
 	return nil
 }
@@ -135,21 +152,23 @@ func resourceHerokuSpaceVPNConnectionCreate(d *schema.ResourceData, meta interfa
 		return fmt.Errorf("Error creating VPN: %v", err)
 	}
 
+	// Track the VPN in state before waiting, so that a VPN that fails to be
+	// provisioned is tainted and deleted on the next apply, rather than orphaned.
+	d.SetId(buildCompositeID(space, conn.ID))
+
 	log.Printf("[DEBUG] Waiting for VPN (%s) to be allocated", conn.ID)
 	stateConf := &resource.StateChangeConf{
 		Pending:      []string{"pending", "provisioning"},
 		Target:       []string{"active"},
 		Refresh:      spaceVPNConnectionStateRefreshFunc(client, space, conn.ID),
-		Timeout:      30 * time.Minute,
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 		PollInterval: 20 * time.Second,
 	}
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("error waiting for VPN to become available: %s", err)
+		return taintedCreateError(fmt.Sprintf("VPN connection %s", conn.Name), stateWaitError(fmt.Sprintf("VPN (%s) to become available", conn.ID), schema.TimeoutCreate, err))
 	}
 
-	d.SetId(buildCompositeID(space, conn.ID))
-
 	return resourceHerokuSpaceVPNConnectionRead(d, meta)
 }
 
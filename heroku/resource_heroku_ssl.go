@@ -1,10 +1,16 @@
 package heroku
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
@@ -32,8 +38,9 @@ func resourceHerokuSSL() *schema.Resource {
 			},
 
 			"certificate_chain": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentCertificateChain,
 			},
 
 			"private_key": {
@@ -42,6 +49,12 @@ func resourceHerokuSSL() *schema.Resource {
 				Optional:  true, // This should be 'Required' using 'Optional' to make things easier during resource import.
 			},
 
+			"migrate_from_ssl_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -64,9 +77,15 @@ func resourceHerokuSSLImport(d *schema.ResourceData, meta interface{}) ([]*schem
 	}
 
 	d.SetId(ep.ID)
-	d.Set("app_id", ep.App.ID)
-	d.Set("certificate_chain", ep.CertificateChain)
-	d.Set("name", ep.Name)
+	if err := d.Set("app_id", ep.App.ID); err != nil {
+		return nil, setAttributeError("app_id", err)
+	}
+	if err := d.Set("certificate_chain", ep.CertificateChain); err != nil {
+		return nil, setAttributeError("certificate_chain", err)
+	}
+	if err := d.Set("name", ep.Name); err != nil {
+		return nil, setAttributeError("name", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -91,6 +110,12 @@ func resourceHerokuSSLCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	d.SetId(ep.ID)
 
+	if v, ok := d.GetOk("migrate_from_ssl_endpoint"); ok {
+		if err := migrateSSLEndpoint(ctx, client, appID, v.(string), ep); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceHerokuSSLRead(ctx, d, meta)
 }
 
@@ -99,12 +124,21 @@ func resourceHerokuSSLRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	ep, err := client.SniEndpointInfo(context.Background(), getAppId(d), d.Id())
 	if err != nil {
+		if removeNotFound(d, "SSL endpoint", err) {
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	d.Set("app_id", ep.App.ID)
-	d.Set("certificate_chain", ep.CertificateChain)
-	d.Set("name", ep.Name)
+	if err := d.Set("app_id", ep.App.ID); err != nil {
+		return setAttributeDiagnostics("app_id", err)
+	}
+	if err := d.Set("certificate_chain", ep.CertificateChain); err != nil {
+		return setAttributeDiagnostics("certificate_chain", err)
+	}
+	if err := d.Set("name", ep.Name); err != nil {
+		return setAttributeDiagnostics("name", err)
+	}
 	// TODO: need to add d.Set("private_key")
 
 	return nil
@@ -115,7 +149,12 @@ func resourceHerokuSSLUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	appID := getAppId(d)
 
+	// Update the certificate in place, so its domains keep serving HTTPS.
 	if d.HasChange("certificate_chain") || d.HasChange("private_key") {
+		if d.Get("private_key").(string) == "" {
+			return diag.Errorf("private_key must be set to update the certificate of SSL endpoint %s", d.Id())
+		}
+
 		opts := heroku.SniEndpointUpdateOpts{
 			CertificateChain: d.Get("certificate_chain").(string),
 			PrivateKey:       d.Get("private_key").(string),
@@ -137,6 +176,12 @@ func resourceHerokuSSLUpdate(ctx context.Context, d *schema.ResourceData, meta i
 func resourceHerokuSSLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Config).Api
 
+	// When the certificate is replaced by another one on the same app, move its
+	// domains to the replacement first, so they never stop serving HTTPS.
+	if err := moveSniEndpointDomains(ctx, client, getAppId(d), d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
 	log.Printf("[INFO] Deleting SSL Cert: %s", d.Id())
 
 	_, err := client.SniEndpointDelete(context.TODO(), getAppId(d), d.Id())
@@ -150,3 +195,204 @@ func resourceHerokuSSLDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	return nil
 }
+
+// migrateSSLEndpoint moves the domains of a legacy SSL endpoint to a new SNI
+// endpoint, then removes the SSL endpoint and its add-on. The SSL endpoint is only
+// removed once all of its domains are served by the SNI endpoint.
+func migrateSSLEndpoint(ctx context.Context, client *heroku.Service, appID, sslEndpointID string, ep *heroku.SniEndpoint) error {
+	sslEndpoint, err := client.SSLEndpointInfo(ctx, appID, sslEndpointID)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL endpoint %s to migrate: %s", sslEndpointID, err)
+	}
+
+	for _, hostname := range sslEndpoint.Domains {
+		if !sslCertCoversHostname(ep.SSLCert.CertDomains, hostname) {
+			return fmt.Errorf("SSL Cert %s does not cover domain %s of SSL endpoint %s, which is left in place", ep.ID, hostname, sslEndpoint.Name)
+		}
+	}
+
+	for _, hostname := range sslEndpoint.Domains {
+		log.Printf("[INFO] Moving domain %s from SSL endpoint %s to SSL Cert %s", hostname, sslEndpoint.Name, ep.ID)
+		opts := heroku.DomainUpdateOpts{SniEndpoint: &ep.ID}
+		if _, err := client.DomainUpdate(ctx, appID, hostname, opts); err != nil {
+			return fmt.Errorf("Error moving domain %s to SSL Cert %s: %s", hostname, ep.ID, err)
+		}
+	}
+
+	log.Printf("[INFO] Deleting SSL endpoint: %s", sslEndpoint.Name)
+	if _, err := client.SSLEndpointDelete(ctx, appID, sslEndpoint.ID); err != nil {
+		return fmt.Errorf("Error deleting SSL endpoint %s: %s", sslEndpoint.Name, err)
+	}
+
+	addons, err := client.AddOnListByApp(ctx, appID, nil)
+	if err != nil {
+		return fmt.Errorf("Error retrieving add-ons of app %s: %s", appID, err)
+	}
+	for _, addon := range addons {
+		if addon.AddonService.Name != "ssl" {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting SSL endpoint add-on: %s", addon.Name)
+		if _, err := client.AddOnDelete(ctx, appID, addon.ID); err != nil {
+			return fmt.Errorf("Error deleting SSL endpoint add-on %s: %s", addon.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// moveSniEndpointDomains associates the domains of an SNI endpoint with the newest
+// other endpoint of the app whose certificate covers them. Domains without such a
+// replacement are left as they are.
+func moveSniEndpointDomains(ctx context.Context, client *heroku.Service, appID, id string) error {
+	domains, err := client.DomainList(ctx, appID, &heroku.ListRange{Field: "hostname", Max: 1000})
+	if err != nil {
+		return fmt.Errorf("Error retrieving domains of app %s: %s", appID, err)
+	}
+
+	var hostnames []string
+	for _, do := range domains {
+		if do.SniEndpoint != nil && do.SniEndpoint.ID == id {
+			hostnames = append(hostnames, do.Hostname)
+		}
+	}
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	endpoints, err := client.SniEndpointList(ctx, appID, &heroku.ListRange{Field: "id", Max: 1000})
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL endpoints of app %s: %s", appID, err)
+	}
+
+	for _, hostname := range hostnames {
+		var replacement *heroku.SniEndpoint
+		for i, ep := range endpoints {
+			if ep.ID == id || !sslCertCoversHostname(ep.SSLCert.CertDomains, hostname) {
+				continue
+			}
+			if replacement == nil || ep.CreatedAt.After(replacement.CreatedAt) {
+				replacement = &endpoints[i]
+			}
+		}
+
+		if replacement == nil {
+			log.Printf("[WARN] No other SSL certificate of app %s covers domain %s, it will stop serving HTTPS", appID, hostname)
+			continue
+		}
+
+		log.Printf("[INFO] Moving domain %s from SSL Cert %s to %s", hostname, id, replacement.ID)
+		opts := heroku.DomainUpdateOpts{SniEndpoint: &replacement.ID}
+		if _, err := client.DomainUpdate(ctx, appID, hostname, opts); err != nil {
+			return fmt.Errorf("Error moving domain %s to SSL Cert %s: %s", hostname, replacement.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// sslCertCoversHostname reports whether a hostname matches one of a certificate's
+// domains, where a wildcard domain covers a single label.
+func sslCertCoversHostname(certDomains []interface{}, hostname string) bool {
+	hostname = strings.ToLower(hostname)
+	for _, v := range certDomains {
+		certDomain, ok := v.(string)
+		if !ok {
+			continue
+		}
+		certDomain = strings.ToLower(certDomain)
+
+		if certDomain == hostname {
+			return true
+		}
+		if strings.HasPrefix(certDomain, "*.") {
+			i := strings.Index(hostname, ".")
+			if i > 0 && hostname[i:] == certDomain[1:] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// suppressEquivalentCertificateChain ignores differences between the configured
+// certificate chain and the one returned by Heroku, which may reorder or complete
+// it. Chains are equivalent when they have the same leaf certificate, and every
+// configured certificate is in the chain returned by Heroku.
+func suppressEquivalentCertificateChain(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	oldCerts, err := parseCertificateChain(old)
+	if err != nil {
+		return false
+	}
+	newCerts, err := parseCertificateChain(new)
+	if err != nil {
+		return false
+	}
+
+	if !bytes.Equal(certificateChainLeaf(oldCerts).Raw, certificateChainLeaf(newCerts).Raw) {
+		return false
+	}
+
+	fingerprints := make(map[[sha256.Size]byte]bool, len(oldCerts))
+	for _, cert := range oldCerts {
+		fingerprints[sha256.Sum256(cert.Raw)] = true
+	}
+	for _, cert := range newCerts {
+		if !fingerprints[sha256.Sum256(cert.Raw)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseCertificateChain parses the certificates of a PEM encoded chain.
+func parseCertificateChain(chain string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found in chain")
+	}
+
+	return certs, nil
+}
+
+// certificateChainLeaf returns the first certificate of a chain that did not
+// issue any of the others, whatever the order of the chain.
+func certificateChainLeaf(certs []*x509.Certificate) *x509.Certificate {
+	for _, cert := range certs {
+		issuer := false
+		for _, other := range certs {
+			if other != cert && bytes.Equal(other.RawIssuer, cert.RawSubject) {
+				issuer = true
+				break
+			}
+		}
+		if !issuer {
+			return cert
+		}
+	}
+	return certs[0]
+}
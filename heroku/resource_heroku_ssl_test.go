@@ -2,11 +2,19 @@ package heroku
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -152,3 +160,84 @@ func testAccCheckHerokuSSLExists(n string, endpoint *heroku.SniEndpoint) resourc
 		return nil
 	}
 }
+
+func TestSSLCertCoversHostname(t *testing.T) {
+	certDomains := []interface{}{"example.com", "*.apps.example.com"}
+
+	tests := map[string]bool{
+		"example.com":          true,
+		"EXAMPLE.com":          true,
+		"www.example.com":      false,
+		"one.apps.example.com": true,
+		"a.b.apps.example.com": false,
+		"apps.example.com":     false,
+	}
+
+	for hostname, expected := range tests {
+		if actual := sslCertCoversHostname(certDomains, hostname); actual != expected {
+			t.Errorf("sslCertCoversHostname(%q) = %t, expected %t", hostname, actual, expected)
+		}
+	}
+}
+
+func TestSuppressEquivalentCertificateChain(t *testing.T) {
+	root, rootKey := generateTestCertificate(t, "root", true, "", nil)
+	intermediate, intermediateKey := generateTestCertificate(t, "intermediate", true, root, rootKey)
+	leaf, _ := generateTestCertificate(t, "leaf.example.com", false, intermediate, intermediateKey)
+	other, _ := generateTestCertificate(t, "other.example.com", false, intermediate, intermediateKey)
+
+	tests := []struct {
+		name     string
+		old, new string
+		expected bool
+	}{
+		{"identical", leaf + intermediate, leaf + intermediate, true},
+		{"whitespace", leaf + intermediate, "\n" + leaf + "\n\n" + intermediate + "\n", true},
+		{"reordered", intermediate + leaf, leaf + intermediate, true},
+		{"completed", leaf + intermediate + root, leaf + intermediate, true},
+		{"different leaf", other + intermediate, leaf + intermediate, false},
+		{"missing intermediate", leaf, leaf + intermediate, false},
+		{"invalid", leaf, "not a certificate", false},
+		{"new resource", "", leaf, false},
+	}
+
+	for _, tt := range tests {
+		if actual := suppressEquivalentCertificateChain("certificate_chain", tt.old, tt.new, nil); actual != tt.expected {
+			t.Errorf("%s: suppressEquivalentCertificateChain = %t, expected %t", tt.name, actual, tt.expected)
+		}
+	}
+}
+
+// generateTestCertificate generates a PEM encoded certificate, signed by the
+// parent certificate or self-signed when there is no parent.
+func generateTestCertificate(t *testing.T, cn string, isCA bool, parentPEM string, parentKey *ecdsa.PrivateKey) (string, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+
+	parent, signer := template, key
+	if parentPEM != "" {
+		block, _ := pem.Decode([]byte(parentPEM))
+		if parent, err = x509.ParseCertificate(block.Bytes); err != nil {
+			t.Fatal(err)
+		}
+		signer = parentKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), key
+}
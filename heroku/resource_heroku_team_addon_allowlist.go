@@ -0,0 +1,210 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuTeamAddonAllowlist() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuTeamAddonAllowlistCreate,
+		ReadContext:   resourceHerokuTeamAddonAllowlistRead,
+		UpdateContext: resourceHerokuTeamAddonAllowlistUpdate,
+		DeleteContext: resourceHerokuTeamAddonAllowlistDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHerokuTeamAddonAllowlistImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"addon_services": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"enforce": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceHerokuTeamAddonAllowlistImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("team", d.Id()); err != nil {
+		return nil, setAttributeError("team", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceHerokuTeamAddonAllowlistCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Get("team").(string)
+
+	for _, s := range d.Get("addon_services").(*schema.Set).List() {
+		if err := allowTeamAddonService(ctx, client, team, s.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(team)
+
+	if err := setTeamAddonsControls(ctx, client, team, d.Get("enforce").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceHerokuTeamAddonAllowlistRead(ctx, d, meta)
+}
+
+func resourceHerokuTeamAddonAllowlistRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	allowed, err := client.AllowedAddOnServiceListByTeam(ctx, d.Id(), nil)
+	if err != nil {
+		if removeNotFound(d, "Add-on allowlist of team", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving allowed add-on services of team %s: %s", d.Id(), err)
+	}
+
+	preferences, err := client.TeamPreferencesList(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "Add-on allowlist of team", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving preferences of team %s: %s", d.Id(), err)
+	}
+
+	services := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		services = append(services, a.AddonService.Name)
+	}
+
+	if err := d.Set("team", d.Id()); err != nil {
+		return setAttributeDiagnostics("team", err)
+	}
+	if err := d.Set("addon_services", services); err != nil {
+		return setAttributeDiagnostics("addon_services", err)
+	}
+	if err := d.Set("enforce", preferences.AddonsControls != nil && *preferences.AddonsControls); err != nil {
+		return setAttributeDiagnostics("enforce", err)
+	}
+
+	return nil
+}
+
+func resourceHerokuTeamAddonAllowlistUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Id()
+
+	if d.HasChange("addon_services") {
+		o, n := d.GetChange("addon_services")
+		oldServices := o.(*schema.Set)
+		newServices := n.(*schema.Set)
+
+		// Allow the new services before disallowing the old ones, so that an enforced
+		// allowlist is never briefly empty.
+		for _, s := range newServices.Difference(oldServices).List() {
+			if err := allowTeamAddonService(ctx, client, team, s.(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if err := disallowTeamAddonServices(ctx, client, team, oldServices.Difference(newServices)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("enforce") {
+		if err := setTeamAddonsControls(ctx, client, team, d.Get("enforce").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceHerokuTeamAddonAllowlistRead(ctx, d, meta)
+}
+
+func resourceHerokuTeamAddonAllowlistDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	team := d.Id()
+
+	// Stop enforcing the allowlist before emptying it, so that installing add-ons
+	// is not blocked entirely in between.
+	if err := setTeamAddonsControls(ctx, client, team, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := disallowTeamAddonServices(ctx, client, team, d.Get("addon_services").(*schema.Set)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func allowTeamAddonService(ctx context.Context, client *heroku.Service, team, service string) error {
+	log.Printf("[DEBUG] Allowing add-on service %s for team %s", service, team)
+	opts := heroku.AllowedAddOnServiceCreateByTeamOpts{AddonService: &service}
+	if _, err := client.AllowedAddOnServiceCreateByTeam(ctx, team, opts); err != nil {
+		return fmt.Errorf("Error allowing add-on service %s for team %s: %s", service, team, err)
+	}
+	return nil
+}
+
+// disallowTeamAddonServices removes the given add-on services from the team's
+// allowlist, ignoring services that are no longer allowed.
+func disallowTeamAddonServices(ctx context.Context, client *heroku.Service, team string, services *schema.Set) error {
+	if services.Len() == 0 {
+		return nil
+	}
+
+	allowed, err := client.AllowedAddOnServiceListByTeam(ctx, team, nil)
+	if err != nil {
+		return fmt.Errorf("Error retrieving allowed add-on services of team %s: %s", team, err)
+	}
+
+	for _, a := range allowed {
+		if !services.Contains(a.AddonService.Name) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Disallowing add-on service %s for team %s", a.AddonService.Name, team)
+		if _, err := client.AllowedAddOnServiceDeleteByTeam(ctx, team, a.ID); err != nil {
+			return fmt.Errorf("Error disallowing add-on service %s for team %s: %s", a.AddonService.Name, team, err)
+		}
+	}
+
+	return nil
+}
+
+// setTeamAddonsControls sets whether the team only allows installing add-ons of
+// its allowed add-on services.
+func setTeamAddonsControls(ctx context.Context, client *heroku.Service, team string, enabled bool) error {
+	log.Printf("[DEBUG] Setting add-ons controls of team %s to %t", team, enabled)
+	opts := heroku.TeamPreferencesUpdateOpts{AddonsControls: &enabled}
+	if _, err := client.TeamPreferencesUpdate(ctx, team, opts); err != nil {
+		return fmt.Errorf("Error updating add-ons controls of team %s: %s", team, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuTeamAddonAllowlist_Basic(t *testing.T) {
+	// Add-on allowlists are only available to Heroku Enterprise teams.
+	team := testAccConfig.GetSpaceOrganizationOrSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuTeamAddonAllowlistConfig(team, `"heroku-postgresql"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_team_addon_allowlist.foobar", "addon_services.#", "1"),
+					resource.TestCheckResourceAttr(
+						"heroku_team_addon_allowlist.foobar", "enforce", "true"),
+				),
+			},
+			{
+				Config: testAccCheckHerokuTeamAddonAllowlistConfig(team, `"heroku-postgresql", "heroku-redis"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"heroku_team_addon_allowlist.foobar", "addon_services.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "heroku_team_addon_allowlist.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuTeamAddonAllowlistConfig(team, services string) string {
+	return fmt.Sprintf(`
+resource "heroku_team_addon_allowlist" "foobar" {
+  team           = "%s"
+  addon_services = [%s]
+}
+`, team, services)
+}
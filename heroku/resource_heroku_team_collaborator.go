@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -124,17 +123,21 @@ func resourceHerokuTeamCollaboratorRead(d *schema.ResourceData, meta interface{}
 	teamCollaborator, err := resourceHerokuTeamCollaboratorRetrieve(d.Id(), d.Get("app").(string), client)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "Couldn't find that user") {
-			// If user cannot be found, remove the resource from state.
-			d.SetId("")
+		if removeNotFound(d, "Team collaborator", err) {
 			return nil
 		}
 		return err
 	}
 
-	d.Set("app", teamCollaborator.AppName)
-	d.Set("email", teamCollaborator.TeamCollaborator.Email)
-	d.Set("permissions", teamCollaborator.Permissions)
+	if err := d.Set("app", teamCollaborator.AppName); err != nil {
+		return setAttributeError("app", err)
+	}
+	if err := d.Set("email", teamCollaborator.TeamCollaborator.Email); err != nil {
+		return setAttributeError("email", err)
+	}
+	if err := d.Set("permissions", teamCollaborator.Permissions); err != nil {
+		return setAttributeError("permissions", err)
+	}
 
 	return nil
 }
@@ -219,7 +222,7 @@ func resourceHerokuTeamCollaboratorRetrieve(id string, appName string, client *h
 	err := teamCollaborator.Update()
 
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error retrieving team collaborator: %s", err)
+		return nil, fmt.Errorf("[ERROR] Error retrieving team collaborator: %w", err)
 	}
 
 	return &teamCollaborator, nil
@@ -233,6 +236,10 @@ func (tc *teamCollaborator) Update() error {
 	teamCollaborator, err := tc.Client.TeamAppCollaboratorInfo(context.TODO(), tc.AppName, tc.Id)
 
 	if err != nil {
+		// The permissions of a collaborator that is not found cannot be found either.
+		if isNotFoundError(err) {
+			return err
+		}
 		errs = append(errs, err)
 	} else {
 		tc.TeamCollaborator = &herokuTeamCollaborator{}
@@ -276,15 +283,21 @@ func resourceHerokuTeamCollaboratorImport(d *schema.ResourceData, meta interface
 	}
 
 	d.SetId(collaborator.ID)
-	d.Set("app", collaborator.App.Name)
-	d.Set("email", collaborator.User.Email)
+	if err := d.Set("app", collaborator.App.Name); err != nil {
+		return nil, setAttributeError("app", err)
+	}
+	if err := d.Set("email", collaborator.User.Email); err != nil {
+		return nil, setAttributeError("email", err)
+	}
 
 	var perms []string
 	for _, p := range collaborator.Permissions {
 		perms = append(perms, p.Name)
 	}
 
-	d.Set("permissions", perms)
+	if err := d.Set("permissions", perms); err != nil {
+		return nil, setAttributeError("permissions", err)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
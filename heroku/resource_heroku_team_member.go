@@ -2,7 +2,7 @@ package heroku
 
 import (
 	"context"
-	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	heroku "github.com/heroku/heroku-go/v5"
@@ -52,8 +52,12 @@ func resourceHerokuTeamMemberImport(d *schema.ResourceData, meta interface{}) ([
 	if err != nil {
 		return nil, err
 	}
-	d.Set("team", team)
-	d.Set("email", email)
+	if err := d.Set("team", team); err != nil {
+		return nil, setAttributeError("team", err)
+	}
+	if err := d.Set("email", email); err != nil {
+		return nil, setAttributeError("email", err)
+	}
 
 	readErr := resourceHerokuTeamMemberRead(d, meta)
 	if readErr != nil {
@@ -96,6 +100,9 @@ func resourceHerokuTeamMemberRead(d *schema.ResourceData, meta interface{}) erro
 
 	members, err := client.TeamMemberList(context.TODO(), team, &heroku.ListRange{Field: "email"})
 	if err != nil {
+		if removeNotFound(d, "Team member", err) {
+			return nil
+		}
 		return err
 	}
 
@@ -108,13 +115,23 @@ func resourceHerokuTeamMemberRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if found.ID == "" {
-		return fmt.Errorf("Could not find member record for %s on team %s", email, team)
+		log.Printf("[WARN] Team member %s not found on team %s, removing from state", email, team)
+		d.SetId("")
+		return nil
 	}
 
-	d.Set("team", team)
-	d.Set("email", found.Email)
-	d.Set("role", found.Role)
-	d.Set("federated", found.Federated)
+	if err := d.Set("team", team); err != nil {
+		return setAttributeError("team", err)
+	}
+	if err := d.Set("email", found.Email); err != nil {
+		return setAttributeError("email", err)
+	}
+	if err := d.Set("role", found.Role); err != nil {
+		return setAttributeError("role", err)
+	}
+	if err := d.Set("federated", found.Federated); err != nil {
+		return setAttributeError("federated", err)
+	}
 
 	return nil
 }
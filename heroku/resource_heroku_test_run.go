@@ -0,0 +1,229 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func resourceHerokuTestRun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHerokuTestRunCreate,
+		ReadContext:   resourceHerokuTestRunRead,
+		DeleteContext: resourceHerokuTestRunDelete,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"commit_sha": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"commit_branch": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"commit_message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source_url", "source_path"},
+				ValidateFunc: validateSourceUrl,
+			},
+
+			"source_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"warning_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"actor_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceHerokuTestRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	opts := heroku.TestRunCreateOpts{
+		Pipeline:      d.Get("pipeline").(string),
+		CommitSha:     d.Get("commit_sha").(string),
+		CommitBranch:  d.Get("commit_branch").(string),
+		CommitMessage: d.Get("commit_message").(string),
+	}
+
+	if v, ok := d.GetOk("source_path"); ok {
+		getURL, _, err := uploadSourcePath(client, v.(string), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		opts.SourceBlobURL = getURL
+	} else {
+		opts.SourceBlobURL = d.Get("source_url").(string)
+	}
+
+	if v, ok := d.GetOk("organization"); ok {
+		vs := v.(string)
+		opts.Organization = &vs
+	}
+
+	log.Printf("[DEBUG] Creating test run of %s on pipeline %s", opts.CommitSha, opts.Pipeline)
+	testRun, err := client.TestRunCreate(ctx, opts)
+	if err != nil {
+		return diag.Errorf("Error creating test run: %s", err)
+	}
+
+	// Track the test run in state even if it fails below, so that a failed
+	// test run is replaced on the next apply.
+	d.SetId(testRun.ID)
+
+	log.Printf("[DEBUG] Waiting for test run #%d (%s) to complete", testRun.Number, testRun.ID)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "creating", "building", "running", "debugging"},
+		Target:  []string{"succeeded"},
+		Refresh: TestRunStateRefreshFunc(client, testRun.ID),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return diag.FromErr(taintedCreateError(fmt.Sprintf("Test run #%d", testRun.Number), err))
+	}
+
+	log.Printf("[INFO] Test run #%d (%s) succeeded", testRun.Number, testRun.ID)
+
+	return resourceHerokuTestRunRead(ctx, d, meta)
+}
+
+func resourceHerokuTestRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Config).Api
+
+	testRun, err := client.TestRunInfo(ctx, d.Id())
+	if err != nil {
+		if removeNotFound(d, "Test run", err) {
+			return nil
+		}
+		return diag.Errorf("Error retrieving test run: %s", err)
+	}
+
+	if err := d.Set("pipeline", testRun.Pipeline.ID); err != nil {
+		return setAttributeDiagnostics("pipeline", err)
+	}
+	if err := d.Set("commit_sha", testRun.CommitSha); err != nil {
+		return setAttributeDiagnostics("commit_sha", err)
+	}
+	if err := d.Set("commit_branch", testRun.CommitBranch); err != nil {
+		return setAttributeDiagnostics("commit_branch", err)
+	}
+	if err := d.Set("commit_message", testRun.CommitMessage); err != nil {
+		return setAttributeDiagnostics("commit_message", err)
+	}
+	if err := d.Set("number", testRun.Number); err != nil {
+		return setAttributeDiagnostics("number", err)
+	}
+	if err := d.Set("status", testRun.Status); err != nil {
+		return setAttributeDiagnostics("status", err)
+	}
+	if err := d.Set("actor_email", testRun.ActorEmail); err != nil {
+		return setAttributeDiagnostics("actor_email", err)
+	}
+
+	if testRun.Message != nil {
+		if err := d.Set("message", *testRun.Message); err != nil {
+			return setAttributeDiagnostics("message", err)
+		}
+	}
+
+	if testRun.WarningMessage != nil {
+		if err := d.Set("warning_message", *testRun.WarningMessage); err != nil {
+			return setAttributeDiagnostics("warning_message", err)
+		}
+	}
+
+	return nil
+}
+
+// resourceHerokuTestRunDelete only removes the test run from state, as test runs
+// are part of the pipeline's CI history and cannot be deleted.
+func resourceHerokuTestRunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] There is no DELETE for test run resource so this is a no-op. Resource will be removed from state.")
+	d.SetId("")
+	return nil
+}
+
+// TestRunStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch a Heroku CI test run.
+func TestRunStateRefreshFunc(client *heroku.Service, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		testRun, err := client.TestRunInfo(context.TODO(), id)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get test run status: %s (%s)", err, id)
+			return nil, "", err
+		}
+
+		switch testRun.Status {
+		case "failed", "errored", "cancelled":
+			msg := fmt.Sprintf("Test run #%d (%s) of %s %s", testRun.Number, testRun.ID, testRun.CommitSha, testRun.Status)
+			if testRun.Message != nil && *testRun.Message != "" {
+				msg = fmt.Sprintf("%s: %s", msg, *testRun.Message)
+			}
+			return nil, "", fmt.Errorf("%s", msg)
+		}
+
+		return testRun, testRun.Status, nil
+	}
+}
@@ -0,0 +1,39 @@
+package heroku
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHerokuTestRun_LocalSource(t *testing.T) {
+	pipelineID := testAccConfig.GetPipelineIDorSkip(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckHerokuTestRunConfig_localSource(pipelineID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("heroku_test_run.foobar", "status", "succeeded"),
+					resource.TestCheckResourceAttr("heroku_test_run.foobar", "commit_branch", "main"),
+					resource.TestCheckResourceAttrSet("heroku_test_run.foobar", "number"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHerokuTestRunConfig_localSource(pipelineID string) string {
+	return fmt.Sprintf(`
+resource "heroku_test_run" "foobar" {
+  pipeline       = "%s"
+  commit_sha     = "0123456789abcdef0123456789abcdef01234567"
+  commit_branch  = "main"
+  commit_message = "Terraform acceptance test"
+  source_path    = "test-fixtures/test-run"
+}
+`, pipelineID)
+}
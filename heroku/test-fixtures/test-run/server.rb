@@ -0,0 +1,14 @@
+# A tiny server using the Heroku stack's built-in Ruby.
+require 'webrick'
+
+server = WEBrick::HTTPServer.new :Port => ENV["PORT"]
+
+server.mount_proc '/' do |req, res|
+    res.body = "Hello, world!\n"
+end
+
+trap 'INT' do
+  server.shutdown
+end
+
+server.start
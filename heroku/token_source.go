@@ -0,0 +1,248 @@
+package heroku
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOAuthTokenURL is the URL that Heroku OAuth client credentials are
+// exchanged for access tokens at.
+const DefaultOAuthTokenURL = "https://id.heroku.com/oauth/token"
+
+// tokenExpiryMargin is how long before its expiry an access token is replaced, so
+// that it does not expire while a request is in flight.
+const tokenExpiryMargin = time.Minute
+
+// apiToken is a credential of the Platform API, with its expiry when known. A
+// token with a Username is an API key, sent with basic authentication like the
+// Heroku CLI does; others are OAuth access tokens.
+type apiToken struct {
+	Username string
+	Value    string
+	Expiry   time.Time
+}
+
+func (t *apiToken) valid() bool {
+	return t != nil && t.Value != "" && (t.Expiry.IsZero() || time.Now().Add(tokenExpiryMargin).Before(t.Expiry))
+}
+
+// tokenSource provides the credentials of API requests. When the API rejects a
+// token, for example because a short-lived token expired during an apply, Refresh
+// replaces it and reports whether a different token is now available, so that the
+// rejected request can be retried.
+type tokenSource interface {
+	Token() (*apiToken, error)
+	Refresh(rejected *apiToken) (bool, error)
+}
+
+// apiKeyTokenSource provides an API key, which is re-read from wherever it was
+// configured when rejected, e.g. after `heroku login` renewed the key in ~/.netrc.
+type apiKeyTokenSource struct {
+	mu     sync.Mutex
+	token  *apiToken
+	reload func() (email, apiKey string, err error)
+}
+
+func newAPIKeyTokenSource(email, apiKey string, reload func() (string, string, error)) *apiKeyTokenSource {
+	return &apiKeyTokenSource{
+		token:  &apiToken{Username: email, Value: apiKey},
+		reload: reload,
+	}
+}
+
+func (s *apiKeyTokenSource) Token() (*apiToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token, nil
+}
+
+func (s *apiKeyTokenSource) Refresh(rejected *apiToken) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another request may have already replaced the rejected key.
+	if s.token.Value != rejected.Value {
+		return true, nil
+	}
+
+	email, apiKey, err := s.reload()
+	if err != nil {
+		return false, fmt.Errorf("Error re-reading Heroku credentials: %s", err)
+	}
+	if apiKey == "" || apiKey == rejected.Value {
+		return false, nil
+	}
+
+	log.Printf("[INFO] Heroku API key was rejected, retrying with the re-read API key")
+	s.token = &apiToken{Username: email, Value: apiKey}
+
+	return true, nil
+}
+
+// oauthTokenSource exchanges Heroku OAuth client credentials for access tokens,
+// getting a new token when the current one is about to expire. With a refresh
+// token, it is used for the exchange instead of the client_credentials grant.
+type oauthTokenSource struct {
+	mu           sync.Mutex
+	clientID     string
+	clientSecret string
+	refreshToken string
+	tokenURL     string
+	httpClient   *http.Client
+	token        *apiToken
+}
+
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func newOAuthTokenSource(clientID, clientSecret, refreshToken, tokenURL string, transport http.RoundTripper) *oauthTokenSource {
+	return &oauthTokenSource{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		tokenURL:     tokenURL,
+		httpClient:   &http.Client{Timeout: 30 * time.Second, Transport: transport},
+	}
+}
+
+func (s *oauthTokenSource) Token() (*apiToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token, nil
+	}
+
+	return s.exchange()
+}
+
+func (s *oauthTokenSource) Refresh(rejected *apiToken) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another request may have already replaced the rejected token.
+	if s.token != nil && s.token.Value != rejected.Value {
+		return true, nil
+	}
+
+	log.Printf("[INFO] Heroku OAuth access token was rejected, exchanging the client credentials again")
+	token, err := s.exchange()
+	if err != nil {
+		return false, err
+	}
+
+	return token.Value != rejected.Value, nil
+}
+
+func (s *oauthTokenSource) exchange() (*apiToken, error) {
+	form := url.Values{}
+	form.Set("client_id", s.clientID)
+	form.Set("client_secret", s.clientSecret)
+	if s.refreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", s.refreshToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+
+	log.Printf("[DEBUG] Exchanging OAuth client credentials of %s for an access token", s.clientID)
+	res, err := s.httpClient.Post(s.tokenURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("Error exchanging OAuth client credentials: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error exchanging OAuth client credentials: %s", res.Status)
+	}
+
+	var body oauthTokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("Error decoding OAuth access token: %s", err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("Error exchanging OAuth client credentials: no access token returned")
+	}
+
+	s.token = &apiToken{Value: body.AccessToken}
+	if body.ExpiresIn > 0 {
+		s.token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	if body.RefreshToken != "" {
+		s.refreshToken = body.RefreshToken
+	}
+
+	return s.token, nil
+}
+
+// tokenTransport authenticates requests with the token of its source. A request
+// that is rejected as unauthorized is retried once when the source has a new token.
+type tokenTransport struct {
+	source    tokenSource
+	transport http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.transport.RoundTrip(authenticatedRequest(req, token))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// Requests with a body can only be retried when it can be read again.
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+
+	refreshed, err := t.source.Refresh(token)
+	if err != nil {
+		log.Printf("[WARN] %s", err)
+		return res, nil
+	}
+	if !refreshed {
+		return res, nil
+	}
+
+	token, err = t.source.Token()
+	if err != nil {
+		return res, nil
+	}
+
+	retry := authenticatedRequest(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry.Body = body
+	}
+	res.Body.Close()
+
+	return t.transport.RoundTrip(retry)
+}
+
+// authenticatedRequest returns a copy of the request authenticated with the token,
+// as RoundTrippers must not modify the request they were given.
+func authenticatedRequest(req *http.Request, token *apiToken) *http.Request {
+	req = req.Clone(req.Context())
+	if token.Username != "" {
+		req.SetBasicAuth(token.Username, token.Value)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token.Value)
+	}
+	return req
+}
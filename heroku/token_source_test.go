@@ -0,0 +1,157 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	heroku "github.com/heroku/heroku-go/v5"
+)
+
+func TestOAuthTokenSource(t *testing.T) {
+	exchanges := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("got grant_type %q, want client_credentials", got)
+		}
+		if r.PostForm.Get("client_id") != "id" || r.PostForm.Get("client_secret") != "secret" {
+			t.Errorf("got client credentials %q/%q, want id/secret", r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"))
+		}
+		w.Write([]byte(`{"access_token":"token-1","expires_in":28800,"refresh_token":"refresh"}`))
+	}))
+	defer srv.Close()
+
+	source := newOAuthTokenSource("id", "secret", "", srv.URL, nil)
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Value != "token-1" {
+			t.Errorf("got token %q, want token-1", token.Value)
+		}
+	}
+
+	if exchanges != 1 {
+		t.Errorf("got %d exchanges, want the valid token to be reused", exchanges)
+	}
+	if source.refreshToken != "refresh" {
+		t.Errorf("got refresh token %q, want the returned refresh token to be kept", source.refreshToken)
+	}
+}
+
+func TestOAuthTokenSourceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if _, err := newOAuthTokenSource("id", "secret", "", srv.URL, nil).Token(); err == nil {
+		t.Fatal("expected an error for rejected client credentials")
+	}
+}
+
+func TestProviderConfigureUsesOAuthToken(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"oauth-token","expires_in":28800}`))
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer oauth-token" {
+			t.Errorf("got Authorization %q, want the OAuth access token", got)
+		}
+		w.Write([]byte(`{"name":"some-app"}`))
+	}))
+	defer apiSrv.Close()
+
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, nil)
+	d.Set("api_key", "ignored")
+	d.Set("oauth_client_id", "id")
+	d.Set("oauth_client_secret", "secret")
+	d.Set("oauth_token_url", tokenSrv.URL)
+	d.Set("url", apiSrv.URL)
+
+	client, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := client.(*Config).Api
+	c.URL = apiSrv.URL
+
+	if _, err := c.AppInfo(context.Background(), "does-not-matter"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTokenTransportRetriesWithRefreshedAPIKey(t *testing.T) {
+	var authorizations []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, key, _ := r.BasicAuth()
+		authorizations = append(authorizations, key)
+		if key != "renewed" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"id":"unauthorized","message":"Invalid credentials provided."}`))
+			return
+		}
+		w.Write([]byte(`{"name":"some-app"}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.Email = "ops@example.com"
+	config.APIKey = "expired"
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+	config.tokenSource.(*apiKeyTokenSource).reload = func() (string, string, error) {
+		return "ops@example.com", "renewed", nil
+	}
+
+	if _, err := config.Api.AppUpdate(context.Background(), "some-app", heroku.AppUpdateOpts{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(authorizations) != 2 || authorizations[0] != "expired" || authorizations[1] != "renewed" {
+		t.Errorf("got API keys %v, want the rejected request to be retried once with the renewed key", authorizations)
+	}
+}
+
+func TestTokenTransportDoesNotRetryWithoutNewToken(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"id":"unauthorized","message":"Invalid credentials provided."}`))
+	}))
+	defer srv.Close()
+
+	config := NewConfig()
+	config.URL = srv.URL
+	config.APIKey = "expired"
+	if err := config.initializeAPI(); err != nil {
+		t.Fatal(err)
+	}
+	config.tokenSource.(*apiKeyTokenSource).reload = func() (string, string, error) {
+		return "", "expired", nil
+	}
+
+	if _, err := config.Api.AppInfo(context.Background(), "some-app"); err == nil {
+		t.Fatal("expected an unauthorized error")
+	}
+
+	if requests != 1 {
+		t.Errorf("got %d requests, want no retry with the same key", requests)
+	}
+}
@@ -2,10 +2,28 @@ package heroku
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
+var hostnameLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// webhookIncludeEntities are the entities whose events app webhooks can include.
+var webhookIncludeEntities = []string{
+	"api:addon-attachment",
+	"api:addon",
+	"api:app",
+	"api:build",
+	"api:collaborator",
+	"api:domain",
+	"api:dyno",
+	"api:formation",
+	"api:release",
+	"api:sni-endpoint",
+}
+
 // validateUUID matches type terraform.SchemaValidateFunc
 func validateUUID(val interface{}, key string) ([]string, []error) {
 	s, ok := val.(string)
@@ -17,3 +35,56 @@ func validateUUID(val interface{}, key string) ([]string, []error) {
 	}
 	return nil, nil
 }
+
+// validateDomainHostname matches type terraform.SchemaValidateFunc. Wildcard
+// hostnames are valid, but warn that ACM cannot issue certificates for them.
+func validateDomainHostname(val interface{}, key string) ([]string, []error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q is an invalid hostname: unable to assert %q to string", key, val)}
+	}
+	if len(s) > 253 {
+		return nil, []error{fmt.Errorf("%q is an invalid hostname: %q is longer than 253 characters", key, s)}
+	}
+
+	labels := strings.Split(strings.ToLower(s), ".")
+	if len(labels) < 2 {
+		return nil, []error{fmt.Errorf("%q is an invalid hostname: %q must have at least two labels, such as example.com", key, s)}
+	}
+
+	var warnings []string
+	if labels[0] == "*" {
+		labels = labels[1:]
+		warnings = append(warnings, fmt.Sprintf("%q is a wildcard hostname %q: Automated Certificate Management does not issue "+
+			"certificates for wildcard domains, so associate it with an SNI endpoint with a wildcard certificate using sni_endpoint_id", key, s))
+	}
+
+	for _, label := range labels {
+		if !hostnameLabelRegexp.MatchString(label) {
+			return nil, []error{fmt.Errorf("%q is an invalid hostname: label %q of %q must be 1-63 letters, digits or hyphens, "+
+				"and only the first label may be a wildcard (*)", key, label, s)}
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateWebhookInclude matches type terraform.SchemaValidateFunc. The entity must
+// be lowercase, as the API returns it lowercase and any other case causes a diff.
+func validateWebhookInclude(val interface{}, key string) ([]string, []error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q is an invalid webhook entity: unable to assert %q to string", key, val)}
+	}
+
+	for _, entity := range webhookIncludeEntities {
+		if s == entity {
+			return nil, nil
+		}
+		if strings.EqualFold(s, entity) {
+			return nil, []error{fmt.Errorf("%q is an invalid webhook entity %q, did you mean %q?", key, s, entity)}
+		}
+	}
+
+	return nil, []error{fmt.Errorf("%q is an invalid webhook entity %q, it must be one of: %s", key, s, strings.Join(webhookIncludeEntities, ", "))}
+}
@@ -25,3 +25,65 @@ func TestValidateUUID(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDomainHostname(t *testing.T) {
+	valid := []interface{}{
+		"example.com",
+		"www.Example.com",
+		"my-app.example.co.uk",
+	}
+	for _, v := range valid {
+		warnings, errors := validateDomainHostname(v, "hostname")
+		if len(errors) != 0 || len(warnings) != 0 {
+			t.Fatalf("%q should be a valid hostname: %q %q", v, warnings, errors)
+		}
+	}
+
+	wildcard := []interface{}{
+		"*.example.com",
+	}
+	for _, v := range wildcard {
+		warnings, errors := validateDomainHostname(v, "hostname")
+		if len(errors) != 0 || len(warnings) != 1 {
+			t.Fatalf("%q should be a valid hostname with a warning: %q %q", v, warnings, errors)
+		}
+	}
+
+	invalid := []interface{}{
+		"localhost",
+		"www.*.example.com",
+		"*example.com",
+		"-www.example.com",
+		"www..example.com",
+		"https://www.example.com",
+		1,
+	}
+	for _, v := range invalid {
+		_, errors := validateDomainHostname(v, "hostname")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid hostname", v)
+		}
+	}
+}
+
+func TestValidateWebhookInclude(t *testing.T) {
+	for _, v := range webhookIncludeEntities {
+		if _, errors := validateWebhookInclude(v, "include"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid webhook entity: %q", v, errors)
+		}
+	}
+
+	invalid := []interface{}{
+		"api:Release",
+		"release",
+		"api:config-vars",
+		"",
+		1,
+	}
+	for _, v := range invalid {
+		_, errors := validateWebhookInclude(v, "include")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid webhook entity", v)
+		}
+	}
+}